@@ -20,13 +20,14 @@ type cGen struct {
 	cGenDao
 	cGenEnums
 	cGenCtrl
+	cGenClient
 	cGenPb
 	cGenPbEntity
 	cGenService
 }
 
 const (
-	cGenBrief = `automatically generate go files for dao/do/entity/pb/pbentity`
+	cGenBrief = `automatically generate go files for dao/do/entity/pb/pbentity/client`
 	cGenDc    = `
 The "gen" command is designed for multiple generating purposes. 
 It's currently supporting generating go files for ORM models, protobuf and protobuf entity files.
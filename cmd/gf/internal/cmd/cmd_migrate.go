@@ -0,0 +1,220 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/database/gdb/migrate"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/gtag"
+
+	"github.com/gogf/gf/cmd/gf/v2/internal/utility/mlog"
+)
+
+var (
+	Migrate = cMigrate{}
+)
+
+type cMigrate struct {
+	g.Meta `name:"migrate" brief:"{cMigrateBrief}" dc:"{cMigrateDc}"`
+}
+
+const (
+	cMigrateBrief = `manage versioned database schema migrations`
+	cMigrateDc    = `
+The "migrate" command manages SQL migration files named like "{version}_{name}.up.sql" and "{version}_{name}.down.sql".
+It uses the "database" configuration of the configuration file in default, or the database link given by option "link".
+Please use "gf migrate up -h" for specified command help.
+`
+	cMigrateEg = `
+gf migrate create create_user
+gf migrate up
+gf migrate up -g order
+gf migrate down -n 2
+gf migrate to 20240102150405
+gf migrate status -l "mysql:root:12345678@tcp(127.0.0.1:3306)/test"
+`
+	cMigrateBriefPath  = `directory path of the migration files`
+	cMigrateBriefLink  = `database configuration, the same as the ORM configuration of GoFrame`
+	cMigrateBriefGroup = `database configuration group name`
+	cMigrateBriefTable = `table name for recording applied migrations`
+	cMigrateBriefSteps = `count of the latest applied migrations to revert`
+)
+
+func init() {
+	gtag.Sets(g.MapStrStr{
+		`cMigrateBrief`:      cMigrateBrief,
+		`cMigrateDc`:         cMigrateDc,
+		`cMigrateEg`:         cMigrateEg,
+		`cMigrateBriefPath`:  cMigrateBriefPath,
+		`cMigrateBriefLink`:  cMigrateBriefLink,
+		`cMigrateBriefGroup`: cMigrateBriefGroup,
+		`cMigrateBriefTable`: cMigrateBriefTable,
+		`cMigrateBriefSteps`: cMigrateBriefSteps,
+	})
+}
+
+// cMigrateBaseInput is the common input for migrate commands.
+type cMigrateBaseInput struct {
+	Path  string `name:"path"  short:"p" brief:"{cMigrateBriefPath}" d:"manifest/migration"`
+	Link  string `name:"link"  short:"l" brief:"{cMigrateBriefLink}"`
+	Group string `name:"group" short:"g" brief:"{cMigrateBriefGroup}" d:"default"`
+	Table string `name:"table" short:"t" brief:"{cMigrateBriefTable}" d:"gf_schema_migrations"`
+}
+
+type cMigrateCreateInput struct {
+	g.Meta `name:"create" brief:"create empty up and down sql migration files" eg:"{cMigrateEg}"`
+	Name   string `name:"NAME" arg:"true" v:"required" brief:"name of the migration, like: create_user"`
+	Path   string `name:"path" short:"p"  brief:"{cMigrateBriefPath}" d:"manifest/migration"`
+}
+
+type cMigrateCreateOutput struct{}
+
+type cMigrateUpInput struct {
+	g.Meta `name:"up" brief:"apply all pending migrations" eg:"{cMigrateEg}"`
+	cMigrateBaseInput
+}
+
+type cMigrateUpOutput struct{}
+
+type cMigrateDownInput struct {
+	g.Meta `name:"down" brief:"revert the latest applied migrations" eg:"{cMigrateEg}"`
+	cMigrateBaseInput
+	Steps int `name:"steps" short:"n" brief:"{cMigrateBriefSteps}" d:"1"`
+}
+
+type cMigrateDownOutput struct{}
+
+type cMigrateToInput struct {
+	g.Meta  `name:"to" brief:"migrate database to specified version" eg:"{cMigrateEg}"`
+	Version string `name:"VERSION" arg:"true" v:"required" brief:"target version of the migration"`
+	cMigrateBaseInput
+}
+
+type cMigrateToOutput struct{}
+
+type cMigrateStatusInput struct {
+	g.Meta `name:"status" brief:"show status of all migrations" eg:"{cMigrateEg}"`
+	cMigrateBaseInput
+}
+
+type cMigrateStatusOutput struct{}
+
+func (c cMigrate) Create(ctx context.Context, in cMigrateCreateInput) (out *cMigrateCreateOutput, err error) {
+	upFile, downFile, err := migrate.CreateSQLFiles(in.Path, in.Name)
+	if err != nil {
+		mlog.Fatalf(`create migration files failed: %+v`, err)
+	}
+	mlog.Print(`created:`, upFile)
+	mlog.Print(`created:`, downFile)
+	return
+}
+
+func (c cMigrate) Up(ctx context.Context, in cMigrateUpInput) (out *cMigrateUpOutput, err error) {
+	versions, err := c.getMigrator(in.cMigrateBaseInput).Up(ctx)
+	c.printVersions(`applied`, versions)
+	if err != nil {
+		mlog.Fatalf(`%+v`, err)
+	}
+	if len(versions) == 0 {
+		mlog.Print(`no pending migration`)
+	}
+	return
+}
+
+func (c cMigrate) Down(ctx context.Context, in cMigrateDownInput) (out *cMigrateDownOutput, err error) {
+	versions, err := c.getMigrator(in.cMigrateBaseInput).Down(ctx, in.Steps)
+	c.printVersions(`reverted`, versions)
+	if err != nil {
+		mlog.Fatalf(`%+v`, err)
+	}
+	if len(versions) == 0 {
+		mlog.Print(`no applied migration`)
+	}
+	return
+}
+
+func (c cMigrate) To(ctx context.Context, in cMigrateToInput) (out *cMigrateToOutput, err error) {
+	versions, err := c.getMigrator(in.cMigrateBaseInput).To(ctx, in.Version)
+	c.printVersions(`migrated`, versions)
+	if err != nil {
+		mlog.Fatalf(`%+v`, err)
+	}
+	if len(versions) == 0 {
+		mlog.Printf(`database is already at version "%s"`, in.Version)
+	}
+	return
+}
+
+func (c cMigrate) Status(ctx context.Context, in cMigrateStatusInput) (out *cMigrateStatusOutput, err error) {
+	statuses, err := c.getMigrator(in.cMigrateBaseInput).Status(ctx)
+	if err != nil {
+		mlog.Fatalf(`%+v`, err)
+	}
+	var data = make([][]string, 0, len(statuses))
+	for _, status := range statuses {
+		var state = `pending`
+		switch {
+		case status.Missing:
+			state = `missing`
+		case status.Applied:
+			state = `applied`
+		}
+		data = append(data, []string{status.Version, status.Name, state, status.AppliedAt})
+	}
+	buffer := bytes.NewBuffer(nil)
+	table := tablewriter.NewTable(buffer)
+	table.Header("VERSION", "NAME", "STATUS", "APPLIED AT")
+	table.Bulk(data)
+	table.Render()
+	mlog.Print(buffer.String())
+	return
+}
+
+// getMigrator creates and returns the Migrator with migration files loaded.
+func (c cMigrate) getMigrator(in cMigrateBaseInput) *migrate.Migrator {
+	var (
+		db  gdb.DB
+		err error
+	)
+	// It uses user passed database configuration.
+	if in.Link != "" {
+		var tempGroup = gtime.TimestampNanoStr()
+		err = gdb.AddConfigNode(tempGroup, gdb.ConfigNode{
+			Link: in.Link,
+		})
+		if err != nil {
+			mlog.Fatalf(`database configuration failed: %+v`, err)
+		}
+		if db, err = gdb.Instance(tempGroup); err != nil {
+			mlog.Fatalf(`database initialization failed: %+v`, err)
+		}
+	} else {
+		db = g.DB(in.Group)
+	}
+	if db == nil {
+		mlog.Fatal(`database initialization failed, may be invalid database configuration`)
+	}
+	m := migrate.New(db, migrate.Config{Table: in.Table})
+	if err = m.LoadDir(in.Path); err != nil {
+		mlog.Fatalf(`load migration files failed: %+v`, err)
+	}
+	return m
+}
+
+// printVersions prints the migration `versions` with `action`.
+func (c cMigrate) printVersions(action string, versions []string) {
+	for _, version := range versions {
+		mlog.Printf(`%s: %s`, action, version)
+	}
+}
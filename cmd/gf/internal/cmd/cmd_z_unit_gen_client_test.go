@@ -0,0 +1,76 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+	"github.com/gogf/gf/v2/util/gutil"
+
+	"github.com/gogf/gf/cmd/gf/v2/internal/cmd/genclient"
+)
+
+func Test_Gen_Client_Default(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path      = gfile.Temp(guid.S())
+			tsPath    = filepath.Join(path, "ts")
+			goPath    = filepath.Join(path, "sdk")
+			apiFolder = gtest.DataPath("genclient", "api")
+			in        = genclient.CGenClientInput{
+				SrcFolder: apiFolder,
+				TsPath:    tsPath,
+				GoPath:    goPath,
+			}
+		)
+		err := gutil.FillStructWithDefault(&in)
+		t.AssertNil(err)
+
+		err = gfile.Mkdir(path)
+		t.AssertNil(err)
+		defer gfile.Remove(path)
+
+		_, err = genclient.CGenClient{}.Client(ctx, in)
+		t.AssertNil(err)
+
+		// TypeScript files.
+		files, err := gfile.ScanDir(tsPath, "*.ts")
+		t.AssertNil(err)
+		t.Assert(files, []string{
+			filepath.Join(tsPath, "article_v1.ts"),
+			filepath.Join(tsPath, "client.ts"),
+			filepath.Join(tsPath, "index.ts"),
+			filepath.Join(tsPath, "user_v1.ts"),
+		})
+		for _, file := range files {
+			t.Assert(
+				gfile.GetContents(file),
+				gfile.GetContents(gtest.DataPath("genclient", "ts", gfile.Basename(file))),
+			)
+		}
+
+		// Go files.
+		files, err = gfile.ScanDir(goPath, "*.go")
+		t.AssertNil(err)
+		t.Assert(files, []string{
+			filepath.Join(goPath, "sdk.go"),
+			filepath.Join(goPath, "sdk.iclient.go"),
+			filepath.Join(goPath, "sdk_article_v1.go"),
+			filepath.Join(goPath, "sdk_user_v1.go"),
+		})
+		for _, file := range files {
+			t.Assert(
+				gfile.GetContents(file),
+				gfile.GetContents(gtest.DataPath("genclient", "sdk", gfile.Basename(file))),
+			)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package genclient
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/util/gtag"
+
+	"github.com/gogf/gf/cmd/gf/v2/internal/utility/mlog"
+)
+
+const (
+	CGenClientConfig = `gfcli.gen.client`
+	CGenClientUsage  = `gf gen client [OPTION]`
+	CGenClientBrief  = `parse api definitions to generate typed TypeScript/Go client sdk files`
+	CGenClientEg     = `
+gf gen client -t web/src/api
+gf gen client -g sdk
+gf gen client -s api -t web/src/api -g sdk
+`
+	CGenClientAd = `
+The api definitions are the request/response structs in api folder, which are parsed in the same way of
+OpenAPI specification generating of package goai:
+1. The request parameters are placed in path/query/header/cookie/body by "in" tag of struct field,
+   or in path if its name is in route path, or in query for GET/DELETE/HEAD method, or in body by default.
+2. The enum types are the constants of named types, which are the same as "gf gen enums".
+3. The error codes are the builtin codes of package gcode and the codes defined using "gcode.New" in project.
+`
+	CGenClientBriefSrcFolder = `source folder path to be parsed. default: api`
+	CGenClientBriefTsPath    = `destination folder path storing generated TypeScript files`
+	CGenClientBriefGoPath    = `destination folder path storing generated go sdk files`
+)
+
+func init() {
+	gtag.Sets(g.MapStrStr{
+		`CGenClientConfig`:         CGenClientConfig,
+		`CGenClientUsage`:          CGenClientUsage,
+		`CGenClientBrief`:          CGenClientBrief,
+		`CGenClientEg`:             CGenClientEg,
+		`CGenClientAd`:             CGenClientAd,
+		`CGenClientBriefSrcFolder`: CGenClientBriefSrcFolder,
+		`CGenClientBriefTsPath`:    CGenClientBriefTsPath,
+		`CGenClientBriefGoPath`:    CGenClientBriefGoPath,
+	})
+}
+
+type (
+	CGenClient      struct{}
+	CGenClientInput struct {
+		g.Meta    `name:"client" config:"{CGenClientConfig}" usage:"{CGenClientUsage}" brief:"{CGenClientBrief}" eg:"{CGenClientEg}" ad:"{CGenClientAd}"`
+		SrcFolder string `short:"s" name:"srcFolder" brief:"{CGenClientBriefSrcFolder}" d:"api"`
+		TsPath    string `short:"t" name:"tsPath"    brief:"{CGenClientBriefTsPath}"`
+		GoPath    string `short:"g" name:"goPath"    brief:"{CGenClientBriefGoPath}"`
+	}
+	CGenClientOutput struct{}
+)
+
+func (c CGenClient) Client(ctx context.Context, in CGenClientInput) (out *CGenClientOutput, err error) {
+	if in.TsPath == "" && in.GoPath == "" {
+		mlog.Fatal(`either TypeScript path or go path should be specified for client generating`)
+	}
+	if !gfile.Exists(in.SrcFolder) {
+		mlog.Fatalf(`source folder path "%s" does not exist`, in.SrcFolder)
+	}
+	mlog.Printf(`scanning api definitions: %s`, gfile.RealPath(in.SrcFolder))
+	definition, err := newApiParser().Parse(gfile.RealPath(in.SrcFolder))
+	if err != nil {
+		return nil, err
+	}
+	if len(definition.Modules) == 0 {
+		mlog.Print(`no api definitions found`)
+		return
+	}
+	if in.TsPath != "" {
+		if err = newTsGenerator(definition).Generate(in.TsPath); err != nil {
+			return nil, err
+		}
+	}
+	if in.GoPath != "" {
+		if err = newGoGenerator(definition).Generate(in.GoPath); err != nil {
+			return nil, err
+		}
+	}
+	mlog.Print(`done!`)
+	return
+}
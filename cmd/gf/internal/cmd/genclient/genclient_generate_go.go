@@ -0,0 +1,121 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package genclient
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/text/gstr"
+
+	"github.com/gogf/gf/cmd/gf/v2/internal/consts"
+	"github.com/gogf/gf/cmd/gf/v2/internal/utility/mlog"
+)
+
+type goGenerator struct {
+	definition *apiDefinition
+}
+
+func newGoGenerator(definition *apiDefinition) *goGenerator {
+	return &goGenerator{
+		definition: definition,
+	}
+}
+
+// Generate generates the go client files to `folderPath`, of which the package name is the folder name.
+// The generated client sends requests using package httpclient, which places the request parameters
+// by their "in" tags and returns errors with the business error code of response.
+func (c *goGenerator) Generate(folderPath string) (err error) {
+	var pkgName = gfile.Basename(folderPath)
+	if err = c.doGeneratePkgFile(folderPath, pkgName); err != nil {
+		return
+	}
+	if err = c.doGenerateIClientFile(folderPath, pkgName); err != nil {
+		return
+	}
+	for _, module := range c.definition.Modules {
+		if err = c.doGenerateImplementerFile(folderPath, pkgName, module); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (c *goGenerator) doGeneratePkgFile(folderPath, pkgName string) (err error) {
+	var filePath = gfile.Join(folderPath, fmt.Sprintf(`%s.go`, pkgName))
+	if gfile.Exists(filePath) {
+		return nil
+	}
+	fileContent := gstr.TrimLeft(gstr.ReplaceByMap(consts.TemplateGenClientGoPkgNew, g.MapStrStr{
+		"{PkgName}": pkgName,
+	}))
+	return c.putGoFile(filePath, fileContent)
+}
+
+func (c *goGenerator) doGenerateIClientFile(folderPath, pkgName string) (err error) {
+	var (
+		filePath  = gfile.Join(folderPath, fmt.Sprintf(`%s.iclient.go`, pkgName))
+		functions = make([]string, 0, len(c.definition.Modules))
+	)
+	for _, module := range c.definition.Modules {
+		functions = append(functions, fmt.Sprintf("\t%s() I%s", module.ClientName(), module.ClientName()))
+	}
+	fileContent := gstr.TrimLeft(gstr.ReplaceByMap(consts.TemplateGenClientGoIClient, g.MapStrStr{
+		"{PkgName}":            pkgName,
+		"{InterfaceFunctions}": gstr.Join(functions, "\n"),
+	}))
+	return c.putGoFile(filePath, fileContent)
+}
+
+func (c *goGenerator) doGenerateImplementerFile(folderPath, pkgName string, module *apiModule) (err error) {
+	var (
+		filePath        = gfile.Join(folderPath, fmt.Sprintf(`%s_%s.go`, pkgName, module.FileName()))
+		implementerName = module.ClientName()
+		pkgAlias        = module.Package.Name()
+		interfaceFuncs  = make([]string, 0, len(module.Apis))
+		buffer          = bytes.NewBuffer(nil)
+	)
+	for _, item := range module.Apis {
+		var methodComment = fmt.Sprintf("// %s %s\n// %s %s", item.Name, item.GetComment(), item.Method, item.Path)
+		if item.GetComment() == "" {
+			methodComment = fmt.Sprintf("// %s %s %s", item.Name, item.Method, item.Path)
+		}
+		interfaceFuncs = append(interfaceFuncs, fmt.Sprintf(
+			"\t%s(ctx context.Context, req *%s.%sReq) (res *%s.%sRes, err error)",
+			item.Name, pkgAlias, item.Name, pkgAlias, item.Name,
+		))
+		buffer.WriteString(gstr.ReplaceByMap(consts.TemplateGenClientGoImplementerFunc, g.MapStrStr{
+			"{MethodComment}":   methodComment,
+			"{MethodName}":      item.Name,
+			"{ImplementerName}": implementerName,
+			"{PkgAlias}":        pkgAlias,
+		}))
+	}
+	fileContent := gstr.TrimLeft(gstr.ReplaceByMap(consts.TemplateGenClientGoImplementer, g.MapStrStr{
+		"{PkgName}":            pkgName,
+		"{ImportPath}":         module.Import,
+		"{ImplementerName}":    implementerName,
+		"{InterfaceFunctions}": gstr.Join(interfaceFuncs, "\n"),
+	}))
+	return c.putGoFile(filePath, fileContent+buffer.String())
+}
+
+// putGoFile formats and writes go file content.
+func (c *goGenerator) putGoFile(filePath, fileContent string) (err error) {
+	formatted, err := format.Source([]byte(fileContent))
+	if err != nil {
+		return err
+	}
+	if err = gfile.PutBytes(filePath, formatted); err != nil {
+		return
+	}
+	mlog.Printf(`generated: %s`, gfile.RealPath(filePath))
+	return
+}
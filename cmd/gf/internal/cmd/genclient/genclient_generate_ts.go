@@ -0,0 +1,522 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package genclient
+
+import (
+	"bytes"
+	"fmt"
+	"go/constant"
+	"go/types"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/text/gregex"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gtag"
+
+	"github.com/gogf/gf/cmd/gf/v2/internal/cmd/genenums"
+	"github.com/gogf/gf/cmd/gf/v2/internal/consts"
+	"github.com/gogf/gf/cmd/gf/v2/internal/utility/mlog"
+)
+
+const (
+	tsFileClient = `client.ts`
+	tsFileIndex  = `index.ts`
+	tsTypeAny    = `unknown`
+
+	paramInPath   = `path`
+	paramInQuery  = `query`
+	paramInHeader = `header`
+	paramInCookie = `cookie`
+	paramInBody   = `body`
+)
+
+// tsSpecialTypes is the TypeScript types for golang types that are not converted by their definitions.
+var tsSpecialTypes = map[string]string{
+	`time.Time`:                                  `string`,
+	`time.Duration`:                              `number`,
+	`encoding/json.RawMessage`:                   tsTypeAny,
+	`github.com/gogf/gf/v2/os/gtime.Time`:        `string`,
+	`github.com/gogf/gf/v2/encoding/gjson.Json`:  tsTypeAny,
+	`github.com/gogf/gf/v2/container/gvar.Var`:   tsTypeAny,
+	`github.com/gogf/gf/v2/net/ghttp.UploadFile`: tsTypeAny,
+}
+
+// tsParamOrder is the order of parameter placements in generated request functions.
+var tsParamOrder = []string{paramInPath, paramInQuery, paramInHeader, paramInCookie, paramInBody}
+
+type tsGenerator struct {
+	definition *apiDefinition
+}
+
+// tsModuleWriter writes the TypeScript definitions of types referenced by an api module.
+type tsModuleWriter struct {
+	definition  *apiDefinition
+	declared    map[string]string // Declared type names, the key is the full golang type name.
+	requests    map[string]bool   // Request struct types, the key is the full golang type name.
+	usedNames   map[string]bool   // Used TypeScript type names.
+	definitions []string          // Declared TypeScript definitions in order.
+}
+
+// tsField is a field of TypeScript interface.
+type tsField struct {
+	Name     string // Property name.
+	Type     string // TypeScript type.
+	Optional bool   // Whether the property is optional.
+	Comment  string // Comment of property.
+	In       string // Parameter placement, which is only used for request fields.
+}
+
+func newTsGenerator(definition *apiDefinition) *tsGenerator {
+	return &tsGenerator{
+		definition: definition,
+	}
+}
+
+// Generate generates the TypeScript client files to `folderPath`.
+func (c *tsGenerator) Generate(folderPath string) (err error) {
+	if err = c.doGenerateClientFile(folderPath); err != nil {
+		return
+	}
+	for _, module := range c.definition.Modules {
+		if err = c.doGenerateModuleFile(folderPath, module); err != nil {
+			return
+		}
+	}
+	return c.doGenerateIndexFile(folderPath)
+}
+
+func (c *tsGenerator) doGenerateClientFile(folderPath string) (err error) {
+	var (
+		filePath   = gfile.Join(folderPath, tsFileClient)
+		errorCodes = make([]string, 0, len(c.definition.ErrorCodes))
+	)
+	for _, item := range c.definition.ErrorCodes {
+		var line = fmt.Sprintf(`  %s: %d,`, item.Name, item.Code)
+		if item.Message != "" {
+			line = fmt.Sprintf("  /** %s */\n%s", tsCommentText(item.Message), line)
+		}
+		errorCodes = append(errorCodes, line)
+	}
+	fileContent := gstr.TrimLeft(gstr.ReplaceByMap(consts.TemplateGenClientTsClient, g.MapStrStr{
+		"{ErrorCodes}": gstr.Join(errorCodes, "\n"),
+	}))
+	if err = gfile.PutContents(filePath, fileContent); err != nil {
+		return
+	}
+	mlog.Printf(`generated: %s`, gfile.RealPath(filePath))
+	return
+}
+
+func (c *tsGenerator) doGenerateModuleFile(folderPath string, module *apiModule) (err error) {
+	var (
+		filePath  = gfile.Join(folderPath, module.FileName()+".ts")
+		writer    = newTsModuleWriter(c.definition)
+		functions = bytes.NewBuffer(nil)
+	)
+	for _, item := range module.Apis {
+		writer.requests[types.TypeString(item.Req, nil)] = true
+		var (
+			reqName  = writer.TypeOf(item.Req)
+			resName  = writer.TypeOf(item.Res)
+			params   = make(map[string][]string)
+			reqField = writer.RequestFields(item)
+			reqParam = fmt.Sprintf(`req: %s = {}`, reqName)
+		)
+		for _, field := range reqField {
+			params[field.In] = append(params[field.In], fmt.Sprintf(
+				`%s: req%s`, tsPropertyName(field.Name), tsPropertyAccess(field.Name),
+			))
+			if !field.Optional {
+				// The request object is not optional if it has required fields.
+				reqParam = fmt.Sprintf(`req: %s`, reqName)
+			}
+		}
+		if len(reqField) == 0 {
+			reqParam = fmt.Sprintf(`_req: %s = {}`, reqName)
+		}
+		functions.WriteString("\n")
+		functions.WriteString(tsComment("  ", item.Name+" "+item.GetComment(), item.Method+" "+item.Path))
+		functions.WriteString(fmt.Sprintf(
+			"  %s(%s, init?: RequestInit): Promise<%s> {\n",
+			gstr.CaseCamelLower(item.Name), reqParam, resName,
+		))
+		functions.WriteString(fmt.Sprintf(
+			"    return this.client.request<%s>('%s', '%s', {\n", resName, item.Method, item.Path,
+		))
+		for _, in := range tsParamOrder {
+			if len(params[in]) == 0 {
+				continue
+			}
+			functions.WriteString(fmt.Sprintf("      %s: { %s },\n", in, gstr.Join(params[in], ", ")))
+		}
+		functions.WriteString("    }, init);\n")
+		functions.WriteString("  }\n")
+	}
+	fileContent := gstr.TrimLeft(gstr.ReplaceByMap(consts.TemplateGenClientTsModule, g.MapStrStr{
+		"{Definitions}": writer.String(),
+		"{ModuleName}":  module.ClientName(),
+		"{Functions}":   functions.String(),
+	}))
+	if err = gfile.PutContents(filePath, fileContent); err != nil {
+		return
+	}
+	mlog.Printf(`generated: %s`, gfile.RealPath(filePath))
+	return
+}
+
+func (c *tsGenerator) doGenerateIndexFile(folderPath string) (err error) {
+	var (
+		filePath = gfile.Join(folderPath, tsFileIndex)
+		imports  = make([]string, 0)
+		exports  = make([]string, 0)
+		modules  = make([]string, 0)
+	)
+	for _, module := range c.definition.Modules {
+		var (
+			clientName = module.ClientName()
+			varName    = gstr.CaseCamelLower(clientName)
+		)
+		imports = append(imports, fmt.Sprintf(`import { %s } from './%s';`, clientName, module.FileName()))
+		exports = append(exports, fmt.Sprintf(`export * as %s from './%s';`, varName, module.FileName()))
+		modules = append(modules, fmt.Sprintf(`    %s: new %s(client),`, varName, clientName))
+	}
+	fileContent := gstr.TrimLeft(gstr.ReplaceByMap(consts.TemplateGenClientTsIndex, g.MapStrStr{
+		"{Imports}": gstr.Join(imports, "\n"),
+		"{Exports}": gstr.Join(exports, "\n"),
+		"{Modules}": gstr.Join(modules, "\n"),
+	}))
+	if err = gfile.PutContents(filePath, fileContent); err != nil {
+		return
+	}
+	mlog.Printf(`generated: %s`, gfile.RealPath(filePath))
+	return
+}
+
+func newTsModuleWriter(definition *apiDefinition) *tsModuleWriter {
+	return &tsModuleWriter{
+		definition: definition,
+		declared:   make(map[string]string),
+		requests:   make(map[string]bool),
+		usedNames:  make(map[string]bool),
+	}
+}
+
+// String returns all the declared definitions.
+func (w *tsModuleWriter) String() string {
+	if len(w.definitions) == 0 {
+		return ""
+	}
+	return "\n" + gstr.Join(w.definitions, "\n")
+}
+
+// RequestFields returns the fields of request struct of api `item` with their parameter placements,
+// which follows the same rules as package goai generating the OpenAPI specification.
+func (w *tsModuleWriter) RequestFields(item *apiItem) []tsField {
+	structType, ok := item.Req.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	fields := w.structFields(structType, true)
+	for i, field := range fields {
+		if field.In != "" {
+			continue
+		}
+		switch {
+		case gstr.ContainsI(item.Path, fmt.Sprintf(`{%s}`, field.Name)):
+			fields[i].In = paramInPath
+		case item.Method == http.MethodGet || item.Method == http.MethodDelete || item.Method == http.MethodHead:
+			fields[i].In = paramInQuery
+		default:
+			fields[i].In = paramInBody
+		}
+	}
+	return fields
+}
+
+// TypeOf returns the TypeScript type of golang type `t`, it declares the referenced named types.
+func (w *tsModuleWriter) TypeOf(t types.Type) string {
+	switch v := types.Unalias(t).(type) {
+	case *types.Pointer:
+		return w.TypeOf(v.Elem())
+
+	case *types.Named:
+		return w.declareNamed(v)
+
+	case *types.Basic:
+		switch {
+		case v.Info()&types.IsBoolean != 0:
+			return "boolean"
+		case v.Info()&types.IsNumeric != 0:
+			return "number"
+		case v.Info()&types.IsString != 0:
+			return "string"
+		}
+		return tsTypeAny
+
+	case *types.Slice:
+		return w.arrayOf(v.Elem())
+
+	case *types.Array:
+		return w.arrayOf(v.Elem())
+
+	case *types.Map:
+		return fmt.Sprintf(`Record<string, %s>`, w.TypeOf(v.Elem()))
+
+	case *types.Struct:
+		var (
+			fields = w.structFields(v, false)
+			array  = make([]string, 0, len(fields))
+		)
+		for _, field := range fields {
+			array = append(array, fmt.Sprintf(`%s: %s`, tsPropertyDeclaration(field), field.Type))
+		}
+		if len(array) == 0 {
+			return "Record<string, never>"
+		}
+		return fmt.Sprintf(`{ %s }`, gstr.Join(array, "; "))
+	}
+	return tsTypeAny
+}
+
+// arrayOf returns the TypeScript array type of golang element type `elem`.
+// The byte slice is string, as it is encoded in base64 in json.
+func (w *tsModuleWriter) arrayOf(elem types.Type) string {
+	if basic, ok := types.Unalias(elem).(*types.Basic); ok && basic.Kind() == types.Byte {
+		return "string"
+	}
+	elemType := w.TypeOf(elem)
+	if gstr.ContainsAny(elemType, " |&") {
+		return fmt.Sprintf(`(%s)[]`, elemType)
+	}
+	return elemType + "[]"
+}
+
+// declareNamed declares the named type `named` and returns its TypeScript type name.
+func (w *tsModuleWriter) declareNamed(named *types.Named) string {
+	var (
+		obj      = named.Obj()
+		fullName = types.TypeString(named, nil)
+	)
+	if obj.Pkg() == nil {
+		// Builtin types like error.
+		return tsTypeAny
+	}
+	originName := obj.Pkg().Path() + "." + obj.Name()
+	if tsType, ok := tsSpecialTypes[originName]; ok {
+		return tsType
+	}
+	if name, ok := w.declared[fullName]; ok {
+		return name
+	}
+	// Declares the name first for recursive types.
+	name := w.uniqueName(named)
+	w.declared[fullName] = name
+
+	var (
+		definition string
+		comment    = tsComment("", w.definition.Comments[originName])
+	)
+	if enums, ok := w.definition.Enums[fullName]; ok && len(enums) > 0 {
+		definition = w.enumDefinition(name, enums)
+	} else if structType, ok := named.Underlying().(*types.Struct); ok {
+		var buffer = bytes.NewBuffer(nil)
+		buffer.WriteString(fmt.Sprintf("export interface %s {\n", name))
+		for _, field := range w.structFields(structType, w.requests[fullName]) {
+			buffer.WriteString(tsComment("  ", field.Comment))
+			buffer.WriteString(fmt.Sprintf("  %s: %s;\n", tsPropertyDeclaration(field), field.Type))
+		}
+		buffer.WriteString("}\n")
+		definition = buffer.String()
+	} else {
+		definition = fmt.Sprintf("export type %s = %s;\n", name, w.TypeOf(named.Underlying()))
+	}
+	w.definitions = append(w.definitions, comment+definition)
+	return name
+}
+
+// enumDefinition returns the definition of enum type `name`, which is a union type and a constant object
+// of the same name, so that the enum values can be used like: Status.StatusEnabled.
+func (w *tsModuleWriter) enumDefinition(name string, enums []genenums.EnumItem) string {
+	var (
+		values    = make([]string, 0, len(enums))
+		valueSet  = make(map[string]struct{})
+		constants = bytes.NewBuffer(nil)
+	)
+	for _, item := range enums {
+		var value = item.Value
+		switch item.Kind {
+		case constant.String:
+			value = strconv.Quote(item.Value)
+		case constant.Int, constant.Float, constant.Bool:
+		default:
+			continue
+		}
+		if _, ok := valueSet[value]; !ok {
+			valueSet[value] = struct{}{}
+			values = append(values, value)
+		}
+		constants.WriteString(fmt.Sprintf("  %s: %s,\n", item.Name, value))
+	}
+	if len(values) == 0 {
+		return fmt.Sprintf("export type %s = %s;\n", name, tsTypeAny)
+	}
+	return fmt.Sprintf(
+		"export type %s = %s;\nexport const %s = {\n%s} as const;\n",
+		name, gstr.Join(values, " | "), name, constants.String(),
+	)
+}
+
+// uniqueName returns an unused TypeScript type name for named type `named`.
+// The package name is used as prefix if the type name is used by type of other package.
+func (w *tsModuleWriter) uniqueName(named *types.Named) string {
+	var name = named.Obj().Name()
+	if typeArgs := named.TypeArgs(); typeArgs != nil {
+		for i := 0; i < typeArgs.Len(); i++ {
+			argName, _ := gregex.ReplaceString(`[^\w]`, "", w.TypeOf(typeArgs.At(i)))
+			name += gstr.UcFirst(argName)
+		}
+	}
+	if w.usedNames[name] {
+		name = gstr.CaseCamel(named.Obj().Pkg().Name()) + name
+	}
+	for i := 2; w.usedNames[name]; i++ {
+		name = fmt.Sprintf(`%s%d`, gstr.TrimRight(name, "0123456789"), i)
+	}
+	w.usedNames[name] = true
+	return name
+}
+
+// structFields returns the TypeScript fields of struct `structType`, the fields of embedded structs
+// without name tag are merged into current level just like json encoding.
+// The parameter `isRequest` specifies whether it is request struct, whose field is named using
+// priority tags like converting request parameters, and is optional if it has no "required" rule.
+func (w *tsModuleWriter) structFields(structType *types.Struct, isRequest bool) []tsField {
+	var (
+		fields  = make([]tsField, 0)
+		nameSet = make(map[string]struct{})
+	)
+	for i := 0; i < structType.NumFields(); i++ {
+		var (
+			field = structType.Field(i)
+			tag   = reflect.StructTag(structType.Tag(i))
+		)
+		if !field.Exported() || isMetaType(field.Type()) {
+			continue
+		}
+		var (
+			nameTag  = tag.Get(gtag.Json)
+			tagArray []string
+		)
+		if isRequest {
+			nameTag = getTagValue(tag, gtag.StructTagPriority...)
+		}
+		tagArray = gstr.SplitAndTrim(nameTag, ",")
+		var name = field.Name()
+		if len(tagArray) > 0 && tagArray[0] != "" {
+			name = tagArray[0]
+		}
+		if name == "-" {
+			continue
+		}
+		if field.Embedded() && (len(tagArray) == 0 || tagArray[0] == "") {
+			embeddedType := types.Unalias(field.Type())
+			if pointer, ok := embeddedType.(*types.Pointer); ok {
+				embeddedType = pointer.Elem()
+			}
+			if embeddedStruct, ok := embeddedType.Underlying().(*types.Struct); ok {
+				// The fields of current level have higher priority.
+				for _, embeddedField := range w.structFields(embeddedStruct, isRequest) {
+					if _, ok = nameSet[embeddedField.Name]; !ok {
+						nameSet[embeddedField.Name] = struct{}{}
+						fields = append(fields, embeddedField)
+					}
+				}
+				continue
+			}
+		}
+		var item = tsField{
+			Name:    name,
+			Type:    w.TypeOf(field.Type()),
+			Comment: getTagValue(tag, gtag.Description, gtag.DescriptionShort, gtag.DescriptionShort2),
+			In:      tag.Get(gtag.In),
+		}
+		if isRequest {
+			item.Optional = item.In != paramInPath && !isRequiredRules(getTagValue(tag, gtag.Valid, gtag.ValidShort))
+		} else {
+			_, isPointer := types.Unalias(field.Type()).(*types.Pointer)
+			item.Optional = isPointer || gstr.InArray(tagArray, "omitempty")
+		}
+		if _, ok := nameSet[name]; ok {
+			// Replace the field of embedded struct.
+			for j := range fields {
+				if fields[j].Name == name {
+					fields[j] = item
+				}
+			}
+			continue
+		}
+		nameSet[name] = struct{}{}
+		fields = append(fields, item)
+	}
+	return fields
+}
+
+// tsPropertyDeclaration returns the property declaration of `field` in interface.
+func tsPropertyDeclaration(field tsField) string {
+	if field.Optional {
+		return tsPropertyName(field.Name) + "?"
+	}
+	return tsPropertyName(field.Name)
+}
+
+// tsPropertyName returns the property name that can be used in TypeScript object literal.
+func tsPropertyName(name string) string {
+	if gregex.IsMatchString(`^[A-Za-z_$][\w$]*$`, name) {
+		return name
+	}
+	return "'" + gstr.Replace(name, "'", `\'`) + "'"
+}
+
+// tsPropertyAccess returns the property access expression of `name`, eg: .name or ['x-name'].
+func tsPropertyAccess(name string) string {
+	if gregex.IsMatchString(`^[A-Za-z_$][\w$]*$`, name) {
+		return "." + name
+	}
+	return "[" + tsPropertyName(name) + "]"
+}
+
+// tsComment returns the JSDoc comment of non-empty `lines` with indent `indent`.
+func tsComment(indent string, lines ...string) string {
+	var array = make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line = gstr.Trim(line); line != "" {
+			array = append(array, tsCommentText(line))
+		}
+	}
+	switch len(array) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("%s/** %s */\n", indent, array[0])
+	}
+	var buffer = bytes.NewBuffer(nil)
+	buffer.WriteString(indent + "/**\n")
+	for _, line := range array {
+		buffer.WriteString(fmt.Sprintf("%s * %s\n", indent, line))
+	}
+	buffer.WriteString(indent + " */\n")
+	return buffer.String()
+}
+
+// tsCommentText escapes the text for JSDoc comment.
+func tsCommentText(text string) string {
+	return gstr.Replace(text, "*/", `*\/`)
+}
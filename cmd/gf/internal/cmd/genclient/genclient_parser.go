@@ -0,0 +1,417 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package genclient
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gtag"
+
+	"github.com/gogf/gf/cmd/gf/v2/internal/cmd/genenums"
+	"github.com/gogf/gf/cmd/gf/v2/internal/utility/mlog"
+)
+
+const (
+	pkgLoadMode     = 0xffffff
+	metaTypeName    = `github.com/gogf/gf/v2/util/gmeta.Meta`
+	gcodePkgPath    = `github.com/gogf/gf/v2/errors/gcode`
+	gcodeNewFunc    = `New`
+	validRequired   = `required`
+	defaultMethod   = `GET`
+	reqStructSuffix = `Req`
+	resStructSuffix = `Res`
+)
+
+// apiDefinition is the parsed api definitions of project.
+type apiDefinition struct {
+	Modules    []*apiModule                   // Api modules sorted by name and version.
+	Enums      map[string][]genenums.EnumItem // Enum items, the key is the full type name like "demo.com/api/user/v1.Status".
+	Comments   map[string]string              // Type comments of api packages, the key is the full type name.
+	ErrorCodes []apiErrorCode                 // Builtin error codes and error codes defined in project.
+}
+
+// apiModule is the api definitions of a module version, which is a package in api folder.
+type apiModule struct {
+	Module  string         `eg:"user"`
+	Version string         `eg:"v1"`
+	Import  string         `eg:"demo.com/api/user/v1"`
+	Package *types.Package // Package of api definitions.
+	Apis    []*apiItem     // Api items sorted by name.
+}
+
+// apiItem is an api definition of request and response structs.
+type apiItem struct {
+	Name    string       `eg:"GetList"`
+	Path    string       `eg:"/user/list"`
+	Method  string       `eg:"GET"`
+	Summary string       `eg:"get user list"`
+	Comment string       `eg:"GetList get user list"`
+	Req     *types.Named // Request struct type.
+	Res     *types.Named // Response struct type.
+}
+
+// apiErrorCode is an error code definition.
+type apiErrorCode struct {
+	Name    string `eg:"CodeNotFound"`
+	Code    int    `eg:"65"`
+	Message string `eg:"Not Found"`
+}
+
+// builtinErrorCodes is the builtin error codes of package gcode,
+// which should be updated along with package gcode.
+var builtinErrorCodes = []struct {
+	Name string
+	Code gcode.Code
+}{
+	{"CodeNil", gcode.CodeNil},
+	{"CodeOK", gcode.CodeOK},
+	{"CodeInternalError", gcode.CodeInternalError},
+	{"CodeValidationFailed", gcode.CodeValidationFailed},
+	{"CodeDbOperationError", gcode.CodeDbOperationError},
+	{"CodeInvalidParameter", gcode.CodeInvalidParameter},
+	{"CodeMissingParameter", gcode.CodeMissingParameter},
+	{"CodeInvalidOperation", gcode.CodeInvalidOperation},
+	{"CodeInvalidConfiguration", gcode.CodeInvalidConfiguration},
+	{"CodeMissingConfiguration", gcode.CodeMissingConfiguration},
+	{"CodeNotImplemented", gcode.CodeNotImplemented},
+	{"CodeNotSupported", gcode.CodeNotSupported},
+	{"CodeOperationFailed", gcode.CodeOperationFailed},
+	{"CodeNotAuthorized", gcode.CodeNotAuthorized},
+	{"CodeSecurityReason", gcode.CodeSecurityReason},
+	{"CodeServerBusy", gcode.CodeServerBusy},
+	{"CodeUnknown", gcode.CodeUnknown},
+	{"CodeNotFound", gcode.CodeNotFound},
+	{"CodeInvalidRequest", gcode.CodeInvalidRequest},
+	{"CodeNecessaryPackageNotImport", gcode.CodeNecessaryPackageNotImport},
+	{"CodeInternalPanic", gcode.CodeInternalPanic},
+	{"CodeTooManyRequests", gcode.CodeTooManyRequests},
+	{"CodeOptimisticLockConflict", gcode.CodeOptimisticLockConflict},
+	{"CodeBusinessValidationFailed", gcode.CodeBusinessValidationFailed},
+}
+
+// ClientName returns the name of client for the module, eg: UserV1.
+func (m *apiModule) ClientName() string {
+	return gstr.CaseCamel(m.Module) + gstr.UcFirst(m.Version)
+}
+
+// FileName returns the file name without extension for the module, eg: user_v1.
+func (m *apiModule) FileName() string {
+	if m.Version == "" {
+		return gstr.CaseSnake(m.Module)
+	}
+	return gstr.CaseSnake(m.Module) + "_" + m.Version
+}
+
+// GetComment returns the comment of api item, which is the summary if no comment.
+func (a *apiItem) GetComment() string {
+	if a.Comment != "" {
+		return a.Comment
+	}
+	return a.Summary
+}
+
+type apiParser struct{}
+
+func newApiParser() *apiParser {
+	return &apiParser{}
+}
+
+// Parse loads the go packages in `srcFolderPath` and parses the api definitions.
+func (p *apiParser) Parse(srcFolderPath string) (*apiDefinition, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Dir:   srcFolderPath,
+		Mode:  pkgLoadMode,
+		Tests: false,
+	}, "./...")
+	if err != nil {
+		return nil, err
+	}
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil, gerror.Newf(`load package "%s" failed: %v`, pkg.PkgPath, pkg.Errors[0])
+		}
+	}
+	var definition = &apiDefinition{
+		Enums:    make(map[string][]genenums.EnumItem),
+		Comments: make(map[string]string),
+	}
+	// Enums.
+	enumsParser := genenums.NewEnumsParser(nil)
+	enumsParser.ParsePackages(pkgs)
+	for _, item := range enumsParser.Enums() {
+		definition.Enums[item.Type] = append(definition.Enums[item.Type], item)
+	}
+	// Modules.
+	for _, pkg := range pkgs {
+		for name, comment := range p.getTypeComments(pkg) {
+			definition.Comments[pkg.PkgPath+"."+name] = comment
+		}
+		if module := p.parseModule(srcFolderPath, pkg, definition.Comments); module != nil {
+			definition.Modules = append(definition.Modules, module)
+		}
+	}
+	sort.Slice(definition.Modules, func(i, j int) bool {
+		return definition.Modules[i].FileName() < definition.Modules[j].FileName()
+	})
+	// Error codes.
+	definition.ErrorCodes = p.parseErrorCodes(pkgs)
+	return definition, nil
+}
+
+// parseModule parses the api items in package `pkg`, it returns nil if there's no api definition.
+// The parameter `comments` is the type comments, the key is the full type name.
+func (p *apiParser) parseModule(srcFolderPath string, pkg *packages.Package, comments map[string]string) *apiModule {
+	if pkg.Types == nil || len(pkg.GoFiles) == 0 {
+		return nil
+	}
+	var (
+		module = &apiModule{
+			Module:  pkg.Name,
+			Import:  pkg.PkgPath,
+			Package: pkg.Types,
+		}
+		scope = pkg.Types.Scope()
+	)
+	// The package should be in standard structure: api/{module}/{version}.
+	if relativePath, err := filepath.Rel(srcFolderPath, gfile.Dir(pkg.GoFiles[0])); err == nil {
+		array := gstr.SplitAndTrim(filepath.ToSlash(relativePath), "/")
+		if len(array) > 0 && array[0] != "." {
+			module.Module = array[0]
+		}
+		if len(array) > 1 {
+			module.Version = array[len(array)-1]
+		}
+	}
+	for _, name := range scope.Names() {
+		if !gstr.HasSuffix(name, reqStructSuffix) {
+			continue
+		}
+		reqType, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || !reqType.Exported() {
+			continue
+		}
+		reqNamed, ok := reqType.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		metaTag, ok := getMetaTag(reqNamed)
+		if !ok || metaTag.Get(gtag.Path) == "" {
+			continue
+		}
+		var (
+			apiName = gstr.TrimRightStr(name, reqStructSuffix, 1)
+			resName = apiName + resStructSuffix
+		)
+		resType, ok := scope.Lookup(resName).(*types.TypeName)
+		if !ok {
+			mlog.Printf(`response struct "%s" not found for "%s.%s", ignored`, resName, pkg.PkgPath, name)
+			continue
+		}
+		resNamed, ok := resType.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		item := &apiItem{
+			Name:    apiName,
+			Path:    metaTag.Get(gtag.Path),
+			Method:  gstr.ToUpper(metaTag.Get(gtag.Method)),
+			Summary: getTagValue(metaTag, gtag.Summary, gtag.SummaryShort, gtag.SummaryShort2),
+			Comment: comments[pkg.PkgPath+"."+name],
+			Req:     reqNamed,
+			Res:     resNamed,
+		}
+		if item.Method == "" {
+			item.Method = defaultMethod
+		}
+		// remove the struct name from the comment.
+		if gstr.HasPrefix(item.Comment, name) {
+			item.Comment = gstr.Trim(gstr.TrimLeftStr(item.Comment, name, 1))
+		}
+		module.Apis = append(module.Apis, item)
+	}
+	if len(module.Apis) == 0 {
+		return nil
+	}
+	return module
+}
+
+// getTypeComments retrieves the doc comments of types in package `pkg`, the key is the type name.
+func (p *apiParser) getTypeComments(pkg *packages.Package) map[string]string {
+	var comments = make(map[string]string)
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				var doc = typeSpec.Doc
+				if doc == nil && len(genDecl.Specs) == 1 {
+					doc = genDecl.Doc
+				}
+				if doc == nil {
+					continue
+				}
+				comments[typeSpec.Name.Name] = gstr.Join(gstr.SplitAndTrim(doc.Text(), "\n"), " ")
+			}
+		}
+	}
+	return comments
+}
+
+// parseErrorCodes parses the error codes defined using "gcode.New" in packages of the same go module
+// with api packages, and returns them along with the builtin error codes.
+func (p *apiParser) parseErrorCodes(pkgs []*packages.Package) []apiErrorCode {
+	var (
+		errorCodes = make([]apiErrorCode, 0)
+		nameSet    = make(map[string]struct{})
+		modulePath string
+	)
+	for _, item := range builtinErrorCodes {
+		nameSet[item.Name] = struct{}{}
+		errorCodes = append(errorCodes, apiErrorCode{
+			Name:    item.Name,
+			Code:    item.Code.Code(),
+			Message: item.Code.Message(),
+		})
+	}
+	for _, pkg := range pkgs {
+		if pkg.Module != nil {
+			modulePath = pkg.Module.Path
+			break
+		}
+	}
+	if modulePath == "" {
+		return errorCodes
+	}
+	var projectErrorCodes = make([]apiErrorCode, 0)
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if pkg.Module == nil || pkg.Module.Path != modulePath || pkg.TypesInfo == nil {
+			return
+		}
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.VAR {
+					continue
+				}
+				for _, spec := range genDecl.Specs {
+					valueSpec, ok := spec.(*ast.ValueSpec)
+					if !ok {
+						continue
+					}
+					for i, value := range valueSpec.Values {
+						if i >= len(valueSpec.Names) || !valueSpec.Names[i].IsExported() {
+							continue
+						}
+						errorCode, ok := parseErrorCodeExpr(pkg.TypesInfo, value)
+						if !ok {
+							continue
+						}
+						errorCode.Name = valueSpec.Names[i].Name
+						if _, ok = nameSet[errorCode.Name]; ok {
+							continue
+						}
+						nameSet[errorCode.Name] = struct{}{}
+						projectErrorCodes = append(projectErrorCodes, errorCode)
+					}
+				}
+			}
+		}
+	})
+	sort.SliceStable(projectErrorCodes, func(i, j int) bool {
+		return projectErrorCodes[i].Code < projectErrorCodes[j].Code
+	})
+	return append(errorCodes, projectErrorCodes...)
+}
+
+// parseErrorCodeExpr parses expression like `gcode.New(10000, "message", nil)` with constant arguments.
+func parseErrorCodeExpr(info *types.Info, expr ast.Expr) (errorCode apiErrorCode, ok bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) < 2 {
+		return errorCode, false
+	}
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return errorCode, false
+	}
+	fn, ok := info.Uses[selector.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != gcodePkgPath || fn.Name() != gcodeNewFunc {
+		return errorCode, false
+	}
+	var (
+		codeValue    = info.Types[call.Args[0]].Value
+		messageValue = info.Types[call.Args[1]].Value
+	)
+	if codeValue == nil || codeValue.Kind() != constant.Int {
+		return errorCode, false
+	}
+	code, exact := constant.Int64Val(codeValue)
+	if !exact {
+		return errorCode, false
+	}
+	errorCode.Code = int(code)
+	if messageValue != nil && messageValue.Kind() == constant.String {
+		errorCode.Message = constant.StringVal(messageValue)
+	}
+	return errorCode, true
+}
+
+// getMetaTag returns the tag of g.Meta field in struct type `named`.
+func getMetaTag(named *types.Named) (reflect.StructTag, bool) {
+	structType, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return "", false
+	}
+	for i := 0; i < structType.NumFields(); i++ {
+		if isMetaType(structType.Field(i).Type()) {
+			return reflect.StructTag(structType.Tag(i)), true
+		}
+	}
+	return "", false
+}
+
+// isMetaType checks whether `t` is the type g.Meta.
+func isMetaType(t types.Type) bool {
+	return types.TypeString(types.Unalias(t), nil) == metaTypeName
+}
+
+// getTagValue returns the first non-empty value of tag `names`.
+func getTagValue(tag reflect.StructTag, names ...string) string {
+	for _, name := range names {
+		if value := tag.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// isRequiredRules checks whether validation rules `rules` contain the "required" rule.
+func isRequiredRules(rules string) bool {
+	for _, rule := range gstr.SplitAndTrim(rules, "|") {
+		if rule == validRequired {
+			return true
+		}
+	}
+	return false
+}
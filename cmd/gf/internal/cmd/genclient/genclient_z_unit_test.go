@@ -0,0 +1,55 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package genclient
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/test/gtest"
+	"golang.org/x/tools/go/packages"
+)
+
+// Test_BuiltinErrorCodes checks builtinErrorCodes against the error codes declared in package gcode.
+func Test_BuiltinErrorCodes(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		pkgs, err := packages.Load(&packages.Config{
+			Mode: packages.NeedName | packages.NeedFiles,
+		}, "github.com/gogf/gf/v2/errors/gcode")
+		t.AssertNil(err)
+		t.Assert(len(pkgs), 1)
+		t.Assert(len(pkgs[0].Errors), 0)
+
+		var declaredNames = make([]string, 0)
+		for _, path := range pkgs[0].GoFiles {
+			file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+			t.AssertNil(err)
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.VAR {
+					continue
+				}
+				for _, spec := range genDecl.Specs {
+					for _, name := range spec.(*ast.ValueSpec).Names {
+						if name.IsExported() && strings.HasPrefix(name.Name, "Code") {
+							declaredNames = append(declaredNames, name.Name)
+						}
+					}
+				}
+			}
+		}
+		var builtinNames = make([]string, 0, len(builtinErrorCodes))
+		for _, item := range builtinErrorCodes {
+			builtinNames = append(builtinNames, item.Name)
+		}
+		t.AssertGT(len(declaredNames), 0)
+		t.Assert(builtinNames, declaredNames)
+	})
+}
@@ -112,6 +112,11 @@ func (p *EnumsParser) ParsePackage(pkg *packages.Package) {
 	}
 }
 
+// Enums returns all the parsed enum items.
+func (p *EnumsParser) Enums() []EnumItem {
+	return p.enums
+}
+
 func (p *EnumsParser) Export() string {
 	var typeEnumMap = make(map[string][]any)
 	for _, enum := range p.enums {
@@ -0,0 +1,23 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// CreateReq create article.
+type CreateReq struct {
+	g.Meta  `path:"/article" method:"post"`
+	Title   string            `json:"title" v:"required"`
+	Content string            `json:"content"`
+	Extra   map[string]string `json:"extra"`
+}
+
+type CreateRes struct {
+	Id int64 `json:"id"`
+}
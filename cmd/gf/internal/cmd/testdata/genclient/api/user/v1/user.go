@@ -0,0 +1,75 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package v1
+
+import (
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// Status is the status of user.
+type Status int
+
+const (
+	StatusDisabled Status = 0
+	StatusEnabled  Status = 1
+)
+
+var (
+	CodeUserNotFound = gcode.New(10001, "User Not Found", nil)
+)
+
+// User is the user information.
+type User struct {
+	Id        int         `json:"id"        dc:"user id"`
+	Name      string      `json:"name"`
+	Status    Status      `json:"status"`
+	Tags      []string    `json:"tags"`
+	Profile   *Profile    `json:"profile"`
+	CreatedAt *gtime.Time `json:"createdAt"`
+}
+
+type Profile struct {
+	Avatar string `json:"avatar,omitempty"`
+}
+
+type PageReq struct {
+	Page int `json:"page" d:"1"`
+	Size int `json:"size" d:"10"`
+}
+
+// GetListReq get user list.
+type GetListReq struct {
+	g.Meta `path:"/user" method:"get" summary:"user list"`
+	PageReq
+	Status Status `json:"status"`
+	Token  string `json:"token" in:"header"`
+}
+
+type GetListRes struct {
+	List  []User `json:"list"`
+	Total int    `json:"total"`
+}
+
+type GetOneReq struct {
+	g.Meta `path:"/user/{id}" method:"get" summary:"get one user"`
+	Id     int `json:"id" v:"required"`
+}
+
+type GetOneRes struct {
+	*User
+}
+
+type UpdateReq struct {
+	g.Meta `path:"/user/{id}" method:"put"`
+	Id     int    `json:"id"`
+	Name   string `json:"name" v:"required|length:1,32"`
+	Force  bool   `json:"force" in:"query"`
+}
+
+type UpdateRes struct{}
@@ -0,0 +1,22 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package sdk
+
+import (
+	"github.com/gogf/gf/contrib/sdk/httpclient/v2"
+)
+
+type implementer struct {
+	config httpclient.Config
+}
+
+// New creates and returns the client for all api modules.
+// The returned errors of api functions carry the business error code of response,
+// which can be retrieved using gerror.Code and compared with codes of package gcode.
+func New(config httpclient.Config) IClient {
+	return &implementer{
+		config: config,
+	}
+}
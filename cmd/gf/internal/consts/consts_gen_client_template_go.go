@@ -0,0 +1,81 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package consts
+
+const TemplateGenClientGoPkgNew = `
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package {PkgName}
+
+import (
+	"github.com/gogf/gf/contrib/sdk/httpclient/v2"
+)
+
+type implementer struct {
+	config httpclient.Config
+}
+
+// New creates and returns the client for all api modules.
+// The returned errors of api functions carry the business error code of response,
+// which can be retrieved using gerror.Code and compared with codes of package gcode.
+func New(config httpclient.Config) IClient {
+	return &implementer{
+		config: config,
+	}
+}
+
+`
+
+const TemplateGenClientGoIClient = `
+// =================================================================================
+// Code generated and maintained by GoFrame CLI tool. DO NOT EDIT.
+// =================================================================================
+
+package {PkgName}
+
+type IClient interface {
+{InterfaceFunctions}
+}
+`
+
+const TemplateGenClientGoImplementer = `
+// =================================================================================
+// Code generated and maintained by GoFrame CLI tool. DO NOT EDIT.
+// =================================================================================
+
+package {PkgName}
+
+import (
+	"context"
+
+	"github.com/gogf/gf/contrib/sdk/httpclient/v2"
+
+	"{ImportPath}"
+)
+
+type I{ImplementerName} interface {
+{InterfaceFunctions}
+}
+
+type implementer{ImplementerName} struct {
+	*httpclient.Client
+}
+
+func (i *implementer) {ImplementerName}() I{ImplementerName} {
+	return &implementer{ImplementerName}{httpclient.New(i.config)}
+}
+`
+
+const TemplateGenClientGoImplementerFunc = `
+{MethodComment}
+func (i *implementer{ImplementerName}) {MethodName}(ctx context.Context, req *{PkgAlias}.{MethodName}Req) (res *{PkgAlias}.{MethodName}Res, err error) {
+	err = i.Request(ctx, req, &res)
+	return
+}
+`
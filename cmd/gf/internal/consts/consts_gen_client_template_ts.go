@@ -0,0 +1,155 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package consts
+
+const TemplateGenClientTsClient = `
+// =================================================================================
+// Code generated and maintained by GoFrame CLI tool. DO NOT EDIT.
+// =================================================================================
+
+/** Error codes of response, including the builtin codes of package gcode and the codes defined in project. */
+export const ErrorCode = {
+{ErrorCodes}
+} as const;
+
+export type ErrorCode = (typeof ErrorCode)[keyof typeof ErrorCode];
+
+/** Error thrown if the request fails or the response code is not ErrorCode.CodeOK. */
+export class ApiError extends Error {
+  readonly code: number;
+  readonly status: number;
+  readonly data: unknown;
+
+  constructor(code: number, message: string, status: number, data?: unknown) {
+    super(message);
+    this.name = 'ApiError';
+    this.code = code;
+    this.status = status;
+    this.data = data;
+  }
+
+  /** Checks whether the error is of given error code. */
+  is(code: ErrorCode): boolean {
+    return this.code === code;
+  }
+}
+
+export interface ClientConfig {
+  /** Service address, eg: https://api.example.com */
+  baseURL: string;
+  /** Headers sent with every request. */
+  headers?: Record<string, string>;
+  /** Custom fetch implementation, the global fetch is used in default. */
+  fetch?: typeof fetch;
+}
+
+export interface RequestParams {
+  path?: Record<string, unknown>;
+  query?: Record<string, unknown>;
+  header?: Record<string, unknown>;
+  cookie?: Record<string, unknown>;
+  body?: Record<string, unknown>;
+}
+
+interface ApiResponse<T> {
+  code: number;
+  message: string;
+  data: T;
+}
+
+export class Client {
+  private readonly config: ClientConfig;
+
+  constructor(config: ClientConfig) {
+    this.config = config;
+  }
+
+  /** Sends request and returns the data of response in format {code, message, data}. */
+  async request<T>(method: string, path: string, params: RequestParams = {}, init: RequestInit = {}): Promise<T> {
+    for (const [name, value] of Object.entries(params.path ?? {})) {
+      path = path.replace('{' + name + '}', encodeURIComponent(String(value)));
+    }
+    const query = new URLSearchParams();
+    for (const [name, value] of Object.entries(params.query ?? {})) {
+      if (value === undefined || value === null) {
+        continue;
+      }
+      for (const item of Array.isArray(value) ? value : [value]) {
+        query.append(name, typeof item === 'object' ? JSON.stringify(item) : String(item));
+      }
+    }
+    const headers = new Headers(this.config.headers);
+    new Headers(init.headers).forEach((value, name) => headers.set(name, value));
+    for (const [name, value] of Object.entries(params.header ?? {})) {
+      if (value !== undefined && value !== null) {
+        headers.set(name, String(value));
+      }
+    }
+    const cookies = Object.entries(params.cookie ?? {})
+      .filter(([, value]) => value !== undefined && value !== null)
+      .map(([name, value]) => name + '=' + encodeURIComponent(String(value)));
+    if (cookies.length > 0) {
+      headers.set('Cookie', cookies.join('; '));
+    }
+    let body: string | undefined;
+    if (params.body && Object.keys(params.body).length > 0) {
+      body = JSON.stringify(params.body);
+      headers.set('Content-Type', 'application/json');
+    }
+    const queryString = query.toString();
+    const url = this.config.baseURL.replace(/\/+$/, '') + path + (queryString ? '?' + queryString : '');
+    const doFetch = this.config.fetch ?? fetch;
+    const response = await doFetch(url, { ...init, method, headers, body });
+    let result: ApiResponse<T>;
+    try {
+      result = (await response.json()) as ApiResponse<T>;
+    } catch (e) {
+      throw new ApiError(ErrorCode.CodeUnknown, response.statusText || String(e), response.status);
+    }
+    if (result.code !== ErrorCode.CodeOK) {
+      throw new ApiError(result.code, result.message, response.status, result.data);
+    }
+    return result.data;
+  }
+}
+`
+
+const TemplateGenClientTsModule = `
+// =================================================================================
+// Code generated and maintained by GoFrame CLI tool. DO NOT EDIT.
+// =================================================================================
+
+import { Client } from './client';
+{Definitions}
+export class {ModuleName} {
+  private readonly client: Client;
+
+  constructor(client: Client) {
+    this.client = client;
+  }
+{Functions}}
+`
+
+const TemplateGenClientTsIndex = `
+// =================================================================================
+// Code generated and maintained by GoFrame CLI tool. DO NOT EDIT.
+// =================================================================================
+
+import { Client, ClientConfig } from './client';
+{Imports}
+
+export * from './client';
+{Exports}
+
+/** Creates and returns the client for all api modules. */
+export function createClient(config: ClientConfig) {
+  const client = new Client(config);
+  return {
+{Modules}
+  };
+}
+`
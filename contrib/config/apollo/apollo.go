@@ -38,9 +38,10 @@ type Config struct {
 
 // Client implements gcfg.Adapter implementing using apollo service.
 type Client struct {
-	config Config        // Config object when created.
-	client agollo.Client // Apollo client.
-	value  *g.Var        // Configmap content cached. It is `*gjson.Json` value internally.
+	config   Config                // Config object when created.
+	client   agollo.Client         // Apollo client.
+	value    *g.Var                // Configmap content cached. It is `*gjson.Json` value internally.
+	watchers *gcfg.WatcherRegistry // Watchers for configuration changes.
 }
 
 // New creates and returns gcfg.Adapter implementing using apollo service.
@@ -54,8 +55,9 @@ func New(ctx context.Context, config Config) (adapter gcfg.Adapter, err error) {
 		config.NamespaceName = storage.GetDefaultNamespace()
 	}
 	client := &Client{
-		config: config,
-		value:  g.NewVar(nil, true),
+		config:   config,
+		value:    g.NewVar(nil, true),
+		watchers: gcfg.NewWatcherRegistry(),
 	}
 	// Apollo client.
 	client.client, err = agollo.StartWithConfig(func() (*apolloConfig.AppConfig, error) {
@@ -143,7 +145,25 @@ func (c *Client) updateLocalValue(ctx context.Context) (err error) {
 	})
 	cache.Clear()
 	if err == nil {
-		c.value.Set(j)
+		if oldJson, ok := c.value.Set(j).(*gjson.Json); ok && oldJson != nil {
+			c.watchers.Notify(ctx, oldJson.Map(), j.Map())
+		}
 	}
 	return
 }
+
+// AddWatcher adds a watcher function `fn` with unique `name`,
+// which is called when remote configuration changes if `Watch` is enabled.
+func (c *Client) AddWatcher(name string, fn gcfg.WatcherFunc) {
+	c.watchers.AddWatcher(name, fn)
+}
+
+// RemoveWatcher removes the watcher function by `name`.
+func (c *Client) RemoveWatcher(name string) {
+	c.watchers.RemoveWatcher(name)
+}
+
+// GetWatcherNames returns all watcher names in adding order.
+func (c *Client) GetWatcherNames() []string {
+	return c.watchers.GetWatcherNames()
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gcfg"
+	"github.com/gogf/gf/v2/os/gctx"
 	"github.com/gogf/gf/v2/os/glog"
 )
 
@@ -41,6 +42,8 @@ type Client struct {
 	client *api.Client
 	// Configmap content cached. It is `*gjson.Json` value internally.
 	value *g.Var
+	// Watchers for configuration changes.
+	watchers *gcfg.WatcherRegistry
 }
 
 // New creates and returns gcfg.Adapter implementing using consul service.
@@ -55,8 +58,9 @@ func New(ctx context.Context, config Config) (adapter gcfg.Adapter, err error) {
 	}
 
 	client := &Client{
-		config: config,
-		value:  g.NewVar(nil, true),
+		config:   config,
+		value:    g.NewVar(nil, true),
+		watchers: gcfg.NewWatcherRegistry(),
 	}
 
 	client.client, err = api.NewClient(&config.ConsulConfig)
@@ -111,6 +115,22 @@ func (c *Client) Data(ctx context.Context) (data map[string]any, err error) {
 	return c.value.Val().(*gjson.Json).Map(), nil
 }
 
+// AddWatcher adds a watcher function `fn` with unique `name`,
+// which is called when remote configuration changes if `Watch` is enabled.
+func (c *Client) AddWatcher(name string, fn gcfg.WatcherFunc) {
+	c.watchers.AddWatcher(name, fn)
+}
+
+// RemoveWatcher removes the watcher function by `name`.
+func (c *Client) RemoveWatcher(name string) {
+	c.watchers.RemoveWatcher(name)
+}
+
+// GetWatcherNames returns all watcher names in adding order.
+func (c *Client) GetWatcherNames() []string {
+	return c.watchers.GetWatcherNames()
+}
+
 func (c *Client) updateLocalValue() (err error) {
 	content, _, err := c.client.KV().Get(c.config.Path, nil)
 	if err != nil {
@@ -128,7 +148,9 @@ func (c *Client) doUpdate(content []byte) (err error) {
 		return gerror.Wrapf(err,
 			`parse config map item from consul path [%+v] failed`, c.config.Path)
 	}
-	c.value.Set(j)
+	if oldJson, ok := c.value.Set(j).(*gjson.Json); ok && oldJson != nil {
+		c.watchers.Notify(gctx.New(), oldJson.Map(), j.Map())
+	}
 	return nil
 }
 
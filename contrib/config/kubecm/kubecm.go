@@ -25,9 +25,10 @@ import (
 
 // Client implements gcfg.Adapter.
 type Client struct {
-	config Config                // Config object when created.
-	client *kubernetes.Clientset // Kubernetes client.
-	value  *g.Var                // Configmap content cached. It is `*gjson.Json` value internally.
+	config   Config                // Config object when created.
+	client   *kubernetes.Clientset // Kubernetes client.
+	value    *g.Var                // Configmap content cached. It is `*gjson.Json` value internally.
+	watchers *gcfg.WatcherRegistry // Watchers for configuration changes.
 }
 
 // Config for Client.
@@ -61,9 +62,10 @@ func New(ctx context.Context, config Config) (adapter gcfg.Adapter, err error) {
 		}
 	}
 	adapter = &Client{
-		config: config,
-		client: config.KubeClient,
-		value:  g.NewVar(nil, true),
+		config:   config,
+		client:   config.KubeClient,
+		value:    g.NewVar(nil, true),
+		watchers: gcfg.NewWatcherRegistry(),
 	}
 	return
 }
@@ -144,7 +146,9 @@ func (c *Client) doUpdate(ctx context.Context, namespace string) (err error) {
 			`parse config map item from %s[%s] failed`, c.config.ConfigMap, c.config.DataItem,
 		)
 	}
-	c.value.Set(j)
+	if oldJson, ok := c.value.Set(j).(*gjson.Json); ok && oldJson != nil {
+		c.watchers.Notify(ctx, oldJson.Map(), j.Map())
+	}
 	return nil
 }
 
@@ -177,3 +181,19 @@ func (c *Client) startAsynchronousWatch(ctx context.Context, namespace string, w
 		}
 	}
 }
+
+// AddWatcher adds a watcher function `fn` with unique `name`,
+// which is called when remote configuration changes if `Watch` is enabled.
+func (c *Client) AddWatcher(name string, fn gcfg.WatcherFunc) {
+	c.watchers.AddWatcher(name, fn)
+}
+
+// RemoveWatcher removes the watcher function by `name`.
+func (c *Client) RemoveWatcher(name string) {
+	c.watchers.RemoveWatcher(name)
+}
+
+// GetWatcherNames returns all watcher names in adding order.
+func (c *Client) GetWatcherNames() []string {
+	return c.watchers.GetWatcherNames()
+}
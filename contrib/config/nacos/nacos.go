@@ -19,6 +19,7 @@ import (
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gcfg"
+	"github.com/gogf/gf/v2/os/gctx"
 )
 
 // Config is the configuration object for nacos client.
@@ -32,9 +33,10 @@ type Config struct {
 
 // Client implements gcfg.Adapter implementing using nacos service.
 type Client struct {
-	config Config                      // Config object when created.
-	client config_client.IConfigClient // Nacos config client.
-	value  *g.Var                      // Configmap content cached. It is `*gjson.Json` value internally.
+	config   Config                      // Config object when created.
+	client   config_client.IConfigClient // Nacos config client.
+	value    *g.Var                      // Configmap content cached. It is `*gjson.Json` value internally.
+	watchers *gcfg.WatcherRegistry       // Watchers for configuration changes.
 }
 
 // New creates and returns gcfg.Adapter implementing using nacos service.
@@ -46,8 +48,9 @@ func New(ctx context.Context, config Config) (adapter gcfg.Adapter, err error) {
 	}
 
 	client := &Client{
-		config: config,
-		value:  g.NewVar(nil, true),
+		config:   config,
+		value:    g.NewVar(nil, true),
+		watchers: gcfg.NewWatcherRegistry(),
 	}
 
 	client.client, err = clients.CreateConfigClient(map[string]any{
@@ -118,7 +121,9 @@ func (c *Client) doUpdate(content string) (err error) {
 	if j, err = gjson.LoadContent([]byte(content)); err != nil {
 		return gerror.Wrap(err, `parse config map item from nacos failed`)
 	}
-	c.value.Set(j)
+	if oldJson, ok := c.value.Set(j).(*gjson.Json); ok && oldJson != nil {
+		c.watchers.Notify(gctx.New(), oldJson.Map(), j.Map())
+	}
 	return nil
 }
 
@@ -139,3 +144,19 @@ func (c *Client) addWatcher() error {
 
 	return nil
 }
+
+// AddWatcher adds a watcher function `fn` with unique `name`,
+// which is called when remote configuration changes if `Watch` is enabled.
+func (c *Client) AddWatcher(name string, fn gcfg.WatcherFunc) {
+	c.watchers.AddWatcher(name, fn)
+}
+
+// RemoveWatcher removes the watcher function by `name`.
+func (c *Client) RemoveWatcher(name string) {
+	c.watchers.RemoveWatcher(name)
+}
+
+// GetWatcherNames returns all watcher names in adding order.
+func (c *Client) GetWatcherNames() []string {
+	return c.watchers.GetWatcherNames()
+}
@@ -39,9 +39,10 @@ type Config struct {
 
 // Client implements gcfg.Adapter implementing using polaris service.
 type Client struct {
-	config Config
-	client model.ConfigFile
-	value  *g.Var
+	config   Config
+	client   model.ConfigFile
+	value    *g.Var
+	watchers *gcfg.WatcherRegistry
 }
 
 const defaultLogDir = "/tmp/polaris/log"
@@ -54,8 +55,9 @@ func New(ctx context.Context, config Config) (adapter gcfg.Adapter, err error) {
 	}
 	var (
 		client = &Client{
-			config: config,
-			value:  g.NewVar(nil, true),
+			config:   config,
+			value:    g.NewVar(nil, true),
+			watchers: gcfg.NewWatcherRegistry(),
 		}
 		configAPI polaris.ConfigAPI
 	)
@@ -150,7 +152,9 @@ func (c *Client) doUpdate(ctx context.Context) (err error) {
 	if j, err = gjson.LoadContent([]byte(c.client.GetContent())); err != nil {
 		return gerror.Wrap(err, `parse config map item from polaris failed`)
 	}
-	c.value.Set(j)
+	if oldJson, ok := c.value.Set(j).(*gjson.Json); ok && oldJson != nil {
+		c.watchers.Notify(ctx, oldJson.Map(), j.Map())
+	}
 	return nil
 }
 
@@ -173,3 +177,19 @@ func (c *Client) startAsynchronousWatch(ctx context.Context, changeChan <-chan m
 		}
 	}
 }
+
+// AddWatcher adds a watcher function `fn` with unique `name`,
+// which is called when remote configuration changes if `Watch` is enabled.
+func (c *Client) AddWatcher(name string, fn gcfg.WatcherFunc) {
+	c.watchers.AddWatcher(name, fn)
+}
+
+// RemoveWatcher removes the watcher function by `name`.
+func (c *Client) RemoveWatcher(name string) {
+	c.watchers.RemoveWatcher(name)
+}
+
+// GetWatcherNames returns all watcher names in adding order.
+func (c *Client) GetWatcherNames() []string {
+	return c.watchers.GetWatcherNames()
+}
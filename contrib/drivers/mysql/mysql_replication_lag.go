@@ -0,0 +1,40 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// GetReplicationLag retrieves and returns the replication lag of the slave node of `link`,
+// which implements gdb.ReplicationLagProber.
+//
+// It uses the `SHOW REPLICA STATUS` statement, or the `SHOW SLAVE STATUS` statement for old versions.
+func (d *Driver) GetReplicationLag(ctx context.Context, link gdb.Link) (time.Duration, error) {
+	result, err := d.DoSelect(ctx, link, `SHOW REPLICA STATUS`)
+	if err != nil {
+		if result, err = d.DoSelect(ctx, link, `SHOW SLAVE STATUS`); err != nil {
+			return 0, err
+		}
+	}
+	if result.IsEmpty() {
+		return 0, gerror.NewCode(gcode.CodeInvalidOperation, `replication is not configured`)
+	}
+	seconds, ok := result[0]["Seconds_Behind_Source"]
+	if !ok {
+		seconds = result[0]["Seconds_Behind_Master"]
+	}
+	if seconds.IsNil() {
+		return 0, gerror.NewCode(gcode.CodeInvalidOperation, `replication is not running`)
+	}
+	return time.Duration(seconds.Int64()) * time.Second, nil
+}
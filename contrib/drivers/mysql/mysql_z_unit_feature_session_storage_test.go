@@ -0,0 +1,160 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gsession"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Session_StorageDB(t *testing.T) {
+	var table = fmt.Sprintf(`sessions_%d`, gtime.TimestampNano())
+	defer dropTable(table)
+
+	storage := gsession.NewStorageDB(db, table)
+	manager := gsession.New(time.Second, storage)
+	sessionId := ""
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(ctx)
+		defer s.Close()
+		t.AssertNil(s.Set("k1", "v1"))
+		t.AssertNil(s.SetMap(g.Map{
+			"k2": "v2",
+			"k3": 3,
+		}))
+		t.Assert(s.IsDirty(), true)
+		sessionId = s.MustId()
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(ctx, sessionId)
+		t.Assert(s.MustGet("k1"), "v1")
+		t.Assert(s.MustGet("k2"), "v2")
+		t.Assert(s.MustGet("k3"), 3)
+		t.Assert(s.MustSize(), 3)
+		t.Assert(s.MustId(), sessionId)
+		t.AssertNil(s.Remove("k3"))
+		t.AssertNil(s.Close())
+
+		s = manager.New(ctx, sessionId)
+		t.Assert(s.MustSize(), 2)
+		t.Assert(s.MustContains("k3"), false)
+
+		// Upsert on existing row.
+		t.AssertNil(s.Set("k1", "v11"))
+		t.AssertNil(s.Close())
+		count, err := db.Model(table).Count()
+		t.AssertNil(err)
+		t.Assert(count, 1)
+		s = manager.New(ctx, sessionId)
+		t.Assert(s.MustGet("k1"), "v11")
+	})
+
+	// Expired.
+	time.Sleep(1100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(ctx, sessionId)
+		t.Assert(s.MustSize(), 0)
+		t.Assert(s.MustGet("k1"), nil)
+
+		t.AssertNil(storage.ClearExpired(ctx))
+		count, err := db.Model(table).Count()
+		t.AssertNil(err)
+		t.Assert(count, 0)
+	})
+
+	// RemoveAll.
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(ctx)
+		t.AssertNil(s.Set("k1", "v1"))
+		id := s.MustId()
+		t.AssertNil(s.Close())
+
+		s = manager.New(ctx, id)
+		t.AssertNil(s.RemoveAll())
+		t.AssertNil(s.Close())
+		s = manager.New(ctx, id)
+		t.Assert(s.MustGet("k1"), nil)
+	})
+}
+
+func Test_Session_StorageDB_NoAutoCreate(t *testing.T) {
+	var table = fmt.Sprintf(`sessions_%d`, gtime.TimestampNano())
+
+	gtest.C(t, func(t *gtest.T) {
+		storage := gsession.NewStorageDB(db, table)
+		storage.SetAutoCreate(false)
+		_, err := storage.GetSession(ctx, "id", time.Second)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Session_StorageDB_UserSessions(t *testing.T) {
+	var table = fmt.Sprintf(`sessions_%d`, gtime.TimestampNano())
+	defer dropTable(table)
+
+	storage := gsession.NewStorageDB(db, table)
+	manager := gsession.New(time.Second, storage)
+	gtest.C(t, func(t *gtest.T) {
+		s1 := manager.New(ctx)
+		t.AssertNil(s1.Set("k", "v1"))
+		t.AssertNil(s1.SetUser("john"))
+		id1 := s1.MustId()
+		t.AssertNil(s1.Close())
+
+		s2 := manager.New(ctx)
+		t.AssertNil(s2.SetUser("john"))
+		id2 := s2.MustId()
+		t.AssertNil(s2.Close())
+
+		ids, err := manager.UserSessions(ctx, "john")
+		t.AssertNil(err)
+		t.AssertIN(id1, ids)
+		t.AssertIN(id2, ids)
+		t.Assert(len(ids), 2)
+
+		// Unbind user.
+		s2 = manager.New(ctx, id2)
+		t.AssertNil(s2.SetUser(""))
+		t.AssertNil(s2.Close())
+		ids, err = manager.UserSessions(ctx, "john")
+		t.AssertNil(err)
+		t.Assert(ids, []string{id1})
+
+		// Revoke.
+		t.AssertNil(manager.RevokeUserSessions(ctx, "john"))
+		ids, err = manager.UserSessions(ctx, "john")
+		t.AssertNil(err)
+		t.Assert(len(ids), 0)
+		t.Assert(manager.New(ctx, id1).MustGet("k"), nil)
+	})
+
+	// Expired index is cleared.
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(ctx)
+		t.AssertNil(s.SetUser("john"))
+		t.AssertNil(s.Close())
+		ids, err := storage.GetUserSessions(ctx, "john")
+		t.AssertNil(err)
+		t.Assert(len(ids), 1)
+
+		time.Sleep(1100 * time.Millisecond)
+		ids, err = storage.GetUserSessions(ctx, "john")
+		t.AssertNil(err)
+		t.Assert(len(ids), 0)
+		t.AssertNil(storage.ClearExpired(ctx))
+		count, err := db.Model(table).Count()
+		t.AssertNil(err)
+		t.Assert(count, 0)
+	})
+}
@@ -0,0 +1,36 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package pgsql
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// replicationLagSql retrieves whether the server is a standby and its replaying lag in seconds.
+// The lag is 0 if all the received WAL is replayed, as the replaying timestamp is not updated
+// if there's no writing on primary.
+const replicationLagSql = `SELECT pg_is_in_recovery() AS in_recovery, ` +
+	`CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0 ` +
+	`ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END AS lag`
+
+// GetReplicationLag retrieves and returns the replication lag of the slave node of `link`,
+// which implements gdb.ReplicationLagProber.
+func (d *Driver) GetReplicationLag(ctx context.Context, link gdb.Link) (time.Duration, error) {
+	result, err := d.DoSelect(ctx, link, replicationLagSql)
+	if err != nil {
+		return 0, err
+	}
+	if result.IsEmpty() || !result[0]["in_recovery"].Bool() {
+		return 0, gerror.NewCode(gcode.CodeInvalidOperation, `server is not in recovery as a standby`)
+	}
+	return time.Duration(result[0]["lag"].Float64() * float64(time.Second)), nil
+}
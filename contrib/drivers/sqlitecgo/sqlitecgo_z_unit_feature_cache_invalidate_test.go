@@ -0,0 +1,132 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package sqlitecgo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Model_Cache_AutoInvalidate(t *testing.T) {
+	table := createInitTable()
+	defer dropTable(table)
+	db.GetCore().SetCacheAutoInvalidate(true)
+	defer db.GetCore().SetCacheAutoInvalidate(false)
+
+	gtest.C(t, func(t *gtest.T) {
+		one, err := db.Model(table).Cache(gdb.CacheOption{Duration: time.Minute}).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "user_1")
+
+		// Writing with another model evicts the cache automatically.
+		_, err = db.Model(table).Data("passport", "user_100").WherePri(1).Update()
+		t.AssertNil(err)
+		one, err = db.Model(table).Cache(gdb.CacheOption{Duration: time.Minute}).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "user_100")
+
+		_, err = db.Model(table).WherePri(1).Delete()
+		t.AssertNil(err)
+		one, err = db.Model(table).Cache(gdb.CacheOption{Duration: time.Minute}).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one.IsEmpty(), true)
+
+		_, err = db.Model(table).Data("id", 1, "passport", "user_1").Insert()
+		t.AssertNil(err)
+		one, err = db.Model(table).Cache(gdb.CacheOption{Duration: time.Minute}).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "user_1")
+	})
+}
+
+func Test_Model_Cache_AutoInvalidate_Join(t *testing.T) {
+	var (
+		table1 = createInitTable()
+		table2 = createInitTable(table1 + "_detail")
+	)
+	defer dropTable(table1)
+	defer dropTable(table2)
+	db.GetCore().SetCacheAutoInvalidate(true)
+	defer db.GetCore().SetCacheAutoInvalidate(false)
+
+	gtest.C(t, func(t *gtest.T) {
+		query := func() (gdb.Record, error) {
+			return db.Model(table1, "u").
+				LeftJoin(table2, "d", "d.id=u.id").
+				Fields("u.passport, d.nickname").
+				Cache(gdb.CacheOption{Duration: time.Minute}).
+				Where("u.id", 1).
+				One()
+		}
+		one, err := query()
+		t.AssertNil(err)
+		t.Assert(one["nickname"], "name_1")
+
+		// Writing the joined table evicts the cache.
+		_, err = db.Model(table2).Data("nickname", "name_100").WherePri(1).Update()
+		t.AssertNil(err)
+		one, err = query()
+		t.AssertNil(err)
+		t.Assert(one["nickname"], "name_100")
+	})
+}
+
+func Test_Model_Cache_AutoInvalidate_Transaction(t *testing.T) {
+	table := createInitTable()
+	defer dropTable(table)
+	db.GetCore().SetCacheAutoInvalidate(true)
+	defer db.GetCore().SetCacheAutoInvalidate(false)
+
+	query := func() (gdb.Record, error) {
+		return db.Model(table).Cache(gdb.CacheOption{Duration: time.Minute}).WherePri(1).One()
+	}
+	// Committed.
+	gtest.C(t, func(t *gtest.T) {
+		one, err := query()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "user_1")
+
+		err = db.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+			_, err := db.Model(table).Ctx(ctx).Data("passport", "user_100").WherePri(1).Update()
+			if err != nil {
+				return err
+			}
+			// It is not evicted until committed.
+			one, err := query()
+			if err != nil {
+				return err
+			}
+			if one["passport"].String() != "user_1" {
+				return gerror.New("cache evicted before committed")
+			}
+			return nil
+		})
+		t.AssertNil(err)
+		one, err = query()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "user_100")
+	})
+	// Rollback.
+	gtest.C(t, func(t *gtest.T) {
+		err := db.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+			_, err := tx.Model(table).Data("passport", "user_200").WherePri(1).Update()
+			if err != nil {
+				return err
+			}
+			return gerror.New("rollback")
+		})
+		t.AssertNE(err, nil)
+		one, err := query()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "user_100")
+	})
+}
@@ -0,0 +1,56 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package sqlitecgo_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gcron"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Cron_LockerDB(t *testing.T) {
+	var table = fmt.Sprintf(`cron_locks_%d`, gtime.TimestampNano())
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		locker := gcron.NewLockerDB(db, table)
+		ok, err := locker.TryLock(ctx, "job", "node1", time.Minute)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+
+		// Held by node1.
+		ok, err = locker.TryLock(ctx, "job", "node2", time.Minute)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+		ok, err = locker.Renew(ctx, "job", "node2", time.Minute)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+		ok, err = locker.Renew(ctx, "job", "node1", time.Millisecond)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+
+		// Expired, so taken over by node2.
+		time.Sleep(10 * time.Millisecond)
+		ok, err = locker.TryLock(ctx, "job", "node2", time.Minute)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+
+		// Only the holder can unlock it.
+		t.AssertNil(locker.Unlock(ctx, "job", "node1"))
+		ok, err = locker.TryLock(ctx, "job", "node1", time.Minute)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+		t.AssertNil(locker.Unlock(ctx, "job", "node2"))
+		ok, err = locker.TryLock(ctx, "job", "node1", time.Minute)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package sqlitecgo_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// newMasterSlaveRoutingDB creates a master-slave db of sqlite files, of which the table content
+// is different in master and slave, so that the routing can be checked by the reading result.
+func newMasterSlaveRoutingDB(t *gtest.T, group string, slaveFilePath string, config gdb.ConfigNode) (gdb.DB, string) {
+	var (
+		masterNode = configNode
+		slaveNode  = configNode
+	)
+	masterNode.Link = fmt.Sprintf(`sqlite::@file(%s)`, gfile.Join(dbDir, group+"_master.db"))
+	masterNode.StickyMasterDuration = config.StickyMasterDuration
+	masterNode.SlaveCheckInterval = config.SlaveCheckInterval
+	slaveNode.Link = fmt.Sprintf(`sqlite::@file(%s)`, slaveFilePath)
+	slaveNode.Role = gdb.RoleSlave
+	t.AssertNil(gdb.SetConfigGroup(group, gdb.ConfigGroup{masterNode, slaveNode}))
+
+	masterDb, err := gdb.New(masterNode)
+	t.AssertNil(err)
+	table := createInitTableWithDb(masterDb)
+	_, err = masterDb.Model(table).Data("passport", "master").WherePri(1).Update()
+	t.AssertNil(err)
+	if gfile.Exists(gfile.Dir(slaveFilePath)) {
+		slaveDb, err := gdb.New(slaveNode)
+		t.AssertNil(err)
+		createInitTableWithDb(slaveDb, table)
+		_, err = slaveDb.Model(table).Data("passport", "slave").WherePri(1).Update()
+		t.AssertNil(err)
+	}
+
+	db, err := gdb.NewByGroup(group)
+	t.AssertNil(err)
+	return db, table
+}
+
+func Test_MasterSlave_Routing(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		rwDb, table := newMasterSlaveRoutingDB(
+			t, "routing", gfile.Join(dbDir, "routing_slave.db"),
+			gdb.ConfigNode{StickyMasterDuration: time.Second},
+		)
+		defer rwDb.Close(ctx)
+
+		// Reading from slave in default.
+		value, err := rwDb.Model(table).Ctx(gctx.New()).WherePri(1).Value("passport")
+		t.AssertNil(err)
+		t.Assert(value, "slave")
+
+		// Forced reading from master.
+		value, err = rwDb.Model(table).Ctx(gdb.WithMaster(gctx.New())).WherePri(1).Value("passport")
+		t.AssertNil(err)
+		t.Assert(value, "master")
+		all, err := rwDb.GetAll(gdb.WithMaster(gctx.New()), fmt.Sprintf("SELECT * FROM %s WHERE id=1", table))
+		t.AssertNil(err)
+		t.Assert(all[0]["passport"], "master")
+
+		// Sticky reading from master after writing in the same context.
+		writeCtx := gctx.New()
+		_, err = rwDb.Model(table).Ctx(writeCtx).Data(g.Map{"nickname": "written"}).WherePri(1).Update()
+		t.AssertNil(err)
+		value, err = rwDb.Model(table).Ctx(writeCtx).WherePri(1).Value("nickname")
+		t.AssertNil(err)
+		t.Assert(value, "written")
+		value, err = rwDb.Model(table).Ctx(gctx.New()).WherePri(1).Value("nickname")
+		t.AssertNil(err)
+		t.Assert(value, "name_1")
+
+		// Sticky expires.
+		time.Sleep(1100 * time.Millisecond)
+		value, err = rwDb.Model(table).Ctx(writeCtx).WherePri(1).Value("nickname")
+		t.AssertNil(err)
+		t.Assert(value, "name_1")
+	})
+}
+
+func Test_MasterSlave_Routing_SlaveCheck(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		// The slave file is in a not existing directory, which fails the health checks.
+		rwDb, table := newMasterSlaveRoutingDB(
+			t, "routing_check", gfile.Join(dbDir, "none-exist", "slave.db"),
+			gdb.ConfigNode{SlaveCheckInterval: 100 * time.Millisecond},
+		)
+		defer rwDb.Close(ctx)
+
+		_, err := rwDb.Model(table).Ctx(gctx.New()).WherePri(1).Value("passport")
+		t.AssertNE(err, nil)
+
+		time.Sleep(300 * time.Millisecond)
+		value, err := rwDb.Model(table).Ctx(gctx.New()).WherePri(1).Value("passport")
+		t.AssertNil(err)
+		t.Assert(value, "master")
+	})
+}
+
+func Test_MasterSlave_Routing_SchemaClose(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		// The slave file is in a not existing directory, which fails the health checks.
+		rwDb, table := newMasterSlaveRoutingDB(
+			t, "routing_schema", gfile.Join(dbDir, "none-exist", "slave.db"),
+			gdb.ConfigNode{SlaveCheckInterval: 100 * time.Millisecond},
+		)
+		defer rwDb.Close(ctx)
+
+		_, err := rwDb.Model(table).Ctx(gctx.New()).WherePri(1).Value("passport")
+		t.AssertNE(err, nil)
+		time.Sleep(300 * time.Millisecond)
+
+		// Closing the schema db does not stop the slave checker shared with its parent.
+		t.AssertNil(rwDb.Schema("main").Close(ctx))
+		value, err := rwDb.Model(table).Ctx(gctx.New()).WherePri(1).Value("passport")
+		t.AssertNil(err)
+		t.Assert(value, "master")
+	})
+}
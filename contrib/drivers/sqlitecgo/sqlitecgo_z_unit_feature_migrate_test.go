@@ -0,0 +1,169 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package sqlitecgo_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/database/gdb/migrate"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Migrate_Up_Down_To_Status(t *testing.T) {
+	var (
+		migrationTable = fmt.Sprintf(`migrations_%d`, gtime.TimestampNano())
+		userTable      = fmt.Sprintf(`migrate_user_%d`, gtime.TimestampNano())
+	)
+	defer dropTable(migrationTable)
+	defer dropTable(userTable)
+
+	gtest.C(t, func(t *gtest.T) {
+		m := migrate.New(db, migrate.Config{Table: migrationTable})
+		err := m.Add(
+			&migrate.Migration{
+				Version: "1",
+				Name:    "create_user",
+				UpSQL:   fmt.Sprintf("CREATE TABLE %s (id INTEGER PRIMARY KEY, name VARCHAR(45));", userTable),
+				DownSQL: fmt.Sprintf("DROP TABLE %s;", userTable),
+			},
+			&migrate.Migration{
+				Version: "2",
+				Name:    "insert_user",
+				Up: func(ctx context.Context, db gdb.DB) error {
+					_, err := db.Model(userTable).Ctx(ctx).Data(gdb.Map{"id": 1, "name": "john"}).Insert()
+					return err
+				},
+				Down: func(ctx context.Context, db gdb.DB) error {
+					_, err := db.Model(userTable).Ctx(ctx).Where("id", 1).Delete()
+					return err
+				},
+			},
+			&migrate.Migration{
+				Version: "3",
+				Name:    "add_user_age",
+				UpSQL:   fmt.Sprintf("ALTER TABLE %s ADD COLUMN age INTEGER;", userTable),
+				DownSQL: "-- Migration 3 down.\n",
+			},
+		)
+		t.AssertNil(err)
+		t.AssertNE(m.Add(&migrate.Migration{Version: "1", UpSQL: "SELECT 1"}), nil)
+		t.AssertNE(m.Add(&migrate.Migration{Version: "4", UpSQL: "-- Migration 4 up.\n"}), nil)
+
+		statuses, err := m.Status(ctx)
+		t.AssertNil(err)
+		t.Assert(len(statuses), 3)
+		t.Assert(statuses[0].Applied, false)
+
+		versions, err := m.To(ctx, "2")
+		t.AssertNil(err)
+		t.Assert(versions, []string{"1", "2"})
+		count, err := db.Model(userTable).Count()
+		t.AssertNil(err)
+		t.Assert(count, 1)
+
+		versions, err = m.Up(ctx)
+		t.AssertNil(err)
+		t.Assert(versions, []string{"3"})
+
+		statuses, err = m.Status(ctx)
+		t.AssertNil(err)
+		t.Assert(len(statuses), 3)
+		for _, status := range statuses {
+			t.Assert(status.Applied, true)
+			t.AssertNE(status.AppliedAt, "")
+		}
+
+		// Migration "3" has no down statement.
+		_, err = m.Down(ctx)
+		t.AssertNE(err, nil)
+
+		versions, err = m.Up(ctx)
+		t.AssertNil(err)
+		t.Assert(len(versions), 0)
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		m := migrate.New(db, migrate.Config{Table: migrationTable})
+		err := m.Add(
+			&migrate.Migration{
+				Version: "1",
+				Name:    "create_user",
+				DownSQL: fmt.Sprintf("DROP TABLE %s;", userTable),
+				UpSQL:   "SELECT 1",
+			},
+			&migrate.Migration{
+				Version: "2",
+				Name:    "insert_user",
+				UpSQL:   "SELECT 1",
+				Down: func(ctx context.Context, db gdb.DB) error {
+					_, err := db.Model(userTable).Ctx(ctx).Where("id", 1).Delete()
+					return err
+				},
+			},
+		)
+		t.AssertNil(err)
+
+		// Migration "3" is applied but not registered.
+		statuses, err := m.Status(ctx)
+		t.AssertNil(err)
+		t.Assert(len(statuses), 3)
+		t.Assert(statuses[2].Version, "3")
+		t.Assert(statuses[2].Missing, true)
+
+		_, err = db.Model(migrationTable).Where("version", "3").Delete()
+		t.AssertNil(err)
+
+		versions, err := m.Down(ctx, 2)
+		t.AssertNil(err)
+		t.Assert(versions, []string{"2", "1"})
+
+		tables, err := db.Tables(ctx)
+		t.AssertNil(err)
+		t.AssertNI(userTable, tables)
+	})
+}
+
+func Test_Migrate_Transaction_Rollback(t *testing.T) {
+	var (
+		migrationTable = fmt.Sprintf(`migrations_%d`, gtime.TimestampNano())
+		userTable      = fmt.Sprintf(`migrate_user_%d`, gtime.TimestampNano())
+	)
+	defer dropTable(migrationTable)
+	defer dropTable(userTable)
+
+	gtest.C(t, func(t *gtest.T) {
+		m := migrate.New(db, migrate.Config{Table: migrationTable})
+		err := m.Add(&migrate.Migration{
+			Version: "1",
+			Name:    "create_user_failed",
+			Up: func(ctx context.Context, db gdb.DB) error {
+				if _, err := db.Exec(ctx, fmt.Sprintf("CREATE TABLE %s (id INTEGER PRIMARY KEY)", userTable)); err != nil {
+					return err
+				}
+				return gerror.New("custom error")
+			},
+		})
+		t.AssertNil(err)
+
+		_, err = m.Up(ctx)
+		t.AssertNE(err, nil)
+
+		tables, err := db.Tables(ctx)
+		t.AssertNil(err)
+		t.AssertNI(userTable, tables)
+
+		statuses, err := m.Status(ctx)
+		t.AssertNil(err)
+		t.Assert(len(statuses), 1)
+		t.Assert(statuses[0].Applied, false)
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package sqlitecgo_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func createTableForOptimisticLockTest(versionField string) string {
+	tableName := "user_" + gtime.Now().TimestampNanoStr()
+	if _, err := db.Exec(ctx, fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (
+		id INTEGER	PRIMARY KEY AUTOINCREMENT
+					UNIQUE
+					NOT NULL,
+		passport    varchar(45) NULL,
+		%s          INTEGER NOT NULL DEFAULT 0
+	);
+	`, tableName, versionField,
+	)); err != nil {
+		gtest.Fatal(err)
+	}
+	if _, err := db.Insert(ctx, tableName, g.Map{"id": 1, "passport": "john"}); err != nil {
+		gtest.Fatal(err)
+	}
+	return tableName
+}
+
+func Test_Model_OptimisticLock(t *testing.T) {
+	table := createTableForOptimisticLockTest("revision")
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		type User struct {
+			Id       int
+			Passport string
+			Revision int
+		}
+		var user *User
+		err := db.Model(table).WherePri(1).Scan(&user)
+		t.AssertNil(err)
+		t.Assert(user.Revision, 0)
+
+		user.Passport = "smith"
+		result, err := db.Model(table).OptimisticLock("revision").Data(user).WherePri(1).Update()
+		t.AssertNil(err)
+		n, _ := result.RowsAffected()
+		t.Assert(n, 1)
+
+		one, err := db.Model(table).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "smith")
+		t.Assert(one["revision"], 1)
+
+		// Stale version.
+		user.Passport = "stale"
+		_, err = db.Model(table).OptimisticLock("revision").Data(user).WherePri(1).Update()
+		t.AssertNE(err, nil)
+		t.Assert(gerror.Code(err), gcode.CodeOptimisticLockConflict)
+
+		one, err = db.Model(table).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "smith")
+		t.Assert(one["revision"], 1)
+	})
+	// Missing version value.
+	gtest.C(t, func(t *gtest.T) {
+		_, err := db.Model(table).OptimisticLock("revision").Data(g.Map{"passport": "john"}).WherePri(1).Update()
+		t.Assert(gerror.Code(err), gcode.CodeMissingParameter)
+
+		_, err = db.Model(table).OptimisticLock("revision").Data("passport", "john").WherePri(1).Update()
+		t.Assert(gerror.Code(err), gcode.CodeMissingParameter)
+
+		_, err = db.Model(table).OptimisticLock("revision").Data("revision=?", 1).WherePri(1).Update()
+		t.Assert(gerror.Code(err), gcode.CodeInvalidParameter)
+	})
+}
+
+func Test_Model_OptimisticLock_VersionField(t *testing.T) {
+	table := createTableForOptimisticLockTest("version")
+	defer dropTable(table)
+
+	// Not detected in default.
+	gtest.C(t, func(t *gtest.T) {
+		_, err := db.Model(table).Data(g.Map{"passport": "smith", "version": 5}).WherePri(1).Update()
+		t.AssertNil(err)
+		value, err := db.Model(table).WherePri(1).Value("version")
+		t.AssertNil(err)
+		t.Assert(value, 5)
+		_, err = db.Model(table).Data(g.Map{"version": 0}).WherePri(1).Update()
+		t.AssertNil(err)
+	})
+
+	node := configNode
+	node.VersionField = "version"
+	versionDb, err := gdb.New(node)
+	if err != nil {
+		gtest.Fatal(err)
+	}
+	defer versionDb.Close(ctx)
+
+	gtest.C(t, func(t *gtest.T) {
+		_, err := versionDb.Model(table).Data(g.Map{"passport": "smith", "version": 0}).WherePri(1).Update()
+		t.AssertNil(err)
+		value, err := versionDb.Model(table).WherePri(1).Value("version")
+		t.AssertNil(err)
+		t.Assert(value, 1)
+
+		_, err = versionDb.Model(table).Data(g.Map{"passport": "stale", "version": 0}).WherePri(1).Update()
+		t.Assert(gerror.Code(err), gcode.CodeOptimisticLockConflict)
+
+		// No version value given, it only increases the version.
+		_, err = versionDb.Model(table).Data(g.Map{"passport": "john"}).WherePri(1).Update()
+		t.AssertNil(err)
+		_, err = versionDb.Model(table).Data("passport", "alice").WherePri(1).Update()
+		t.AssertNil(err)
+
+		one, err := versionDb.Model(table).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "alice")
+		t.Assert(one["version"], 3)
+
+		// The version assigned by caller is not increased.
+		_, err = versionDb.Model(table).Data("passport='bob', `version`=?", 10).WherePri(1).Update()
+		t.AssertNil(err)
+		value, err = versionDb.Model(table).WherePri(1).Value("version")
+		t.AssertNil(err)
+		t.Assert(value, 10)
+	})
+	// Disabled.
+	gtest.C(t, func(t *gtest.T) {
+		_, err := versionDb.Model(table).OptimisticLock("").Data(g.Map{"passport": "bob", "version": 100}).WherePri(1).Update()
+		t.AssertNil(err)
+		one, err := versionDb.Model(table).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "bob")
+		t.Assert(one["version"], 100)
+	})
+}
@@ -2607,6 +2607,62 @@ func Test_Model_Cache(t *testing.T) {
 	})
 }
 
+func Test_Model_Cache_Tags(t *testing.T) {
+	table := createInitTable()
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		one, err := db.Model(table).Cache(gdb.CacheOption{
+			Duration: time.Minute,
+			Tags:     []string{table, "user:1"},
+		}).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "user_1")
+
+		two, err := db.Model(table).Cache(gdb.CacheOption{
+			Duration: time.Minute,
+			Tags:     []string{table, "user:2"},
+		}).WherePri(2).One()
+		t.AssertNil(err)
+		t.Assert(two["passport"], "user_2")
+
+		_, err = db.Model(table).Data("passport", "user_100").WherePri(1).Update()
+		t.AssertNil(err)
+		_, err = db.Model(table).Data("passport", "user_200").WherePri(2).Update()
+		t.AssertNil(err)
+
+		// Invalidates cache by entity tag in update statement.
+		_, err = db.Model(table).Cache(gdb.CacheOption{
+			Duration: -1,
+			Tags:     []string{"user:1"},
+		}).Data("nickname", "name_100").WherePri(1).Update()
+		t.AssertNil(err)
+
+		one, err = db.Model(table).Cache(gdb.CacheOption{
+			Duration: time.Minute,
+			Tags:     []string{table, "user:1"},
+		}).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "user_100")
+
+		two, err = db.Model(table).Cache(gdb.CacheOption{
+			Duration: time.Minute,
+			Tags:     []string{table, "user:2"},
+		}).WherePri(2).One()
+		t.AssertNil(err)
+		t.Assert(two["passport"], "user_2")
+
+		// Invalidates cache by table tag using cache object.
+		t.AssertNil(db.GetCache().InvalidateTags(ctx, table))
+		two, err = db.Model(table).Cache(gdb.CacheOption{
+			Duration: time.Minute,
+			Tags:     []string{table, "user:2"},
+		}).WherePri(2).One()
+		t.AssertNil(err)
+		t.Assert(two["passport"], "user_200")
+	})
+}
+
 func Test_Model_Having(t *testing.T) {
 	table := createInitTable()
 	defer dropTable(table)
@@ -410,3 +410,64 @@ func Test_AdapterRedis_Remove(t *testing.T) {
 		t.AssertNil(err)
 	})
 }
+
+func Test_AdapterRedis_Tags(t *testing.T) {
+	defer cacheRedis.Clear(ctx)
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(cacheRedis.SetWithTags(ctx, "user:1", 1, time.Minute, []string{"user", "user:1"}))
+		t.AssertNil(cacheRedis.SetWithTags(ctx, "user:2", 2, 0, []string{"user", "user:2"}))
+		t.AssertNil(cacheRedis.Set(ctx, "other", 3, 0))
+
+		t.AssertNil(cacheRedis.InvalidateTags(ctx, "user:1"))
+		v, _ := cacheRedis.Get(ctx, "user:1")
+		t.Assert(v, nil)
+		v, _ = cacheRedis.Get(ctx, "user:2")
+		t.Assert(v, 2)
+
+		t.AssertNil(cacheRedis.InvalidateTags(ctx, "user"))
+		v, _ = cacheRedis.Get(ctx, "user:2")
+		t.Assert(v, nil)
+		v, _ = cacheRedis.Get(ctx, "other")
+		t.Assert(v, 3)
+	})
+}
+
+func Test_AdapterRedis_Tags_Retag(t *testing.T) {
+	defer cacheRedis.Clear(ctx)
+	gtest.C(t, func(t *gtest.T) {
+		// Tags are replaced when set again.
+		t.AssertNil(cacheRedis.SetWithTags(ctx, "k", 1, 0, []string{"old", "both"}))
+		t.AssertNil(cacheRedis.SetWithTags(ctx, "k", 2, 0, []string{"new", "both"}))
+		t.AssertNil(cacheRedis.InvalidateTags(ctx, "old"))
+		v, _ := cacheRedis.Get(ctx, "k")
+		t.Assert(v, 2)
+
+		// Tags are kept when set again without tags.
+		t.AssertNil(cacheRedis.Set(ctx, "k", 3, 0))
+		t.AssertNil(cacheRedis.InvalidateTags(ctx, "new"))
+		v, _ = cacheRedis.Get(ctx, "k")
+		t.Assert(v, nil)
+
+		// Invalidated key is unbound from all its tags.
+		t.AssertNil(cacheRedis.Set(ctx, "k", 4, 0))
+		t.AssertNil(cacheRedis.InvalidateTags(ctx, "both"))
+		v, _ = cacheRedis.Get(ctx, "k")
+		t.Assert(v, 4)
+
+		// Empty tags unbind the key.
+		t.AssertNil(cacheRedis.SetWithTags(ctx, "k", 5, 0, []string{"t"}))
+		t.AssertNil(cacheRedis.SetWithTags(ctx, "k", 6, 0, nil))
+		t.AssertNil(cacheRedis.InvalidateTags(ctx, "t"))
+		v, _ = cacheRedis.Get(ctx, "k")
+		t.Assert(v, 6)
+
+		// Removed key is unbound from its tags.
+		t.AssertNil(cacheRedis.SetWithTags(ctx, "k", 7, 0, []string{"t"}))
+		_, err := cacheRedis.Remove(ctx, "k")
+		t.AssertNil(err)
+		t.AssertNil(cacheRedis.Set(ctx, "k", 8, 0))
+		t.AssertNil(cacheRedis.InvalidateTags(ctx, "t"))
+		v, _ = cacheRedis.Get(ctx, "k")
+		t.Assert(v, 8)
+	})
+}
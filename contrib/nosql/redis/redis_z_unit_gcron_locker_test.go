@@ -0,0 +1,50 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gcron"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Cron_LockerRedis(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			key    = guid.S()
+			locker = gcron.NewLockerRedis(redis)
+		)
+		defer redis.Del(ctx, key)
+		ok, err := locker.TryLock(ctx, key, "node1", time.Minute)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+
+		// Held by node1.
+		ok, err = locker.TryLock(ctx, key, "node2", time.Minute)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+		ok, err = locker.Renew(ctx, key, "node2", time.Minute)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+		ok, err = locker.Renew(ctx, key, "node1", time.Minute)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+
+		// Only the holder can unlock it.
+		t.AssertNil(locker.Unlock(ctx, key, "node2"))
+		ok, err = locker.TryLock(ctx, key, "node2", time.Minute)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+		t.AssertNil(locker.Unlock(ctx, key, "node1"))
+		ok, err = locker.TryLock(ctx, key, "node2", time.Minute)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_RateLimitRedisStore_TokenBucket(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			key   = guid.S()
+			store = ghttp.NewRateLimitRedisStore(redis)
+		)
+		defer redis.Del(ctx, key)
+		for i := 0; i < 3; i++ {
+			result, err := store.Allow(ctx, key, ghttp.RateLimitTokenBucket, 3, time.Minute)
+			t.AssertNil(err)
+			t.Assert(result.Allowed, true)
+			t.Assert(result.Remaining, 2-i)
+		}
+		result, err := store.Allow(ctx, key, ghttp.RateLimitTokenBucket, 3, time.Minute)
+		t.AssertNil(err)
+		t.Assert(result.Allowed, false)
+		t.Assert(result.Remaining, 0)
+		t.AssertGT(result.RetryAfter, 0)
+	})
+}
+
+func Test_RateLimitRedisStore_SlidingWindow(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			key   = guid.S()
+			store = ghttp.NewRateLimitRedisStore(redis)
+		)
+		defer redis.Del(ctx, key)
+		for i := 0; i < 3; i++ {
+			result, err := store.Allow(ctx, key, ghttp.RateLimitSlidingWindow, 3, time.Hour)
+			t.AssertNil(err)
+			t.Assert(result.Allowed, true)
+		}
+		result, err := store.Allow(ctx, key, ghttp.RateLimitSlidingWindow, 3, time.Hour)
+		t.AssertNil(err)
+		t.Assert(result.Allowed, false)
+		t.Assert(result.Remaining, 0)
+		t.AssertGT(result.RetryAfter, 0)
+	})
+}
@@ -0,0 +1,54 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gsession"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Session_UserSessions_Redis(t *testing.T) {
+	storages := []gsession.Storage{
+		gsession.NewStorageRedis(redis, "session:"),
+		gsession.NewStorageRedisHashTable(redis, "session-hash:"),
+	}
+	for _, storage := range storages {
+		gtest.C(t, func(t *gtest.T) {
+			var (
+				userId  = guid.S()
+				manager = gsession.New(time.Hour, storage)
+			)
+			s := manager.New(ctx)
+			t.AssertNil(s.Set("k", "v"))
+			anonymousId := s.MustId()
+			t.AssertNil(s.Close())
+
+			// Login rotates the session id and keeps the data.
+			s = manager.New(ctx, anonymousId)
+			t.AssertNil(s.SetUser(userId))
+			sessionId := s.MustId()
+			t.AssertNE(sessionId, anonymousId)
+			t.Assert(s.MustGet("k"), "v")
+			t.AssertNil(s.Close())
+
+			ids, err := manager.UserSessions(ctx, userId)
+			t.AssertNil(err)
+			t.Assert(ids, []string{sessionId})
+
+			t.AssertNil(manager.RevokeUserSessions(ctx, userId))
+			ids, err = manager.UserSessions(ctx, userId)
+			t.AssertNil(err)
+			t.Assert(len(ids), 0)
+			s = manager.New(ctx, sessionId)
+			t.Assert(s.MustGet("k"), nil)
+		})
+	}
+}
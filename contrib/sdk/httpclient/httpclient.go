@@ -49,22 +49,39 @@ func New(config Config) *Client {
 }
 
 // Request sends request to service by struct object `req`, and receives response to struct object `res`.
+//
+// The parameters of `req` are placed in path, query, header, cookie or body by the "in" tags of its
+// fields, see parseRequestParams. The body parameters are sent in json format.
 func (c *Client) Request(ctx context.Context, req, res any) error {
 	var (
 		method = gmeta.Get(req, gtag.Method).String()
 		path   = gmeta.Get(req, gtag.Path).String()
 	)
-	switch gstr.ToUpper(method) {
-	case http.MethodGet:
-		return c.Get(ctx, path, req, res)
-
-	default:
-		result, err := c.ContentJson().DoRequest(ctx, method, c.handlePath(path, req), req)
-		if err != nil {
-			return err
-		}
-		return c.HandleResponse(ctx, result, res)
+	if method == "" {
+		method = http.MethodGet
+	}
+	params, err := parseRequestParams(method, path, req)
+	if err != nil {
+		return err
+	}
+	var (
+		client = c.ContentJson()
+		data   []any
+	)
+	if len(params.Header) > 0 {
+		client = client.Header(params.Header)
+	}
+	if len(params.Cookie) > 0 {
+		client = client.Cookie(params.Cookie)
+	}
+	if len(params.Body) > 0 {
+		data = append(data, params.Body)
+	}
+	result, err := client.DoRequest(ctx, gstr.ToUpper(method), params.BuildPath(path), data...)
+	if err != nil {
+		return gerror.Wrap(err, `http request failed`)
 	}
+	return c.HandleResponse(ctx, result, res)
 }
 
 // Get sends a request using GET method.
@@ -0,0 +1,129 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package httpclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/goai"
+	"github.com/gogf/gf/v2/os/gstructs"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gtag"
+)
+
+// requestParams is the parameters of request object placed by their "in" tags.
+type requestParams struct {
+	Path   map[string]string // Parameters replacing the `{name}` in path.
+	Query  map[string]any    // Parameters in url query.
+	Header map[string]string // Parameters in request headers.
+	Cookie map[string]string // Parameters in request cookies.
+	Body   map[string]any    // Parameters in request body.
+}
+
+// parseRequestParams parses and places the parameters of request object `req` by "in" tags of its fields,
+// which follows the same rules as package goai generating the OpenAPI specification:
+// the field of "in" tag is placed in path/query/header/cookie, the field named as `{name}` in `path`
+// is placed in path, and the others are placed in query for GET/DELETE/HEAD method or body for other methods.
+func parseRequestParams(method, path string, req any) (*requestParams, error) {
+	fields, err := gstructs.Fields(gstructs.FieldsInput{
+		Pointer:         req,
+		RecursiveOption: gstructs.RecursiveOptionEmbedded,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var params = &requestParams{
+		Path:   make(map[string]string),
+		Query:  make(map[string]any),
+		Header: make(map[string]string),
+		Cookie: make(map[string]string),
+		Body:   make(map[string]any),
+	}
+	for _, field := range fields {
+		if !field.IsExported() {
+			continue
+		}
+		var (
+			nameArray = gstr.SplitAndTrim(field.TagPriorityName(), ",")
+			name      = field.Name()
+			in        = field.Tag(gtag.In)
+		)
+		if len(nameArray) > 0 && nameArray[0] != "" {
+			name = nameArray[0]
+		}
+		if name == "-" {
+			continue
+		}
+		// Empty value of "omitempty" field is not sent.
+		if len(nameArray) > 1 && gstr.InArray(nameArray[1:], "omitempty") && field.IsEmpty() {
+			continue
+		}
+		if in == "" {
+			switch {
+			case gstr.ContainsI(path, fmt.Sprintf(`{%s}`, name)):
+				in = goai.ParameterInPath
+			case isQueryMethod(method):
+				in = goai.ParameterInQuery
+			}
+		}
+		var value = field.Value.Interface()
+		switch in {
+		case goai.ParameterInPath:
+			params.Path[name] = gconv.String(value)
+		case goai.ParameterInQuery:
+			params.Query[name] = value
+		case goai.ParameterInHeader:
+			params.Header[name] = gconv.String(value)
+		case goai.ParameterInCookie:
+			params.Cookie[name] = gconv.String(value)
+		case "":
+			params.Body[name] = value
+		default:
+			return nil, gerror.NewCodef(
+				gcode.CodeInvalidParameter, `invalid tag value "%s" for In of field "%s"`, in, field.Name(),
+			)
+		}
+	}
+	return params, nil
+}
+
+// BuildPath replaces the `{name}` in `path` with path parameters and appends the query parameters.
+func (p *requestParams) BuildPath(path string) string {
+	for name, value := range p.Path {
+		path = gstr.ReplaceI(path, fmt.Sprintf(`{%s}`, name), url.PathEscape(value))
+	}
+	if len(p.Query) > 0 {
+		values := url.Values{}
+		for name, value := range p.Query {
+			for _, v := range gconv.Strings(value) {
+				values.Add(name, v)
+			}
+		}
+		if encoded := values.Encode(); encoded != "" {
+			if gstr.Contains(path, "?") {
+				path += "&" + encoded
+			} else {
+				path += "?" + encoded
+			}
+		}
+	}
+	return path
+}
+
+// isQueryMethod checks whether the parameters of `method` are placed in query in default.
+func isQueryMethod(method string) bool {
+	switch gstr.ToUpper(method) {
+	case http.MethodGet, http.MethodDelete, http.MethodHead:
+		return true
+	}
+	return false
+}
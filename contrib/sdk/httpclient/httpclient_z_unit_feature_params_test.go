@@ -0,0 +1,96 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package httpclient_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+
+	"github.com/gogf/gf/contrib/sdk/httpclient/v2"
+)
+
+func Test_HttpClient_Request_Params_Placement(t *testing.T) {
+	type UpdateReq struct {
+		g.Meta `path:"/user/{id}" method:"put"`
+		Id     int    `json:"id"`
+		Token  string `json:"token" in:"header"`
+		Lang   string `json:"lang"  in:"cookie"`
+		Force  bool   `json:"force" in:"query"`
+		Name   string `json:"name"`
+	}
+	type ListReq struct {
+		g.Meta `path:"/user" method:"get"`
+		Page   int    `json:"page"`
+		Token  string `json:"token" in:"header"`
+		Ids    []int  `json:"ids"`
+	}
+	type Res struct {
+		Path   string
+		Query  string
+		Header string
+		Cookie string
+		Body   string
+	}
+
+	s := g.Server(guid.S())
+	s.BindHandler("/user/*", func(r *ghttp.Request) {
+		r.Response.WriteJson(ghttp.DefaultHandlerResponse{
+			Data: Res{
+				Path:   r.URL.Path,
+				Query:  r.URL.RawQuery,
+				Header: r.Header.Get("token"),
+				Cookie: r.Cookie.Get("lang").String(),
+				Body:   r.GetBodyString(),
+			},
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+
+	client := httpclient.New(httpclient.Config{
+		URL: fmt.Sprintf("127.0.0.1:%d", s.GetListenedPort()),
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var res *Res
+		err := client.Request(gctx.New(), &UpdateReq{
+			Id:    1,
+			Token: "abc",
+			Lang:  "en",
+			Force: true,
+			Name:  "john",
+		}, &res)
+		t.AssertNil(err)
+		t.Assert(res.Path, "/user/1")
+		t.Assert(res.Query, "force=true")
+		t.Assert(res.Header, "abc")
+		t.Assert(res.Cookie, "en")
+		t.Assert(res.Body, `{"name":"john"}`)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var res *Res
+		err := client.Request(gctx.New(), &ListReq{
+			Page:  2,
+			Token: "abc",
+			Ids:   []int{1, 2},
+		}, &res)
+		t.AssertNil(err)
+		t.Assert(res.Path, "/user")
+		t.Assert(res.Query, "ids=1&ids=2&page=2")
+		t.Assert(res.Header, "abc")
+		t.Assert(res.Body, "")
+	})
+}
@@ -522,6 +522,9 @@ type Core struct {
 	localTypeMap  *gmap.StrAnyMap // Local type map for database field type conversion.
 	dynamicConfig dynamicConfig   // Dynamic configurations, which can be changed in runtime.
 	innerMemCache *gcache.Cache   // Internal memory cache for storing temporary data.
+	stickyCache   *gcache.Cache   // Context ids of which the reading operations are sticky to master node after writing.
+	slaveChecker  *slaveChecker   // Health checker for slave nodes, which is shared by the chaining copies.
+	owner         bool            // Whether the cache objects and slave checker are owned and closed by this core, which is false for Schema DB sharing them.
 }
 
 type dynamicConfig struct {
@@ -587,6 +590,13 @@ type Driver interface {
 	New(core *Core, node *ConfigNode) (DB, error)
 }
 
+// ReplicationLagProber is the optional interface for database objects of drivers,
+// which supports probing the replication lag of slave node.
+type ReplicationLagProber interface {
+	// GetReplicationLag retrieves and returns the replication lag of the slave node of `link`.
+	GetReplicationLag(ctx context.Context, link Link) (time.Duration, error)
+}
+
 // Link is a common database function wrapper interface.
 // Note that, any operation using `Link` will have no SQL logging.
 type Link interface {
@@ -711,6 +721,7 @@ const (
 	ctxKeyForDB               gctx.StrKey = `CtxKeyForDB`
 	ctxKeyCatchSQL            gctx.StrKey = `CtxKeyCatchSQL`
 	ctxKeyInternalProducedSQL gctx.StrKey = `CtxKeyInternalProducedSQL`
+	ctxKeyForMaster           gctx.StrKey = `CtxKeyForMaster`
 
 	linkPattern            = `^(\w+):(.*?):(.*?)@(\w+?)\((.+?)\)/{0,1}([^\?]*)\?{0,1}(.*?)$`
 	linkPatternDescription = `type:username:password@protocol(host:port)/dbname?param1=value1&...&paramN=valueN`
@@ -945,12 +956,15 @@ func newDBByConfigNode(node *ConfigNode, group string) (db DB, err error) {
 		config:        node,
 		localTypeMap:  gmap.NewStrAnyMap(true),
 		innerMemCache: gcache.New(),
+		stickyCache:   gcache.New(),
+		owner:         true,
 		dynamicConfig: dynamicConfig{
 			MaxIdleConnCount: node.MaxIdleConnCount,
 			MaxOpenConnCount: node.MaxOpenConnCount,
 			MaxConnLifeTime:  node.MaxConnLifeTime,
 		},
 	}
+	c.slaveChecker = newSlaveChecker(c)
 	if v, ok := driverMap[node.Type]; ok {
 		if c.db, err = v.New(c, node); err != nil {
 			return nil, err
@@ -973,6 +987,9 @@ func Instance(name ...string) (db DB, err error) {
 	}
 	v := instances.GetOrSetFuncLock(group, func() any {
 		db, err = NewByGroup(group)
+		if err == nil {
+			RegisterStats(db)
+		}
 		return db
 	})
 	if v != nil {
@@ -989,39 +1006,46 @@ func Instance(name ...string) (db DB, err error) {
 // The parameter `master` specifies whether retrieving a master node, or else a slave node
 // if master-slave nodes are configured.
 func getConfigNodeByGroup(group string, master bool) (*ConfigNode, error) {
-	if list, ok := configs.config[group]; ok {
-		// Separates master and slave configuration nodes array.
-		var (
-			masterList = make(ConfigGroup, 0)
-			slaveList  = make(ConfigGroup, 0)
+	masterList, slaveList, err := getConfigNodeListsByGroup(group)
+	if err != nil {
+		return nil, err
+	}
+	if len(slaveList) < 1 {
+		slaveList = masterList
+	}
+	if master {
+		return getConfigNodeByWeight(masterList), nil
+	}
+	return getConfigNodeByWeight(slaveList), nil
+}
+
+// getConfigNodeListsByGroup separates and returns the master and slave configuration nodes of given group.
+// The returned slave nodes are empty if no slave node configured.
+func getConfigNodeListsByGroup(group string) (masterList, slaveList ConfigGroup, err error) {
+	list, ok := configs.config[group]
+	if !ok {
+		return nil, nil, gerror.NewCodef(
+			gcode.CodeInvalidConfiguration,
+			"empty database configuration for item name '%s'",
+			group,
 		)
-		for i := 0; i < len(list); i++ {
-			if list[i].Role == dbRoleSlave {
-				slaveList = append(slaveList, list[i])
-			} else {
-				masterList = append(masterList, list[i])
-			}
-		}
-		if len(masterList) < 1 {
-			return nil, gerror.NewCode(
-				gcode.CodeInvalidConfiguration,
-				"at least one master node configuration's need to make sense",
-			)
-		}
-		if len(slaveList) < 1 {
-			slaveList = masterList
-		}
-		if master {
-			return getConfigNodeByWeight(masterList), nil
+	}
+	masterList = make(ConfigGroup, 0)
+	slaveList = make(ConfigGroup, 0)
+	for i := 0; i < len(list); i++ {
+		if list[i].Role == dbRoleSlave {
+			slaveList = append(slaveList, list[i])
 		} else {
-			return getConfigNodeByWeight(slaveList), nil
+			masterList = append(masterList, list[i])
 		}
 	}
-	return nil, gerror.NewCodef(
-		gcode.CodeInvalidConfiguration,
-		"empty database configuration for item name '%s'",
-		group,
-	)
+	if len(masterList) < 1 {
+		return nil, nil, gerror.NewCode(
+			gcode.CodeInvalidConfiguration,
+			"at least one master node configuration's need to make sense",
+		)
+	}
+	return masterList, slaveList, nil
 }
 
 // getConfigNodeByWeight calculates the configuration weights and randomly returns a node.
@@ -1082,7 +1106,11 @@ func (c *Core) getSqlDb(master bool, schema ...string) (sqlDb *sql.DB, err error
 		defer configs.RUnlock()
 		// Value COPY for node.
 		// The returned node is a clone of configuration node, which is safe for later modification.
-		node, err = getConfigNodeByGroup(c.group, master)
+		if master {
+			node, err = getConfigNodeByGroup(c.group, true)
+		} else {
+			node, err = c.getSlaveConfigNode()
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -1103,7 +1131,21 @@ func (c *Core) getSqlDb(master bool, schema ...string) (sqlDb *sql.DB, err error
 	if err = c.setConfigNodeToCtx(ctx, node); err != nil {
 		return
 	}
+	if sqlDb, err = c.getSqlDbByNode(node); err != nil {
+		return
+	}
+	if node.Debug {
+		c.db.SetDebug(node.Debug)
+	}
+	if node.DryRun {
+		c.db.SetDryRun(node.DryRun)
+	}
+	return
+}
 
+// getSqlDbByNode retrieves and returns the underlying database connection object of `node`,
+// which is cached by node and is created if it does not exist.
+func (c *Core) getSqlDbByNode(node *ConfigNode) (sqlDb *sql.DB, err error) {
 	// Cache the underlying connection pool object by node.
 	var (
 		instanceCacheFunc = func() any {
@@ -1137,11 +1179,5 @@ func (c *Core) getSqlDb(master bool, schema ...string) (sqlDb *sql.DB, err error
 		// It reads from instance map.
 		sqlDb = instanceValue.(*sql.DB)
 	}
-	if node.Debug {
-		c.db.SetDebug(node.Debug)
-	}
-	if node.DryRun {
-		c.db.SetDryRun(node.DryRun)
-	}
 	return
 }
@@ -109,8 +109,20 @@ func (c *Core) GetCtxTimeout(ctx context.Context, timeoutType ctxTimeoutType) (c
 // It is rare to Close a DB, as the DB handle is meant to be
 // long-lived and shared between many goroutines.
 func (c *Core) Close(ctx context.Context) (err error) {
-	if err = c.cache.Close(ctx); err != nil {
-		return err
+	statsCores.Remove(c)
+	// The shared objects are closed by their owner only, as the Schema DB shares them with its parent.
+	if c.owner {
+		if err = c.cache.Close(ctx); err != nil {
+			return err
+		}
+		if c.stickyCache != nil {
+			if err = c.stickyCache.Close(ctx); err != nil {
+				return err
+			}
+		}
+		if c.slaveChecker != nil {
+			c.slaveChecker.stop()
+		}
 	}
 	c.links.LockFunc(func(m map[any]any) {
 		for k, v := range m {
@@ -139,6 +139,33 @@ type ConfigNode struct {
 	// TimeMaintainDisabled controls whether automatic time maintenance is disabled
 	// Optional field
 	TimeMaintainDisabled bool `json:"timeMaintainDisabled"`
+
+	// VersionField specifies the field name of integer type for optimistic locking of Model.Update,
+	// which enables the optimistic locking for all tables having this field, see Model.OptimisticLock.
+	// Note that it changes the behavior of Model.Update on these tables, as the field is always increased.
+	// Optional field, the optimistic locking is only enabled by Model.OptimisticLock if it is empty
+	VersionField string `json:"versionField"`
+
+	// CacheAutoInvalidate enables automatic invalidation of select caches by Model.Cache,
+	// which evicts the caches of tables written by insert/update/delete statements of Model.
+	// Optional field, it requires the cache adapter implementing gcache.TagAdapter
+	CacheAutoInvalidate bool `json:"cacheAutoInvalidate"`
+
+	// StickyMasterDuration specifies the duration that reading operations are routed to master node
+	// after a writing operation in the same context, which guarantees read-your-writes consistency.
+	// The context is identified by its trace id, see gctx.CtxId.
+	// Optional field, only effective in master-slave setups, 0 means disabled
+	StickyMasterDuration time.Duration `json:"stickyMasterDuration"`
+
+	// SlaveCheckInterval specifies the interval for checking the health of slave nodes.
+	// The slave nodes failing the checks are ejected from reading routing until they pass the checks.
+	// Optional field, only effective in master-slave setups, 0 means disabled
+	SlaveCheckInterval time.Duration `json:"slaveCheckInterval"`
+
+	// SlaveMaxLag specifies the max replication lag of slave nodes, the slave nodes lagging more than it
+	// are ejected from reading routing by the health checks.
+	// Optional field, it requires SlaveCheckInterval and the driver supporting ReplicationLagProber like mysql and pgsql
+	SlaveMaxLag time.Duration `json:"slaveMaxLag"`
 }
 
 type Role string
@@ -367,6 +394,17 @@ func (c *Core) GetDryRun() bool {
 	return c.config.DryRun || allDryRun
 }
 
+// SetCacheAutoInvalidate enables/disables the automatic invalidation of select caches.
+// See ConfigNode.CacheAutoInvalidate.
+func (c *Core) SetCacheAutoInvalidate(enabled bool) {
+	c.config.CacheAutoInvalidate = enabled
+}
+
+// GetCacheAutoInvalidate returns whether the automatic invalidation of select caches is enabled.
+func (c *Core) GetCacheAutoInvalidate() bool {
+	return c.config.CacheAutoInvalidate
+}
+
 // GetPrefix returns the table prefix string configured.
 func (c *Core) GetPrefix() string {
 	return c.config.Prefix
@@ -0,0 +1,226 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gtimer"
+)
+
+// slaveChecker checks the health of slave nodes timely and ejects the failing ones from reading routing.
+type slaveChecker struct {
+	core    *Core           // The core that the checker belongs to.
+	mu      sync.Mutex      // Mutex for starting and stopping.
+	entry   *gtimer.Entry   // Timer entry for checking, which is nil if not started.
+	ejected *gmap.StrAnyMap // Ejected slave nodes, which maps node key to its failure error.
+}
+
+// WithMaster returns a new context from `ctx`, with which the reading operations are routed to
+// master node if master-slave configured. It is usually used for reading the data just written.
+func WithMaster(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKeyForMaster, struct{}{})
+}
+
+// isMasterReading checks and returns whether the reading operations of `ctx` are routed to master node,
+// which is true if `ctx` is created by WithMaster, or it is sticky to master node after writing
+// in StickyMasterDuration of the configuration.
+func (c *Core) isMasterReading(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	if ctx.Value(ctxKeyForMaster) != nil {
+		return true
+	}
+	if c.config.StickyMasterDuration <= 0 {
+		return false
+	}
+	ctxId := gctx.CtxId(ctx)
+	if ctxId == "" {
+		return false
+	}
+	ok, err := c.stickyCache.Contains(ctx, ctxId)
+	if err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+	return ok
+}
+
+// markStickyMaster marks the reading operations of `ctx` routed to master node in StickyMasterDuration,
+// which is called after writing operations.
+func (c *Core) markStickyMaster(ctx context.Context) {
+	if c.config.StickyMasterDuration <= 0 {
+		return
+	}
+	ctxId := gctx.CtxId(ctx)
+	if ctxId == "" {
+		return
+	}
+	err := c.stickyCache.Set(ctx, ctxId, struct{}{}, c.config.StickyMasterDuration)
+	if err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+}
+
+// slaveLink creates and returns a link for reading operations of `ctx`.
+// It returns the master link if the reading operations of `ctx` are routed to master node.
+func (c *Core) slaveLink(ctx context.Context, schema ...string) (Link, error) {
+	if c.isMasterReading(ctx) {
+		return c.MasterLink(schema...)
+	}
+	db, err := c.db.Slave(schema...)
+	if err != nil {
+		return nil, err
+	}
+	return &dbLink{
+		DB:         db,
+		isOnMaster: false,
+	}, nil
+}
+
+// getSlaveConfigNode calculates and returns a slave configuration node of current group,
+// excluding the ones ejected by the health checks. It returns a master node if no slave node available.
+//
+// The returned node is a clone of configuration node, which is safe for later modification.
+func (c *Core) getSlaveConfigNode() (*ConfigNode, error) {
+	masterList, slaveList, err := getConfigNodeListsByGroup(c.group)
+	if err != nil {
+		return nil, err
+	}
+	if c.slaveChecker != nil {
+		c.slaveChecker.start()
+		if c.slaveChecker.ejected.Size() > 0 {
+			availableList := make(ConfigGroup, 0, len(slaveList))
+			for _, node := range slaveList {
+				if !c.slaveChecker.ejected.Contains(getConfigNodeKey(&node)) {
+					availableList = append(availableList, node)
+				}
+			}
+			slaveList = availableList
+		}
+	}
+	if len(slaveList) < 1 {
+		slaveList = masterList
+	}
+	return getConfigNodeByWeight(slaveList), nil
+}
+
+// getReplicationLagProber returns the ReplicationLagProber of the driver, or nil if it is not supported.
+func (c *Core) getReplicationLagProber() ReplicationLagProber {
+	var db = c.db
+	if wrapper, ok := db.(*DriverWrapperDB); ok {
+		db = wrapper.DB
+	}
+	prober, _ := db.(ReplicationLagProber)
+	return prober
+}
+
+// getConfigNodeKey returns the key identifying the configuration node, which contains no password.
+func getConfigNodeKey(node *ConfigNode) string {
+	return fmt.Sprintf(`%s@%s:%s/%s`, node.User, node.Host, node.Port, node.Name)
+}
+
+// newSlaveChecker creates and returns a slave checker for `core`.
+func newSlaveChecker(core *Core) *slaveChecker {
+	return &slaveChecker{
+		core:    core,
+		ejected: gmap.NewStrAnyMap(true),
+	}
+}
+
+// start starts checking timely if SlaveCheckInterval is configured, it does nothing if it is started.
+func (s *slaveChecker) start() {
+	if s.core.config.SlaveCheckInterval <= 0 || s.core.group == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entry != nil {
+		return
+	}
+	s.entry = gtimer.AddSingleton(context.Background(), s.core.config.SlaveCheckInterval, s.checkSlaves)
+}
+
+// stop stops the timely checking.
+func (s *slaveChecker) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entry != nil {
+		s.entry.Close()
+		s.entry = nil
+	}
+	s.ejected.Clear()
+}
+
+// checkSlaves checks all the slave nodes of the group and updates the ejected nodes.
+func (s *slaveChecker) checkSlaves(ctx context.Context) {
+	configs.RLock()
+	_, slaveList, err := getConfigNodeListsByGroup(s.core.group)
+	configs.RUnlock()
+	if err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+		return
+	}
+	for _, node := range slaveList {
+		var key = getConfigNodeKey(&node)
+		if err = s.checkSlave(ctx, node); err != nil {
+			if !s.ejected.Contains(key) {
+				s.core.logger.Warningf(ctx, `slave node "%s" is ejected from reading: %+v`, key, err)
+			}
+			s.ejected.Set(key, err)
+		} else if s.ejected.Remove(key) != nil {
+			s.core.logger.Infof(ctx, `slave node "%s" is recovered for reading`, key)
+		}
+	}
+}
+
+// checkSlave checks the connection and the replication lag of slave `node`.
+func (s *slaveChecker) checkSlave(ctx context.Context, node ConfigNode) error {
+	if node.Charset == "" {
+		node.Charset = defaultCharset
+	}
+	sqlDb, err := s.core.getSqlDbByNode(&node)
+	if err != nil {
+		return err
+	}
+	if sqlDb == nil {
+		return gerror.NewCode(gcode.CodeDbOperationError, `open connection failed`)
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.core.config.SlaveCheckInterval)
+	defer cancel()
+	if err = sqlDb.PingContext(ctx); err != nil {
+		return gerror.WrapCode(gcode.CodeDbOperationError, err, `ping failed`)
+	}
+	var maxLag = s.core.config.SlaveMaxLag
+	if maxLag <= 0 {
+		return nil
+	}
+	prober := s.core.getReplicationLagProber()
+	if prober == nil {
+		return nil
+	}
+	lag, err := prober.GetReplicationLag(
+		context.WithValue(ctx, ctxKeyInternalProducedSQL, struct{}{}),
+		&dbLink{DB: sqlDb},
+	)
+	if err != nil {
+		return err
+	}
+	if lag > maxLag {
+		return gerror.NewCodef(
+			gcode.CodeDbOperationError, `replication lag %s exceeds %s`, lag.String(), maxLag.String(),
+		)
+	}
+	return nil
+}
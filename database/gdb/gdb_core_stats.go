@@ -10,6 +10,14 @@ package gdb
 import (
 	"context"
 	"database/sql"
+
+	"github.com/gogf/gf/v2/container/gmap"
+)
+
+var (
+	// statsCores is the registry of the not closed cores of singleton DB objects,
+	// which is used for retrieving pool stats of all databases.
+	statsCores = gmap.New(true)
 )
 
 type localStatsItem struct {
@@ -43,3 +51,26 @@ func (c *Core) Stats(ctx context.Context) []StatsItem {
 	})
 	return items
 }
+
+// RegisterStats registers `db` for retrieving its pool stats by GetAllStats until it is closed.
+// The singleton DB objects by Instance are registered automatically.
+func RegisterStats(db DB) {
+	if db == nil || db.GetCore() == nil {
+		return
+	}
+	statsCores.Set(db.GetCore(), struct{}{})
+}
+
+// GetAllStats retrieves and returns the pool stats of the registered and not closed DB objects,
+// which is mapped by their configuration group names.
+// The DB objects created by New/NewByGroup are not included unless they are registered by RegisterStats,
+// as they might be created and discarded frequently without closing.
+func GetAllStats(ctx context.Context) map[string][]StatsItem {
+	var stats = make(map[string][]StatsItem)
+	statsCores.Iterator(func(k, v any) bool {
+		core := k.(*Core)
+		stats[core.group] = append(stats[core.group], core.Stats(ctx)...)
+		return true
+	})
+	return stats
+}
@@ -11,8 +11,10 @@ import (
 	"database/sql"
 	"reflect"
 
+	"github.com/gogf/gf/v2/container/gset"
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
 	"github.com/gogf/gf/v2/internal/reflection"
 	"github.com/gogf/gf/v2/text/gregex"
 	"github.com/gogf/gf/v2/util/gconv"
@@ -44,6 +46,9 @@ type TXCore struct {
 	// cancelFunc is the context cancellation function associated with ctx,
 	// used to cancel the transaction context when needed.
 	cancelFunc context.CancelFunc
+	// cacheTags is the tags of select caches written in this transaction,
+	// which are invalidated after the transaction is committed.
+	cacheTags *gset.StrSet
 }
 
 func (c *Core) newEmptyTX() TX {
@@ -101,6 +106,7 @@ func (tx *TXCore) Commit() error {
 	})
 	if err == nil {
 		tx.isClosed = true
+		tx.invalidateCacheTags()
 	}
 	return err
 }
@@ -435,3 +441,32 @@ func (tx *TXCore) IsOnMaster() bool {
 func (tx *TXCore) IsTransaction() bool {
 	return tx != nil
 }
+
+// addCacheTags adds `tags` of select caches to be invalidated after the transaction is committed.
+// It returns false if there's no underlying transaction in progress.
+func (tx *TXCore) addCacheTags(tags []string) bool {
+	if tx.tx == nil || tx.isClosed {
+		return false
+	}
+	if tx.cacheTags == nil {
+		tx.cacheTags = gset.NewStrSet(true)
+	}
+	tx.cacheTags.Add(tags...)
+	return true
+}
+
+// invalidateCacheTags invalidates the select caches written in the transaction.
+func (tx *TXCore) invalidateCacheTags() {
+	if tx.cacheTags == nil || tx.cacheTags.Size() == 0 {
+		return
+	}
+	// The transaction context is canceled after committed.
+	var ctx = context.Background()
+	if tx.ctx != nil {
+		ctx = context.WithoutCancel(tx.ctx)
+	}
+	if err := tx.db.GetCache().InvalidateTags(ctx, tx.cacheTags.Slice()...); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+	tx.cacheTags.Clear()
+}
@@ -40,8 +40,8 @@ func (c *Core) DoQuery(ctx context.Context, link Link, sql string, args ...any)
 		if tx := TXFromCtx(ctx, c.db.GetGroup()); tx != nil {
 			// Firstly, check and retrieve transaction link from context.
 			link = &txLink{tx.GetSqlTX()}
-		} else if link, err = c.SlaveLink(); err != nil {
-			// Or else it creates one from master node.
+		} else if link, err = c.slaveLink(ctx); err != nil {
+			// Or else it creates one from slave node.
 			return nil, err
 		}
 	} else if !link.IsTransaction() {
@@ -139,6 +139,7 @@ func (c *Core) DoExec(ctx context.Context, link Link, sql string, args ...any) (
 	if err != nil {
 		return nil, err
 	}
+	c.markStickyMaster(ctx)
 	return out.Result, err
 }
 
@@ -324,7 +325,7 @@ func (c *Core) Prepare(ctx context.Context, sql string, execOnMaster ...bool) (*
 			return nil, err
 		}
 	} else {
-		if link, err = c.SlaveLink(); err != nil {
+		if link, err = c.slaveLink(ctx); err != nil {
 			return nil, err
 		}
 	}
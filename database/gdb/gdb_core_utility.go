@@ -37,7 +37,7 @@ func (c *Core) GetLink(ctx context.Context, master bool, schema string) (Link, e
 		}
 		return link, nil
 	}
-	link, err := c.db.GetCore().SlaveLink(schema)
+	link, err := c.db.GetCore().slaveLink(ctx, schema)
 	if err != nil {
 		return nil, err
 	}
@@ -61,15 +61,11 @@ func (c *Core) MasterLink(schema ...string) (Link, error) {
 // SlaveLink acts like function Slave but with additional `schema` parameter specifying
 // the schema for the connection. It is defined for internal usage.
 // Also see Slave.
+//
+// It returns the master link if the reading operations of current context are routed to master node,
+// see WithMaster and ConfigNode.StickyMasterDuration.
 func (c *Core) SlaveLink(schema ...string) (Link, error) {
-	db, err := c.db.Slave(schema...)
-	if err != nil {
-		return nil, err
-	}
-	return &dbLink{
-		DB:         db,
-		isOnMaster: false,
-	}, nil
+	return c.slaveLink(c.db.GetCtx(), schema...)
 }
 
 // QuoteWord checks given string `s` a word,
@@ -53,6 +53,7 @@ type Model struct {
 	onConflict     any               // onConflict is used for conflict keys on Upsert clause.
 	tableAliasMap  map[string]string // Table alias to true table name, usually used in join statements.
 	softTimeOption SoftTimeOption    // SoftTimeOption is the option to customize soft time feature for Model.
+	versionField   *string           // Version field for optimistic locking, it is detected by ConfigNode.VersionField if nil, and disabled if empty.
 	shardingConfig ShardingConfig    // ShardingConfig for database/table sharding feature.
 	shardingValue  any               // Sharding value for sharding feature.
 }
@@ -8,9 +8,15 @@ package gdb
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/text/gregex"
+	"github.com/gogf/gf/v2/text/gstr"
 )
 
 // CacheOption is options for model cache control in query.
@@ -29,8 +35,26 @@ type CacheOption struct {
 	// Force caches the query result whatever the result is nil or not.
 	// It is used to avoid Cache Penetration.
 	Force bool
+
+	// Tags is optional tags bound to the cache, like table name or entity tag "user:42".
+	// The caches can be cleared by tags using `Duration` < 0 in insert/update/delete statement,
+	// or using function InvalidateTags of the cache object of the database.
+	// Note that it requires the cache adapter implementing gcache.TagAdapter.
+	Tags []string
 }
 
+const (
+	// tableCacheTagPrefix is the prefix of cache tags of tables for automatic invalidation.
+	tableCacheTagPrefix = "SelectCacheTable:"
+
+	// modelTablesPattern matches the table names in tables of model, which follow the beginning,
+	// comma, "JOIN" or "FROM" of sub-query.
+	modelTablesPattern = `(?i)(?:^|,|\bJOIN\s|\bFROM\s)\s*([^\s,()]+)`
+
+	// rawSqlTablePattern matches the table names following "JOIN" or "FROM" in raw sql.
+	rawSqlTablePattern = `(?i)(?:\bJOIN\s|\bFROM\s)\s*([^\s,()]+)`
+)
+
 // selectCacheItem is the cache item for SELECT statement result.
 type selectCacheItem struct {
 	Result            Result // Sql result of SELECT statement.
@@ -53,16 +77,71 @@ func (m *Model) Cache(option CacheOption) *Model {
 // checkAndRemoveSelectCache checks and removes the cache in insert/update/delete statement if
 // cache feature is enabled.
 func (m *Model) checkAndRemoveSelectCache(ctx context.Context) {
-	if m.cacheEnabled && m.cacheOption.Duration < 0 && len(m.cacheOption.Name) > 0 {
+	m.checkAndInvalidateTableCache(ctx)
+	if !m.cacheEnabled || m.cacheOption.Duration >= 0 {
+		return
+	}
+	if len(m.cacheOption.Name) > 0 {
 		var cacheKey = m.makeSelectCacheKey("")
 		if _, err := m.db.GetCache().Remove(ctx, cacheKey); err != nil {
 			intlog.Errorf(ctx, `%+v`, err)
 		}
 	}
+	m.checkAndInvalidateCacheTags(ctx)
+}
+
+// checkAndInvalidateTableCache removes the select caches of tables written by the model
+// if the cache auto invalidation feature is enabled.
+// It invalidates the caches after the transaction is committed if it's in transaction.
+func (m *Model) checkAndInvalidateTableCache(ctx context.Context) {
+	if !m.db.GetCore().GetCacheAutoInvalidate() {
+		return
+	}
+	tags := m.getTableCacheTags()
+	if len(tags) == 0 {
+		return
+	}
+	var tx = m.tx
+	if tx == nil {
+		tx = TXFromCtx(ctx, m.db.GetGroup())
+	}
+	if txCore, ok := tx.(*TXCore); ok && txCore.addCacheTags(tags) {
+		return
+	}
+	if err := m.db.GetCache().InvalidateTags(ctx, tags...); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+}
+
+// isInTransaction checks and returns whether the model is operating on a transaction,
+// in which case the select cache feature is disabled.
+// The transaction from context is also checked if the cache auto invalidation feature is enabled,
+// as the uncommitted results should not be cached.
+func (m *Model) isInTransaction(ctx context.Context) bool {
+	if m.tx != nil {
+		return true
+	}
+	if !m.db.GetCore().GetCacheAutoInvalidate() {
+		return false
+	}
+	if txCore, ok := TXFromCtx(ctx, m.db.GetGroup()).(*TXCore); ok {
+		return txCore.tx != nil && !txCore.isClosed
+	}
+	return false
+}
+
+// checkAndInvalidateCacheTags removes the caches bound to tags of cache option if any.
+func (m *Model) checkAndInvalidateCacheTags(ctx context.Context) {
+	if len(m.cacheOption.Tags) == 0 {
+		return
+	}
+	if err := m.db.GetCache().InvalidateTags(ctx, m.cacheOption.Tags...); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
 }
 
 func (m *Model) getSelectResultFromCache(ctx context.Context, sql string, args ...any) (result Result, err error) {
-	if !m.cacheEnabled || m.tx != nil {
+	if !m.cacheEnabled || m.isInTransaction(ctx) {
 		return
 	}
 	var (
@@ -92,7 +171,7 @@ func (m *Model) getSelectResultFromCache(ctx context.Context, sql string, args .
 func (m *Model) saveSelectResultToCache(
 	ctx context.Context, selectType SelectType, result Result, sql string, args ...any,
 ) (err error) {
-	if !m.cacheEnabled || m.tx != nil {
+	if !m.cacheEnabled || m.isInTransaction(ctx) {
 		return
 	}
 	var (
@@ -103,6 +182,7 @@ func (m *Model) saveSelectResultToCache(
 		if _, errCache := cacheObj.Remove(ctx, cacheKey); errCache != nil {
 			intlog.Errorf(ctx, `%+v`, errCache)
 		}
+		m.checkAndInvalidateCacheTags(ctx)
 		return
 	}
 	// Special handler for Value/Count operations result.
@@ -136,6 +216,20 @@ func (m *Model) saveSelectResultToCache(
 	if internalData := core.getInternalColumnFromCtx(ctx); internalData != nil {
 		cacheItem.FirstResultColumn = internalData.FirstResultColumn
 	}
+	var tags = m.cacheOption.Tags
+	if core.GetCacheAutoInvalidate() {
+		tags = append(m.getTableCacheTags(), tags...)
+	}
+	if len(tags) > 0 {
+		errCache := cacheObj.SetWithTags(ctx, cacheKey, cacheItem, m.cacheOption.Duration, tags)
+		if errCache != nil {
+			intlog.Errorf(ctx, `%+v`, errCache)
+		}
+		// It falls back to cache without tags if the adapter does not support tags.
+		if gerror.Code(errCache) != gcode.CodeNotSupported {
+			return
+		}
+	}
 	if errCache := cacheObj.Set(ctx, cacheKey, cacheItem, m.cacheOption.Duration); errCache != nil {
 		intlog.Errorf(ctx, `%+v`, errCache)
 	}
@@ -158,3 +252,37 @@ func (m *Model) makeSelectCacheKey(sql string, args ...any) string {
 		args...,
 	)
 }
+
+// getTableCacheTags returns the cache tags of tables operated by the model, including joined tables
+// and tables in sub-queries, which are used for automatic invalidation of select caches.
+func (m *Model) getTableCacheTags() []string {
+	var (
+		matches [][]string
+		tags    []string
+		tagSet  = make(map[string]struct{})
+	)
+	if m.rawSql != "" {
+		matches, _ = gregex.MatchAllString(rawSqlTablePattern, m.rawSql)
+	} else {
+		matches, _ = gregex.MatchAllString(modelTablesPattern, m.tables)
+	}
+	for _, match := range matches {
+		// It uses the last part for name like "schema"."table".
+		parts := gstr.Split(match[1], ".")
+		table := strings.ToLower(gstr.Trim(parts[len(parts)-1], "`\"[]'"))
+		if !gregex.IsMatchString(`^[\w$]+$`, table) {
+			continue
+		}
+		tag := genTableCacheTag(m.db.GetGroup(), m.db.GetSchema(), table)
+		if _, ok := tagSet[tag]; !ok {
+			tagSet[tag] = struct{}{}
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// genTableCacheTag generates and returns the cache tag for `table`.
+func genTableCacheTag(group, schema, table string) string {
+	return fmt.Sprintf(`%s%s@%s#%s`, tableCacheTagPrefix, group, schema, table)
+}
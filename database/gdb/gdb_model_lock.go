@@ -6,6 +6,11 @@
 
 package gdb
 
+import (
+	"context"
+	"strings"
+)
+
 // LockUpdate sets the lock for update for current operation.
 func (m *Model) LockUpdate() *Model {
 	model := m.getModel()
@@ -19,3 +24,87 @@ func (m *Model) LockShared() *Model {
 	model.lockInfo = "LOCK IN SHARE MODE"
 	return model
 }
+
+// OptimisticLock sets the version field for optimistic locking of Update operation.
+//
+// If the updating data contains the version field, the Update operation appends condition
+// "WHERE version=?" with the version value of the data, and it returns error with code
+// gcode.CodeOptimisticLockConflict if no record is affected, which means the record is modified by others.
+// The version field is increased by 1 for updating, unless it is maintained by the caller using Counter/Raw
+// or assigned in updating string of configured version field.
+// Note that the optimistic locking set by OptimisticLock requires map/struct updating data.
+//
+// The optimistic locking is disabled in default. If ConfigNode.VersionField is configured, the field of integer
+// type with this name is detected for all tables if OptimisticLock is not called, which changes the behavior of
+// Update on these tables: the version field is always increased, and the updating with version value in data
+// returns error if it conflicts. The parameter `field` of empty string disables the detected optimistic locking.
+func (m *Model) OptimisticLock(field string) *Model {
+	model := m.getModel()
+	model.versionField = &field
+	return model
+}
+
+// getVersionField retrieves and returns the version field for optimistic locking of current table.
+// The returned `explicit` indicates whether the field is set by OptimisticLock.
+func (m *Model) getVersionField(ctx context.Context) (fieldName string, explicit bool) {
+	if m.versionField != nil {
+		return *m.versionField, true
+	}
+	// The detection is opt-in, as it changes the behavior of Update.
+	var versionField = m.db.GetConfig().VersionField
+	if versionField == "" {
+		return "", false
+	}
+	fieldName, fieldType := m.softTimeMaintainer().(*softTimeMaintainer).getSoftFieldNameAndType(
+		ctx, "", m.tablesInit, []string{versionField},
+	)
+	switch fieldType {
+	case LocalTypeInt, LocalTypeUint, LocalTypeInt64, LocalTypeUint64, LocalTypeBigInt:
+		return fieldName, false
+	default:
+		return "", false
+	}
+}
+
+// isFieldAssignedInUpdateStr checks whether `field` is assigned in updating string `updateStr`,
+// like "nickname=?,version=version+1" or "`nickname`='john', `user`.`version`=2".
+func isFieldAssignedInUpdateStr(updateStr, field string) bool {
+	var (
+		quote      rune
+		depth      int
+		assignment strings.Builder
+		assigned   = func(assignment string) bool {
+			name, _, ok := strings.Cut(assignment, "=")
+			if !ok {
+				return false
+			}
+			name = strings.TrimSpace(name)
+			if pos := strings.LastIndexByte(name, '.'); pos != -1 {
+				name = name[pos+1:]
+			}
+			return strings.EqualFold(strings.Trim(name, "`\"[]"), field)
+		}
+	)
+	for _, c := range updateStr {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			if assigned(assignment.String()) {
+				return true
+			}
+			assignment.Reset()
+			continue
+		}
+		assignment.WriteRune(c)
+	}
+	return assigned(assignment.String())
+}
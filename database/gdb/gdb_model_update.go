@@ -16,6 +16,7 @@ import (
 	"github.com/gogf/gf/v2/internal/empty"
 	"github.com/gogf/gf/v2/internal/intlog"
 	"github.com/gogf/gf/v2/internal/reflection"
+	"github.com/gogf/gf/v2/internal/utils"
 	"github.com/gogf/gf/v2/text/gstr"
 	"github.com/gogf/gf/v2/util/gconv"
 )
@@ -57,6 +58,10 @@ func (m *Model) Update(dataAndWhere ...any) (result sql.Result, err error) {
 	if fieldNameUpdate != "" && (m.unscoped || m.isFieldInFieldsEx(fieldNameUpdate)) {
 		fieldNameUpdate = ""
 	}
+	var (
+		versionField, versionExplicit = m.getVersionField(ctx)
+		versionValue                  any
+	)
 
 	newData, err = m.filterDataForInsertOrUpdate(m.data)
 	if err != nil {
@@ -71,6 +76,31 @@ func (m *Model) Update(dataAndWhere ...any) (result sql.Result, err error) {
 			dataValue := stm.GetValueByFieldTypeForCreateOrUpdate(ctx, fieldTypeUpdate, false)
 			dataMap[fieldNameUpdate] = dataValue
 		}
+		// Optimistic locking with version field.
+		if versionField != "" {
+			var versionKey string
+			for dataKey, dataValue := range dataMap {
+				if utils.EqualFoldWithoutChars(dataKey, versionField) {
+					versionKey, versionValue = dataKey, dataValue
+					break
+				}
+			}
+			switch versionValue.(type) {
+			case Counter, *Counter, Raw, *Raw:
+				// The version field is maintained by the caller, no optimistic locking.
+				versionValue = nil
+			default:
+				if empty.IsNil(versionValue) && versionExplicit {
+					return nil, gerror.NewCodef(
+						gcode.CodeMissingParameter,
+						`version field "%s" is missing in updating data for optimistic locking`,
+						versionField,
+					)
+				}
+				delete(dataMap, versionKey)
+				dataMap[versionField] = &Counter{Field: versionField, Value: 1}
+			}
+		}
 		newData = dataMap
 
 	default:
@@ -81,6 +111,28 @@ func (m *Model) Update(dataAndWhere ...any) (result sql.Result, err error) {
 			updateStr += fmt.Sprintf(`,%s=?`, fieldNameUpdate)
 			conditionArgs = append([]any{dataValue}, conditionArgs...)
 		}
+		// Optimistic locking with version field, which needs the version value of map/struct data,
+		// so it only increases the version for string data if it is not assigned by the caller.
+		if versionField != "" {
+			var versionAssigned = isFieldAssignedInUpdateStr(updateStr, versionField)
+			switch {
+			case versionExplicit && versionAssigned:
+				return nil, gerror.NewCodef(
+					gcode.CodeInvalidParameter,
+					`version field "%s" cannot be assigned in updating string for optimistic locking`,
+					versionField,
+				)
+			case versionExplicit:
+				return nil, gerror.NewCodef(
+					gcode.CodeMissingParameter,
+					`version field "%s" is missing in updating data for optimistic locking`,
+					versionField,
+				)
+			case !versionAssigned:
+				var quotedVersionField = m.db.GetCore().QuoteWord(versionField)
+				updateStr += fmt.Sprintf(`,%s=%s+1`, quotedVersionField, quotedVersionField)
+			}
+		}
 		newData = updateStr
 	}
 
@@ -96,6 +148,15 @@ func (m *Model) Update(dataAndWhere ...any) (result sql.Result, err error) {
 		)
 	}
 
+	// Condition for optimistic locking.
+	if !empty.IsNil(versionValue) {
+		conditionStr = fmt.Sprintf(
+			` WHERE (%s) AND %s=?%s`,
+			gstr.TrimLeftStr(conditionWhere, " WHERE "), m.db.GetCore().QuoteWord(versionField), conditionExtra,
+		)
+		conditionArgs = append(conditionArgs, versionValue)
+	}
+
 	in := &HookUpdateInput{
 		internalParamHookUpdate: internalParamHookUpdate{
 			internalParamHook: internalParamHook{
@@ -110,7 +171,21 @@ func (m *Model) Update(dataAndWhere ...any) (result sql.Result, err error) {
 		Condition: conditionStr,
 		Args:      m.mergeArguments(conditionArgs),
 	}
-	return in.Next(ctx)
+	if result, err = in.Next(ctx); err != nil || empty.IsNil(versionValue) || m.db.GetDryRun() {
+		return
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return result, err
+	}
+	if affected == 0 {
+		return result, gerror.NewCodef(
+			gcode.CodeOptimisticLockConflict,
+			`optimistic lock conflict on table "%s": no record with %s=%v updated`,
+			m.tablesInit, versionField, versionValue,
+		)
+	}
+	return
 }
 
 // UpdateAndGetAffected performs update statement and returns the affected rows number.
@@ -6,6 +6,10 @@
 
 package gdb
 
+import (
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
 // Schema is a schema object from which it can then create a Model.
 type Schema struct {
 	DB
@@ -20,9 +24,16 @@ func (c *Core) Schema(schema string) *Schema {
 		panic(err)
 	}
 	core := db.GetCore()
+	// The sticky cache of the new db is replaced by the shared one, which should be closed.
+	if err = core.stickyCache.Close(c.db.GetCtx()); err != nil {
+		intlog.Errorf(c.db.GetCtx(), `%+v`, err)
+	}
 	// Different schema share some same objects.
 	core.logger = c.logger
 	core.cache = c.cache
+	core.stickyCache = c.stickyCache
+	core.slaveChecker = c.slaveChecker
+	core.owner = false
 	core.schema = schema
 	return &Schema{
 		DB: db,
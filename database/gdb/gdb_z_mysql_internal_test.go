@@ -7,6 +7,7 @@
 package gdb
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -426,3 +427,54 @@ func Test_isSubQuery(t *testing.T) {
 		t.Assert(isSubQuery("select 1"), true)
 	})
 }
+
+func Test_isFieldAssignedInUpdateStr(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(isFieldAssignedInUpdateStr("version=?", "version"), true)
+		t.Assert(isFieldAssignedInUpdateStr("nickname='a', `Version`=version+1", "version"), true)
+		t.Assert(isFieldAssignedInUpdateStr(`"user"."version" = 2`, "version"), true)
+		t.Assert(isFieldAssignedInUpdateStr("subversion=2", "version"), false)
+		t.Assert(isFieldAssignedInUpdateStr("version_name='v2'", "version"), false)
+		t.Assert(isFieldAssignedInUpdateStr("nickname='a,version=1'", "version"), false)
+		t.Assert(isFieldAssignedInUpdateStr("nickname=CONCAT('a', version), id=1", "version"), false)
+	})
+}
+
+func Test_Stats_Registry(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var group = "test_stats_registry"
+		t.AssertNil(SetConfigGroup(group, ConfigGroup{{Type: "default"}}))
+
+		db, err := Instance(group)
+		t.AssertNil(err)
+		t.Assert(statsCores.Contains(db.GetCore()), true)
+
+		size := statsCores.Size()
+		for i := 0; i < 10; i++ {
+			db.GetCore().Schema("test_schema")
+			_, err = NewByGroup(group)
+			t.AssertNil(err)
+		}
+		t.Assert(statsCores.Size(), size)
+
+		t.AssertNil(db.Close(context.Background()))
+		t.Assert(statsCores.Size(), size-1)
+	})
+}
+
+func Test_Schema_SharedObjects(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var group = "test_schema_shared_objects"
+		t.AssertNil(SetConfigGroup(group, ConfigGroup{{Type: "default"}}))
+
+		db, err := NewByGroup(group)
+		t.AssertNil(err)
+		var (
+			core       = db.GetCore()
+			schemaCore = core.Schema("test_schema").GetCore()
+		)
+		t.Assert(schemaCore.schema, "test_schema")
+		t.Assert(schemaCore.stickyCache == core.stickyCache, true)
+		t.Assert(schemaCore.slaveChecker == core.slaveChecker, true)
+	})
+}
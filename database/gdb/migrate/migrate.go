@@ -0,0 +1,132 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package migrate provides versioned schema migration features for package gdb.
+//
+// A migration is identified by its version, which is sorted in lexical order,
+// like "20240102150405" generated by CreateSQLFiles.
+// The applied migrations are recorded in a migrations table of the database.
+package migrate
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// MigrationFunc is the function for a migration, which should use `ctx` for all database operations,
+// so that the operations are done in the same transaction if the migration is transactional.
+type MigrationFunc func(ctx context.Context, db gdb.DB) error
+
+// Migration is a versioned schema change with its up and down operations.
+// It uses Up/Down functions if they are given, or else UpSQL/DownSQL statements.
+type Migration struct {
+	Version            string        // Unique version of the migration, which is sorted in lexical order.
+	Name               string        // Brief name of the migration.
+	Up                 MigrationFunc // Function applying the migration.
+	Down               MigrationFunc // Function reverting the migration.
+	UpSQL              string        // SQL statements applying the migration, separated by ';'.
+	DownSQL            string        // SQL statements reverting the migration, separated by ';'.
+	DisableTransaction bool          // DisableTransaction disables transaction for this migration.
+}
+
+// Migrator manages and applies migrations for a database.
+type Migrator struct {
+	mu         sync.Mutex            // Mutex for concurrent safety of migrations.
+	db         gdb.DB                // Database to migrate.
+	config     Config                // Migrator configuration.
+	migrations map[string]*Migration // Registered migrations, the key is the version.
+}
+
+// Config is the configuration for Migrator.
+type Config struct {
+	Table string // Table name for recording applied migrations, which is DefaultTable in default.
+}
+
+// MigrationStatus is the status of a migration.
+type MigrationStatus struct {
+	Version   string // Version of the migration.
+	Name      string // Name of the migration.
+	Applied   bool   // Whether the migration is applied.
+	AppliedAt string // Applied time of the migration, empty if not applied.
+	Missing   bool   // Whether the migration is applied but not registered in Migrator.
+}
+
+const (
+	// DefaultTable is the default table name for recording applied migrations.
+	DefaultTable = "gf_schema_migrations"
+)
+
+// New creates and returns a Migrator for `db`.
+func New(db gdb.DB, config ...Config) *Migrator {
+	m := &Migrator{
+		db:         db,
+		migrations: make(map[string]*Migration),
+	}
+	if len(config) > 0 {
+		m.config = config[0]
+	}
+	if m.config.Table == "" {
+		m.config.Table = DefaultTable
+	}
+	return m
+}
+
+// Add registers `migrations` to the Migrator.
+// It returns error if any migration is invalid or its version already exists.
+func (m *Migrator) Add(migrations ...*Migration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, migration := range migrations {
+		if migration == nil {
+			continue
+		}
+		if migration.Version == "" {
+			return gerror.NewCode(gcode.CodeInvalidParameter, `migration version cannot be empty`)
+		}
+		if migration.Up == nil && !hasStatements(migration.UpSQL) {
+			return gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`migration "%s" has neither up function nor up sql statement`,
+				migration.Version,
+			)
+		}
+		if _, ok := m.migrations[migration.Version]; ok {
+			return gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`duplicated migration version "%s"`,
+				migration.Version,
+			)
+		}
+		m.migrations[migration.Version] = migration
+	}
+	return nil
+}
+
+// Migrations returns all registered migrations ordered by version.
+func (m *Migrator) Migrations() []*Migration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	migrations := make([]*Migration, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations
+}
+
+// getMigration returns the registered migration by `version`.
+func (m *Migrator) getMigration(version string) *Migration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.migrations[version]
+}
@@ -0,0 +1,183 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package migrate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/text/gregex"
+)
+
+const (
+	sqlFileSuffixUp    = ".up.sql"
+	sqlFileSuffixDown  = ".down.sql"
+	sqlFileNamePattern = `^(\d+)_(.+)\.(up|down)\.sql$`
+)
+
+// LoadDir loads SQL migrations from directory `path` and registers them to the Migrator.
+//
+// The SQL migration files are named like "{version}_{name}.up.sql" and "{version}_{name}.down.sql",
+// eg: "20240102150405_create_user.up.sql", "20240102150405_create_user.down.sql".
+// The down file is optional, but the migration cannot be reverted without it.
+func (m *Migrator) LoadDir(path string) error {
+	migrations, err := LoadSQLMigrations(path)
+	if err != nil {
+		return err
+	}
+	return m.Add(migrations...)
+}
+
+// LoadSQLMigrations loads and returns SQL migrations ordered by version from directory `path`.
+// See Migrator.LoadDir.
+func LoadSQLMigrations(path string) ([]*Migration, error) {
+	if !gfile.IsDir(path) {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `migration directory "%s" does not exist`, path)
+	}
+	files, err := gfile.ScanDirFile(path, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	var migrationMap = make(map[string]*Migration)
+	for _, file := range files {
+		match, _ := gregex.MatchString(sqlFileNamePattern, gfile.Basename(file))
+		if len(match) == 0 {
+			continue
+		}
+		var (
+			version   = match[1]
+			name      = match[2]
+			direction = match[3]
+		)
+		migration, ok := migrationMap[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			migrationMap[version] = migration
+		} else if migration.Name != name {
+			return nil, gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`migration version "%s" has different names "%s" and "%s"`,
+				version, migration.Name, name,
+			)
+		}
+		if direction == "up" {
+			migration.UpSQL = gfile.GetContents(file)
+		} else {
+			migration.DownSQL = gfile.GetContents(file)
+		}
+	}
+	var migrations = make([]*Migration, 0, len(migrationMap))
+	for _, migration := range migrationMap {
+		if !hasStatements(migration.UpSQL) {
+			return nil, gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`up sql file for migration "%s" is missing or has no statement`,
+				migration.Version,
+			)
+		}
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// CreateSQLFiles creates up and down SQL migration files for `name` in directory `path`,
+// using current time as version. It returns the created file paths.
+// The created files contain only comments, and the migration cannot be loaded
+// until its up file is filled with SQL statements.
+func CreateSQLFiles(path, name string) (upFile, downFile string, err error) {
+	name, _ = gregex.ReplaceString(`[^\w]+`, "_", strings.TrimSpace(name))
+	if name = strings.Trim(strings.ToLower(name), "_"); name == "" {
+		return "", "", gerror.NewCode(gcode.CodeInvalidParameter, `migration name cannot be empty`)
+	}
+	var (
+		version  = gtime.Now().Format("YmdHis")
+		baseName = fmt.Sprintf(`%s_%s`, version, name)
+	)
+	upFile = gfile.Join(path, baseName+sqlFileSuffixUp)
+	downFile = gfile.Join(path, baseName+sqlFileSuffixDown)
+	if gfile.Exists(upFile) || gfile.Exists(downFile) {
+		return "", "", gerror.NewCodef(gcode.CodeInvalidOperation, `migration file "%s" already exists`, baseName)
+	}
+	if err = gfile.PutContents(upFile, fmt.Sprintf("-- Migration %s up.\n", baseName)); err != nil {
+		return "", "", err
+	}
+	if err = gfile.PutContents(downFile, fmt.Sprintf("-- Migration %s down.\n", baseName)); err != nil {
+		return "", "", err
+	}
+	return
+}
+
+// splitStatements splits `content` into SQL statements by ';',
+// ignoring the ';' in quoted strings and comments. The empty statements are ignored.
+func splitStatements(content string) []string {
+	var (
+		statements []string
+		start      int
+		quote      byte
+	)
+	flush := func(end int) {
+		if statement := strings.TrimSpace(content[start:end]); statement != "" && !isCommentOnly(statement) {
+			statements = append(statements, statement)
+		}
+		start = end + 1
+	}
+	// It is safe iterating bytes as the special characters are all ASCII characters.
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case strings.HasPrefix(content[i:], "--"):
+			if n := strings.IndexByte(content[i:], '\n'); n != -1 {
+				i += n
+			} else {
+				i = len(content)
+			}
+		case strings.HasPrefix(content[i:], "/*"):
+			if n := strings.Index(content[i+2:], "*/"); n != -1 {
+				i += n + 3
+			} else {
+				i = len(content)
+			}
+		case c == ';':
+			flush(i)
+		}
+	}
+	if start < len(content) {
+		flush(len(content))
+	}
+	return statements
+}
+
+// hasStatements checks and returns whether `content` contains any SQL statement.
+func hasStatements(content string) bool {
+	return len(splitStatements(content)) > 0
+}
+
+// isCommentOnly checks and returns whether `statement` contains only comments.
+func isCommentOnly(statement string) bool {
+	statement, _ = gregex.ReplaceString(`(?s)/\*.*?\*/`, "", statement)
+	for _, line := range strings.Split(statement, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "--") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,215 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package migrate
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Up applies all pending migrations in version order.
+// It returns the versions of the applied migrations, which are applied before error occurs if any.
+func (m *Migrator) Up(ctx context.Context) (versions []string, err error) {
+	appliedSet, err := m.appliedVersionSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, migration := range m.Migrations() {
+		if _, ok := appliedSet[migration.Version]; ok {
+			continue
+		}
+		if err = m.apply(ctx, migration, true); err != nil {
+			return
+		}
+		versions = append(versions, migration.Version)
+	}
+	return
+}
+
+// Down reverts the latest applied migrations in reversed version order.
+// The optional parameter `steps` specifies the count of migrations to revert, which is 1 in default.
+// It returns the versions of the reverted migrations, which are reverted before error occurs if any.
+func (m *Migrator) Down(ctx context.Context, steps ...int) (versions []string, err error) {
+	var count = 1
+	if len(steps) > 0 && steps[0] > 0 {
+		count = steps[0]
+	}
+	records, err := m.appliedRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(records) - 1; i >= 0 && len(versions) < count; i-- {
+		if err = m.revert(ctx, records[i].Version); err != nil {
+			return
+		}
+		versions = append(versions, records[i].Version)
+	}
+	return
+}
+
+// To migrates the database to given `version`, which must be a registered migration version.
+// It reverts the applied migrations greater than `version` in reversed version order,
+// and then applies the pending migrations not greater than `version` in version order.
+// It returns the versions of the reverted and applied migrations.
+func (m *Migrator) To(ctx context.Context, version string) (versions []string, err error) {
+	if m.getMigration(version) == nil {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `migration version "%s" not found`, version)
+	}
+	records, err := m.appliedRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var appliedSet = make(map[string]struct{}, len(records))
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].Version <= version {
+			appliedSet[records[i].Version] = struct{}{}
+			continue
+		}
+		if err = m.revert(ctx, records[i].Version); err != nil {
+			return
+		}
+		versions = append(versions, records[i].Version)
+	}
+	for _, migration := range m.Migrations() {
+		if migration.Version > version {
+			break
+		}
+		if _, ok := appliedSet[migration.Version]; ok {
+			continue
+		}
+		if err = m.apply(ctx, migration, true); err != nil {
+			return
+		}
+		versions = append(versions, migration.Version)
+	}
+	return
+}
+
+// Status returns the status of all registered and applied migrations ordered by version.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	records, err := m.appliedRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		migrations = m.Migrations()
+		statuses   = make([]MigrationStatus, 0, len(migrations))
+	)
+	// Merge registered migrations and applied records, both of which are in version order.
+	var i, j int
+	for i < len(migrations) || j < len(records) {
+		switch {
+		case j >= len(records) || (i < len(migrations) && migrations[i].Version < records[j].Version):
+			statuses = append(statuses, MigrationStatus{
+				Version: migrations[i].Version,
+				Name:    migrations[i].Name,
+			})
+			i++
+
+		case i >= len(migrations) || records[j].Version < migrations[i].Version:
+			statuses = append(statuses, MigrationStatus{
+				Version:   records[j].Version,
+				Name:      records[j].Name,
+				Applied:   true,
+				AppliedAt: records[j].AppliedAt,
+				Missing:   true,
+			})
+			j++
+
+		default:
+			statuses = append(statuses, MigrationStatus{
+				Version:   migrations[i].Version,
+				Name:      migrations[i].Name,
+				Applied:   true,
+				AppliedAt: records[j].AppliedAt,
+			})
+			i++
+			j++
+		}
+	}
+	return statuses, nil
+}
+
+// appliedVersionSet returns the set of applied migration versions.
+func (m *Migrator) appliedVersionSet(ctx context.Context) (map[string]struct{}, error) {
+	records, err := m.appliedRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var set = make(map[string]struct{}, len(records))
+	for _, record := range records {
+		set[record.Version] = struct{}{}
+	}
+	return set, nil
+}
+
+// revert reverts the applied migration of `version`.
+func (m *Migrator) revert(ctx context.Context, version string) error {
+	migration := m.getMigration(version)
+	if migration == nil {
+		return gerror.NewCodef(
+			gcode.CodeInvalidOperation,
+			`applied migration "%s" is not registered, it cannot be reverted`,
+			version,
+		)
+	}
+	if migration.Down == nil && !hasStatements(migration.DownSQL) {
+		return gerror.NewCodef(
+			gcode.CodeNotSupported,
+			`migration "%s" has neither down function nor down sql statement, it cannot be reverted`,
+			version,
+		)
+	}
+	return m.apply(ctx, migration, false)
+}
+
+// apply applies or reverts `migration` according to `up`, and updates the applied record.
+// It executes in transaction if the migration is transactional.
+func (m *Migrator) apply(ctx context.Context, migration *Migration, up bool) (err error) {
+	var (
+		fn         = migration.Up
+		sqlContent = migration.UpSQL
+	)
+	if !up {
+		fn = migration.Down
+		sqlContent = migration.DownSQL
+	}
+	doApply := func(ctx context.Context) error {
+		if fn != nil {
+			if err := fn(ctx, m.db); err != nil {
+				return err
+			}
+		} else {
+			for _, statement := range splitStatements(sqlContent) {
+				if _, err := m.db.Exec(ctx, statement); err != nil {
+					return err
+				}
+			}
+		}
+		if up {
+			return m.insertRecord(ctx, migration)
+		}
+		return m.deleteRecord(ctx, migration.Version)
+	}
+	if m.isTransactional(migration) {
+		err = m.db.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+			return doApply(ctx)
+		})
+	} else {
+		err = doApply(ctx)
+	}
+	if err != nil {
+		if up {
+			return gerror.Wrapf(err, `apply migration "%s" failed`, migration.Version)
+		}
+		return gerror.Wrapf(err, `revert migration "%s" failed`, migration.Version)
+	}
+	return nil
+}
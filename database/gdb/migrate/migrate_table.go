@@ -0,0 +1,127 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// appliedRecord is the record in migrations table.
+type appliedRecord struct {
+	Version   string `orm:"version"`
+	Name      string `orm:"name"`
+	AppliedAt string `orm:"applied_at"`
+}
+
+const (
+	fieldVersion   = "version"
+	fieldName      = "name"
+	fieldAppliedAt = "applied_at"
+)
+
+// nonTransactionalDDLDrivers contains the driver types that implicitly commit transaction
+// when executing DDL statements, so the migrations are not executed in transaction for them.
+var nonTransactionalDDLDrivers = map[string]struct{}{
+	"mysql":      {},
+	"mariadb":    {},
+	"tidb":       {},
+	"oracle":     {},
+	"dm":         {},
+	"clickhouse": {},
+}
+
+const (
+	createTableSqlFormatDefault = "CREATE TABLE %s (%s VARCHAR(64) NOT NULL PRIMARY KEY, %s VARCHAR(255) NOT NULL, %s TIMESTAMP NOT NULL)"
+	createTableSqlFormatMysql   = "CREATE TABLE %s (%s VARCHAR(64) NOT NULL PRIMARY KEY, %s VARCHAR(255) NOT NULL, %s DATETIME NOT NULL)"
+	createTableSqlFormatMssql   = "CREATE TABLE %s (%s NVARCHAR(64) NOT NULL PRIMARY KEY, %s NVARCHAR(255) NOT NULL, %s DATETIME2 NOT NULL)"
+	createTableSqlFormatOracle  = "CREATE TABLE %s (%s VARCHAR2(64) NOT NULL PRIMARY KEY, %s VARCHAR2(255) NOT NULL, %s TIMESTAMP NOT NULL)"
+	createTableSqlFormatClick   = "CREATE TABLE %s (%s String, %s String, %s DateTime) ENGINE = MergeTree() ORDER BY %[2]s"
+)
+
+// createTableSqlFormats contains the sql for creating migrations table of driver types,
+// which uses createTableSqlFormatDefault for driver types not in it.
+var createTableSqlFormats = map[string]string{
+	"mysql":      createTableSqlFormatMysql,
+	"mariadb":    createTableSqlFormatMysql,
+	"tidb":       createTableSqlFormatMysql,
+	"mssql":      createTableSqlFormatMssql,
+	"oracle":     createTableSqlFormatOracle,
+	"dm":         createTableSqlFormatOracle,
+	"clickhouse": createTableSqlFormatClick,
+}
+
+// driverType returns the driver type of the database.
+func (m *Migrator) driverType() string {
+	return m.db.GetConfig().Type
+}
+
+// isTransactional checks and returns whether `migration` should be executed in transaction.
+func (m *Migrator) isTransactional(migration *Migration) bool {
+	if migration.DisableTransaction {
+		return false
+	}
+	_, ok := nonTransactionalDDLDrivers[m.driverType()]
+	return !ok
+}
+
+// ensureTable creates the migrations table if it does not exist.
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	var (
+		core      = m.db.GetCore()
+		tableName = m.db.GetPrefix() + m.config.Table
+	)
+	tables, err := m.db.Tables(ctx)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if table == tableName {
+			return nil
+		}
+	}
+	sqlFormat, ok := createTableSqlFormats[m.driverType()]
+	if !ok {
+		sqlFormat = createTableSqlFormatDefault
+	}
+	_, err = m.db.Exec(ctx, fmt.Sprintf(
+		sqlFormat,
+		core.QuotePrefixTableName(m.config.Table),
+		core.QuoteWord(fieldVersion),
+		core.QuoteWord(fieldName),
+		core.QuoteWord(fieldAppliedAt),
+	))
+	return err
+}
+
+// appliedRecords returns all applied migration records ordered by version.
+func (m *Migrator) appliedRecords(ctx context.Context) (records []appliedRecord, err error) {
+	if err = m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	err = m.db.Model(m.config.Table).Ctx(ctx).OrderAsc(fieldVersion).Scan(&records)
+	return
+}
+
+// insertRecord records `migration` as applied.
+func (m *Migrator) insertRecord(ctx context.Context, migration *Migration) error {
+	_, err := m.db.Model(m.config.Table).Ctx(ctx).Data(gdb.Map{
+		fieldVersion:   migration.Version,
+		fieldName:      migration.Name,
+		fieldAppliedAt: gtime.Now(),
+	}).Insert()
+	return err
+}
+
+// deleteRecord removes the applied record of `version`.
+func (m *Migrator) deleteRecord(ctx context.Context, version string) error {
+	_, err := m.db.Model(m.config.Table).Ctx(ctx).Where(fieldVersion, version).Delete()
+	return err
+}
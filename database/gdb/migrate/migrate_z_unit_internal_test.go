@@ -0,0 +1,86 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package migrate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_splitStatements(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		content := `
+-- create table; with comment
+CREATE TABLE user (id INT, name VARCHAR(45) DEFAULT 'a;b');
+/* block; comment */
+INSERT INTO user VALUES(1, "x;y");
+-- trailing comment only
+`
+		statements := splitStatements(content)
+		t.Assert(len(statements), 2)
+		t.Assert(statements[0], "-- create table; with comment\nCREATE TABLE user (id INT, name VARCHAR(45) DEFAULT 'a;b')")
+		t.Assert(statements[1], "/* block; comment */\nINSERT INTO user VALUES(1, \"x;y\")")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(len(splitStatements("")), 0)
+		t.Assert(len(splitStatements("-- only comment")), 0)
+		t.Assert(splitStatements("SELECT 1"), []string{"SELECT 1"})
+	})
+}
+
+func Test_CreateSQLFiles_LoadSQLMigrations(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.Temp(gtime.TimestampNanoStr())
+		defer gfile.Remove(path)
+
+		upFile, downFile, err := CreateSQLFiles(path, "Create User Table")
+		t.AssertNil(err)
+		t.Assert(gfile.Exists(upFile), true)
+		t.Assert(gfile.Exists(downFile), true)
+		t.Assert(strings.TrimSuffix(upFile, ".up.sql"), strings.TrimSuffix(downFile, ".down.sql"))
+
+		// The created up sql only contains comment, which should be edited before loading.
+		_, err = LoadSQLMigrations(path)
+		t.AssertNE(err, nil)
+
+		t.AssertNil(gfile.PutContents(upFile, gfile.GetContents(upFile)+"SELECT 1;\n"))
+		migrations, err := LoadSQLMigrations(path)
+		t.AssertNil(err)
+		t.Assert(len(migrations), 1)
+		t.Assert(migrations[0].Name, "create_user_table")
+		t.Assert(len(migrations[0].Version), 14)
+
+		_, _, err = CreateSQLFiles(path, "!!!")
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.Temp(gtime.TimestampNanoStr())
+		defer gfile.Remove(path)
+
+		t.AssertNil(gfile.PutContents(gfile.Join(path, "2_b.up.sql"), "SELECT 2"))
+		t.AssertNil(gfile.PutContents(gfile.Join(path, "1_a.up.sql"), "SELECT 1"))
+		t.AssertNil(gfile.PutContents(gfile.Join(path, "1_a.down.sql"), "SELECT -1"))
+		t.AssertNil(gfile.PutContents(gfile.Join(path, "readme.md"), "ignored"))
+
+		migrations, err := LoadSQLMigrations(path)
+		t.AssertNil(err)
+		t.Assert(len(migrations), 2)
+		t.Assert(migrations[0].Version, "1")
+		t.Assert(migrations[0].UpSQL, "SELECT 1")
+		t.Assert(migrations[0].DownSQL, "SELECT -1")
+		t.Assert(migrations[1].Version, "2")
+		t.Assert(migrations[1].DownSQL, "")
+
+		t.AssertNil(gfile.PutContents(gfile.Join(path, "3_c.down.sql"), "SELECT -3"))
+		_, err = LoadSQLMigrations(path)
+		t.AssertNE(err, nil)
+	})
+}
@@ -47,6 +47,8 @@ var (
 	CodeInvalidRequest            = localCode{66, "Invalid Request", nil}              // Invalid request.
 	CodeNecessaryPackageNotImport = localCode{67, "Necessary Package Not Import", nil} // It needs necessary package import.
 	CodeInternalPanic             = localCode{68, "Internal Panic", nil}               // A panic occurred internally.
+	CodeTooManyRequests           = localCode{69, "Too Many Requests", nil}            // Too many requests, the request rate exceeds the limit.
+	CodeOptimisticLockConflict    = localCode{70, "Optimistic Lock Conflict", nil}     // The record is modified by others as its version does not match.
 	CodeBusinessValidationFailed  = localCode{300, "Business Validation Failed", nil}  // Business validation failed.
 )
 
@@ -146,6 +146,7 @@ func Database(name ...string) gdb.DB {
 					}
 				}
 			}
+			gdb.RegisterStats(db)
 			return db
 		} else {
 			// If panics, often because it does not find its configuration for given group.
@@ -45,6 +45,49 @@ func Translate(ctx context.Context, content string) string {
 	return Instance().Translate(ctx, content)
 }
 
+// SetFallbacks sets the fallback language chain for `language`.
+func SetFallbacks(language string, fallbacks ...string) {
+	Instance().SetFallbacks(language, fallbacks...)
+}
+
+// SetMissingKeyHandler sets the handler called when translation key is missing.
+func SetMissingKeyHandler(handler MissingKeyHandler) {
+	Instance().SetMissingKeyHandler(handler)
+}
+
+// Languages returns the names of languages loaded in default manager in ascending order.
+func Languages(ctx context.Context) []string {
+	return Instance().Languages(ctx)
+}
+
+// MatchLanguage returns the best matched language loaded in default manager for `preferred` languages.
+// It returns an empty string if no language matched.
+func MatchLanguage(ctx context.Context, preferred ...string) string {
+	return Instance().MatchLanguage(ctx, preferred...)
+}
+
+// Tn is alias of TranslatePlural for convenience.
+func Tn(ctx context.Context, key string, count any, args map[string]any) string {
+	return Instance().TranslatePlural(ctx, key, count, args)
+}
+
+// TranslatePlural translates the content of `key` in plural form for `count` with configured language,
+// and formats it with named arguments `args`.
+func TranslatePlural(ctx context.Context, key string, count any, args map[string]any) string {
+	return Instance().TranslatePlural(ctx, key, count, args)
+}
+
+// Ta is alias of TranslateArgs for convenience.
+func Ta(ctx context.Context, key string, args map[string]any) string {
+	return Instance().TranslateArgs(ctx, key, args)
+}
+
+// TranslateArgs translates the content of `key` with configured language,
+// and formats it with named arguments `args`.
+func TranslateArgs(ctx context.Context, key string, args map[string]any) string {
+	return Instance().TranslateArgs(ctx, key, args)
+}
+
 // GetContent retrieves and returns the configured content for given key and specified language.
 // It returns an empty string if not found.
 func GetContent(ctx context.Context, key string) string {
@@ -0,0 +1,275 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// messageFormatter formats message in ICU message style, which supports:
+//
+//	{name}                                       named argument.
+//	{count, plural, =0 {none} one {# item} other {# items}} plural argument, `#` is the number.
+//	{count, plural, offset:1 one {...} other {...}} plural argument with offset.
+//	{gender, select, male {he} female {she} other {they}} select argument.
+//	'{literal}'                                  quoted literal text, and '' is a single quote.
+type messageFormatter struct {
+	language string         // Language for plural rules.
+	args     map[string]any // Named arguments.
+	runes    []rune         // Message content.
+	pos      int            // Current parsing position.
+}
+
+// formatMessage formats `message` in ICU message style with named arguments `args`.
+// The optional parameter `number` replaces the `#` in top level of message,
+// which is used for plural forms.
+func formatMessage(language, message string, args map[string]any, number ...string) string {
+	var replacement string
+	if len(number) > 0 {
+		replacement = number[0]
+	}
+	if !strings.ContainsAny(message, "{'") && (replacement == "" || !strings.Contains(message, "#")) {
+		return message
+	}
+	f := &messageFormatter{
+		language: language,
+		args:     args,
+		runes:    []rune(message),
+	}
+	return f.parseText(false, replacement)
+}
+
+// parseText parses the text until the end of message or the end of current branch if `nested`.
+// The `number` is the string replacing `#` in plural branch.
+func (f *messageFormatter) parseText(nested bool, number string) string {
+	var buffer strings.Builder
+	for f.pos < len(f.runes) {
+		c := f.runes[f.pos]
+		switch {
+		case c == '\'':
+			f.parseQuote(&buffer)
+		case c == '{':
+			buffer.WriteString(f.parseArgument())
+		case c == '}' && nested:
+			return buffer.String()
+		case c == '#' && number != "":
+			buffer.WriteString(number)
+			f.pos++
+		default:
+			buffer.WriteRune(c)
+			f.pos++
+		}
+	}
+	return buffer.String()
+}
+
+// parseQuote parses the quoted literal text starting with single quote.
+func (f *messageFormatter) parseQuote(buffer *strings.Builder) {
+	f.pos++
+	if f.pos >= len(f.runes) {
+		buffer.WriteRune('\'')
+		return
+	}
+	switch f.runes[f.pos] {
+	case '\'':
+		buffer.WriteRune('\'')
+		f.pos++
+		return
+	case '{', '}', '#', '|':
+	default:
+		buffer.WriteRune('\'')
+		return
+	}
+	for f.pos < len(f.runes) {
+		c := f.runes[f.pos]
+		f.pos++
+		if c != '\'' {
+			buffer.WriteRune(c)
+			continue
+		}
+		if f.pos < len(f.runes) && f.runes[f.pos] == '\'' {
+			buffer.WriteRune('\'')
+			f.pos++
+			continue
+		}
+		return
+	}
+}
+
+// parseArgument parses the argument starting with '{' and returns its formatted content.
+// It returns the original content if the argument is invalid or not found in arguments.
+func (f *messageFormatter) parseArgument() string {
+	var start = f.pos
+	f.pos++
+	name, end := f.readUntil(",}")
+	if end == 0 {
+		// It is not an argument, the '{' is kept as literal.
+		f.pos = start + 1
+		return "{"
+	}
+	value, ok := f.args[name]
+	if end == '}' {
+		if !ok {
+			return string(f.runes[start:f.pos])
+		}
+		return gconv.String(value)
+	}
+	argType, end := f.readUntil(",}")
+	if end == 0 {
+		f.pos = start + 1
+		return "{"
+	}
+	switch argType {
+	case "plural", "selectordinal", "select":
+		if end == '}' {
+			return string(f.runes[start:f.pos])
+		}
+		return f.parseOptions(argType, value)
+	default:
+		// Other types like number, date are formatted as string, and the style is ignored.
+		if end == ',' {
+			f.skipBlock()
+		}
+		if !ok {
+			return string(f.runes[start:f.pos])
+		}
+		return gconv.String(value)
+	}
+}
+
+// parseOptions parses the options of plural or select argument, and returns the selected one.
+func (f *messageFormatter) parseOptions(argType string, value any) string {
+	var (
+		offset   float64
+		options  = make(map[string]string)
+		selector string
+	)
+	for f.pos < len(f.runes) {
+		f.skipSpaces()
+		if f.pos >= len(f.runes) {
+			break
+		}
+		if f.runes[f.pos] == '}' {
+			f.pos++
+			break
+		}
+		selector = f.readSelector()
+		if strings.HasPrefix(selector, "offset:") {
+			offset = gconv.Float64(strings.TrimPrefix(selector, "offset:"))
+			continue
+		}
+		f.skipSpaces()
+		if f.pos >= len(f.runes) || f.runes[f.pos] != '{' {
+			// Invalid option, it ignores the rest.
+			f.skipBlock()
+			break
+		}
+		f.pos++
+		var number string
+		if argType != "select" {
+			number = f.formatNumber(value, offset)
+		}
+		options[selector] = f.parseText(true, number)
+		if f.pos < len(f.runes) {
+			f.pos++
+		}
+	}
+	if argType == "select" {
+		if v, ok := options[gconv.String(value)]; ok {
+			return v
+		}
+		return options[PluralOther]
+	}
+	// Exact value matching has higher priority.
+	if v, ok := options["="+gconv.String(value)]; ok {
+		return v
+	}
+	for k, v := range options {
+		if strings.HasPrefix(k, "=") && gconv.Float64(k[1:]) == gconv.Float64(value) {
+			return v
+		}
+	}
+	category := PluralCategory(f.language, f.formatNumber(value, offset))
+	if v, ok := options[category]; ok {
+		return v
+	}
+	return options[PluralOther]
+}
+
+// formatNumber formats `value` subtracted by `offset` as string.
+func (f *messageFormatter) formatNumber(value any, offset float64) string {
+	if offset == 0 {
+		switch v := value.(type) {
+		case float32:
+			return strconv.FormatFloat(float64(v), 'f', -1, 32)
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return gconv.String(value)
+		}
+	}
+	return strconv.FormatFloat(gconv.Float64(value)-offset, 'f', -1, 64)
+}
+
+// readUntil reads and returns the trimmed content until any char of `chars`,
+// and the returned `end` is the matched char. The `end` is 0 if no char matched.
+func (f *messageFormatter) readUntil(chars string) (content string, end rune) {
+	var start = f.pos
+	for f.pos < len(f.runes) {
+		c := f.runes[f.pos]
+		if strings.ContainsRune(chars, c) {
+			f.pos++
+			return strings.TrimSpace(string(f.runes[start : f.pos-1])), c
+		}
+		if c == '{' {
+			break
+		}
+		f.pos++
+	}
+	return "", 0
+}
+
+// readSelector reads and returns the selector of option.
+func (f *messageFormatter) readSelector() string {
+	var start = f.pos
+	for f.pos < len(f.runes) {
+		c := f.runes[f.pos]
+		if c == '{' || c == '}' || unicode.IsSpace(c) {
+			break
+		}
+		f.pos++
+	}
+	return string(f.runes[start:f.pos])
+}
+
+// skipSpaces skips the white spaces.
+func (f *messageFormatter) skipSpaces() {
+	for f.pos < len(f.runes) && unicode.IsSpace(f.runes[f.pos]) {
+		f.pos++
+	}
+}
+
+// skipBlock skips the content until the '}' closing current argument.
+func (f *messageFormatter) skipBlock() {
+	var depth = 1
+	for f.pos < len(f.runes) {
+		switch f.runes[f.pos] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		}
+		f.pos++
+		if depth == 0 {
+			return
+		}
+	}
+}
@@ -34,11 +34,13 @@ const (
 
 // Manager for i18n contents, it is concurrent safe, supporting hot reload.
 type Manager struct {
-	mu       sync.RWMutex
-	data     map[string]map[string]string // Translating map.
-	pattern  string                       // Pattern for regex parsing.
-	pathType pathType                     // Path type for i18n files.
-	options  Options                      // configuration options.
+	mu             sync.RWMutex
+	data           map[string]map[string]string            // Translating map.
+	plurals        map[string]map[string]map[string]string // Plural forms map: language -> key -> category -> content.
+	pattern        string                                  // Pattern for regex parsing.
+	pathType       pathType                                // Path type for i18n files.
+	options        Options                                 // configuration options.
+	missingHandler MissingKeyHandler                       // Handler for missing translation keys.
 }
 
 // Options is used for i18n object configuration.
@@ -47,6 +49,12 @@ type Options struct {
 	Language   string         // Default local language.
 	Delimiters []string       // Delimiters for variable parsing.
 	Resource   *gres.Resource // Resource for i18n files.
+
+	// Fallbacks specifies the fallback language chains, which are searched in order
+	// if the content is not found in the language, eg: {"zh-TW": {"zh-CN", "en"}}.
+	// The chains are resolved recursively, and the chain of key "*" is used for all languages
+	// as the last fallback.
+	Fallbacks map[string][]string
 }
 
 var (
@@ -154,6 +162,25 @@ func (m *Manager) SetDelimiters(left, right string) {
 	intlog.Printf(context.TODO(), `SetDelimiters: %v`, m.pattern)
 }
 
+// SetFallbacks sets the fallback language chain for `language`.
+// The `language` can be "*", which is used for all languages as the last fallback.
+func (m *Manager) SetFallbacks(language string, fallbacks ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.options.Fallbacks == nil {
+		m.options.Fallbacks = make(map[string][]string)
+	} else {
+		// Copy on write, as the map might be shared with the options of caller.
+		fallbackMap := make(map[string][]string, len(m.options.Fallbacks)+1)
+		for k, v := range m.options.Fallbacks {
+			fallbackMap[k] = v
+		}
+		m.options.Fallbacks = fallbackMap
+	}
+	m.options.Fallbacks[language] = fallbacks
+	intlog.Printf(context.TODO(), `SetFallbacks: %s, %v`, language, fallbacks)
+}
+
 // T is alias of Translate for convenience.
 func (m *Manager) T(ctx context.Context, content string) string {
 	return m.Translate(ctx, content)
@@ -172,36 +199,158 @@ func (m *Manager) TranslateFormat(ctx context.Context, format string, values ...
 
 // Translate translates `content` with configured language.
 func (m *Manager) Translate(ctx context.Context, content string) string {
+	result, transLang, missing := m.doTranslate(ctx, content, true)
+	m.reportMissingKeys(ctx, transLang, missing)
+	return result
+}
+
+// TranslateContent translates `content` like Translate, which is usually a large content like the
+// rendered template. Different from Translate, only the missing keys in variable delimiters like
+// "{#name}" are reported, the whole `content` is never reported as a missing key.
+func (m *Manager) TranslateContent(ctx context.Context, content string) string {
+	result, transLang, missing := m.doTranslate(ctx, content, false)
+	m.reportMissingKeys(ctx, transLang, missing)
+	return result
+}
+
+// doTranslate translates `content` and returns the result, the translating language
+// and the keys missing in the translating language.
+// The parameter `asKey` specifies whether `content` is reported as missing key
+// if it is not translated as key or variables container.
+func (m *Manager) doTranslate(
+	ctx context.Context, content string, asKey bool,
+) (result, transLang string, missing []string) {
 	m.init(ctx)
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	transLang := m.options.Language
+	transLang = m.options.Language
 	if lang := LanguageFromCtx(ctx); lang != "" {
 		transLang = lang
 	}
-	data := m.data[transLang]
-	if data == nil {
-		return content
-	}
+	languages := m.getLanguageChain(transLang)
 	// Parse content as name.
-	if v, ok := data[content]; ok {
-		return v
+	if v, lang, ok := m.getContentWithFallback(languages, content); ok {
+		if lang != transLang && asKey {
+			missing = append(missing, content)
+		}
+		return v, transLang, missing
 	}
 	// Parse content as variables container.
-	result, _ := gregex.ReplaceStringFuncMatch(
+	var matched bool
+	result, _ = gregex.ReplaceStringFuncMatch(
 		m.pattern, content,
 		func(match []string) string {
-			if v, ok := data[match[1]]; ok {
+			matched = true
+			if v, lang, ok := m.getContentWithFallback(languages, match[1]); ok {
+				if lang != transLang {
+					missing = append(missing, match[1])
+				}
 				return v
 			}
+			missing = append(missing, match[1])
 			// return match[1] will return the content between delimiters
 			// return match[0] will return the original content
 			return match[0]
 		})
+	if !matched && asKey {
+		missing = append(missing, content)
+	}
 	intlog.Printf(ctx, `Translate for language: %s`, transLang)
+	return result, transLang, missing
+}
+
+// Tn is alias of TranslatePlural for convenience.
+func (m *Manager) Tn(ctx context.Context, key string, count any, args map[string]any) string {
+	return m.TranslatePlural(ctx, key, count, args)
+}
+
+// TranslatePlural translates the content of `key` in plural form for `count` with configured language,
+// and formats it with named arguments `args` in ICU message style. The `count` is also passed as
+// argument "count", and the `#` in plural content is replaced with `count`.
+//
+// The plural forms can be configured as a map of CLDR plural categories in i18n files, eg:
+//
+//	[apple]
+//	"=0" = "no apples"
+//	one = "{name} has # apple"
+//	other = "{name} has # apples"
+//
+// or as an ICU plural message, eg:
+//
+//	apple = "{count, plural, =0 {no apples} one {# apple} other {# apples}}"
+//
+// It returns `key` formatted with `args` if no content found.
+func (m *Manager) TranslatePlural(ctx context.Context, key string, count any, args map[string]any) string {
+	result, transLang, found := m.doTranslatePlural(ctx, key, count, args)
+	if !found {
+		m.reportMissingKeys(ctx, transLang, []string{key})
+	}
 	return result
 }
 
+// doTranslatePlural translates the content of `key` in plural form, and returns the result,
+// the translating language and whether the key is found in the translating language.
+func (m *Manager) doTranslatePlural(
+	ctx context.Context, key string, count any, args map[string]any,
+) (result, transLang string, found bool) {
+	m.init(ctx)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var arguments = make(map[string]any, len(args)+1)
+	transLang = m.options.Language
+	if lang := LanguageFromCtx(ctx); lang != "" {
+		transLang = lang
+	}
+	for k, v := range args {
+		arguments[k] = v
+	}
+	arguments["count"] = count
+	for _, lang := range m.getLanguageChain(transLang) {
+		if forms, ok := m.plurals[lang][key]; ok {
+			content, ok := forms["="+gconv.String(count)]
+			if !ok {
+				if content, ok = forms[PluralCategory(lang, count)]; !ok {
+					content = forms[PluralOther]
+				}
+			}
+			return formatMessage(lang, content, arguments, gconv.String(count)), transLang, lang == transLang
+		}
+		if content, ok := m.data[lang][key]; ok {
+			return formatMessage(lang, content, arguments), transLang, lang == transLang
+		}
+	}
+	return formatMessage(transLang, key, arguments), transLang, false
+}
+
+// Ta is alias of TranslateArgs for convenience.
+func (m *Manager) Ta(ctx context.Context, key string, args map[string]any) string {
+	return m.TranslateArgs(ctx, key, args)
+}
+
+// TranslateArgs translates the content of `key` with configured language, and formats it with
+// named arguments `args` in ICU message style, eg:
+//
+//	welcome = "Hello {name}, you have {count, plural, one {# message} other {# messages}}"
+//
+// It returns `key` formatted with `args` if no content found.
+func (m *Manager) TranslateArgs(ctx context.Context, key string, args map[string]any) string {
+	m.init(ctx)
+	m.mu.RLock()
+	transLang := m.options.Language
+	if lang := LanguageFromCtx(ctx); lang != "" {
+		transLang = lang
+	}
+	content, lang, ok := m.getContentWithFallback(m.getLanguageChain(transLang), key)
+	m.mu.RUnlock()
+	if !ok || lang != transLang {
+		m.reportMissingKeys(ctx, transLang, []string{key})
+	}
+	if ok {
+		return formatMessage(lang, content, args)
+	}
+	return formatMessage(transLang, key, args)
+}
+
 // GetContent retrieves and returns the configured content for given key and specified language.
 // It returns an empty string if not found.
 func (m *Manager) GetContent(ctx context.Context, key string) string {
@@ -212,10 +361,8 @@ func (m *Manager) GetContent(ctx context.Context, key string) string {
 	if lang := LanguageFromCtx(ctx); lang != "" {
 		transLang = lang
 	}
-	if data, ok := m.data[transLang]; ok {
-		return data[key]
-	}
-	return ""
+	v, _, _ := m.getContentWithFallback(m.getLanguageChain(transLang), key)
+	return v
 }
 
 // reset reset data of the manager.
@@ -223,6 +370,7 @@ func (m *Manager) reset() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.data = nil
+	m.plurals = nil
 }
 
 // init initializes the manager for lazy initialization design.
@@ -255,6 +403,7 @@ func (m *Manager) init(ctx context.Context) {
 				array []string
 			)
 			m.data = make(map[string]map[string]string)
+			m.plurals = make(map[string]map[string]map[string]string)
 			for _, file := range files {
 				name = file.Name()
 				path = name[len(m.options.Path)+1:]
@@ -268,9 +417,7 @@ func (m *Manager) init(ctx context.Context) {
 					m.data[lang] = make(map[string]string)
 				}
 				if j, err := gjson.LoadContent(file.Content()); err == nil {
-					for k, v := range j.Var().Map() {
-						m.data[lang][k] = gconv.String(v)
-					}
+					m.loadContent(lang, j.Var().Map())
 				} else {
 					intlog.Errorf(ctx, "load i18n file '%s' failed: %+v", name, err)
 				}
@@ -287,6 +434,7 @@ func (m *Manager) init(ctx context.Context) {
 			array []string
 		)
 		m.data = make(map[string]map[string]string)
+		m.plurals = make(map[string]map[string]map[string]string)
 		for _, file := range files {
 			path = file[len(m.options.Path)+1:]
 			array = strings.Split(path, gfile.Separator)
@@ -299,9 +447,7 @@ func (m *Manager) init(ctx context.Context) {
 				m.data[lang] = make(map[string]string)
 			}
 			if j, err := gjson.LoadContent(gfile.GetBytes(file)); err == nil {
-				for k, v := range j.Var().Map() {
-					m.data[lang][k] = gconv.String(v)
-				}
+				m.loadContent(lang, j.Var().Map())
 			} else {
 				intlog.Errorf(ctx, "load i18n file '%s' failed: %+v", file, err)
 			}
@@ -316,3 +462,79 @@ func (m *Manager) init(ctx context.Context) {
 		})
 	}
 }
+
+// loadContent loads the key-value pairs of `lang` into manager.
+// The value of map type containing plural categories, eg: {"one": "# item", "other": "# items"},
+// is loaded as plural forms, and its "other" form is also used as the normal content.
+func (m *Manager) loadContent(lang string, content map[string]any) {
+	for k, v := range content {
+		if forms := toPluralForms(v); forms != nil {
+			if m.plurals[lang] == nil {
+				m.plurals[lang] = make(map[string]map[string]string)
+			}
+			m.plurals[lang][k] = forms
+			if other, ok := forms[PluralOther]; ok {
+				m.data[lang][k] = other
+				continue
+			}
+		}
+		m.data[lang][k] = gconv.String(v)
+	}
+}
+
+// getLanguageChain returns the language searching chain for `language` using fallback configuration.
+// Note that it should be called with read lock.
+func (m *Manager) getLanguageChain(language string) []string {
+	var (
+		chain   = []string{language}
+		visited = map[string]struct{}{language: {}}
+	)
+	for i := 0; i < len(chain); i++ {
+		for _, fallback := range m.options.Fallbacks[chain[i]] {
+			if _, ok := visited[fallback]; !ok {
+				visited[fallback] = struct{}{}
+				chain = append(chain, fallback)
+			}
+		}
+	}
+	for _, fallback := range m.options.Fallbacks["*"] {
+		if _, ok := visited[fallback]; !ok {
+			visited[fallback] = struct{}{}
+			chain = append(chain, fallback)
+		}
+	}
+	return chain
+}
+
+// getContentWithFallback searches and returns the content of `key` in `languages` in order.
+// It also returns the language where the content is found.
+// Note that it should be called with read lock.
+func (m *Manager) getContentWithFallback(languages []string, key string) (content, language string, found bool) {
+	for _, lang := range languages {
+		if v, ok := m.data[lang][key]; ok {
+			return v, lang, true
+		}
+	}
+	return "", "", false
+}
+
+// toPluralForms converts and returns `value` as plural forms if it is a map of plural categories,
+// or else it returns nil.
+func toPluralForms(value any) map[string]string {
+	m, ok := value.(map[string]any)
+	if !ok || len(m) == 0 {
+		return nil
+	}
+	var forms = make(map[string]string, len(m))
+	for k, v := range m {
+		switch k {
+		case PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther:
+		default:
+			if !strings.HasPrefix(k, "=") {
+				return nil
+			}
+		}
+		forms[k] = gconv.String(v)
+	}
+	return forms
+}
@@ -0,0 +1,111 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MissingKeyHandler is the handler called when translation key `key` is missing for `language`.
+// The key is missing if it is not configured in `language`, even if it is found in its fallbacks.
+type MissingKeyHandler func(ctx context.Context, language, key string)
+
+// MissingKeyRecorder records the missing translation keys for languages,
+// which is used for exporting report of untranslated contents.
+//
+// Example:
+//
+//	recorder := gi18n.NewMissingKeyRecorder()
+//	gi18n.Instance().SetMissingKeyHandler(recorder.Record)
+//	...
+//	report := recorder.Report()
+type MissingKeyRecorder struct {
+	mu   sync.RWMutex
+	data map[string]map[string]struct{} // Missing keys: language -> key set.
+}
+
+// NewMissingKeyRecorder creates and returns a new recorder for missing translation keys.
+func NewMissingKeyRecorder() *MissingKeyRecorder {
+	return &MissingKeyRecorder{
+		data: make(map[string]map[string]struct{}),
+	}
+}
+
+// Record records the missing `key` for `language`, which implements MissingKeyHandler.
+func (r *MissingKeyRecorder) Record(ctx context.Context, language, key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.data[language] == nil {
+		r.data[language] = make(map[string]struct{})
+	}
+	r.data[language][key] = struct{}{}
+}
+
+// Keys returns the recorded missing keys for `language` in ascending order.
+func (r *MissingKeyRecorder) Keys(language string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return sortedKeys(r.data[language])
+}
+
+// Report returns all the recorded missing keys, the map key is language name and
+// its value is the missing keys in ascending order.
+func (r *MissingKeyRecorder) Report() map[string][]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var report = make(map[string][]string, len(r.data))
+	for language, keys := range r.data {
+		report[language] = sortedKeys(keys)
+	}
+	return report
+}
+
+// Clear deletes all the recorded missing keys.
+func (r *MissingKeyRecorder) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.data = make(map[string]map[string]struct{})
+}
+
+// SetMissingKeyHandler sets the handler called when translation key is missing,
+// which is used in Translate, TranslateFormat, TranslatePlural and TranslateArgs.
+// Note that GetContent does not call the handler, as it is commonly used for probing content.
+// The handler is called for every missing, and it can be nil to disable the reporting.
+func (m *Manager) SetMissingKeyHandler(handler MissingKeyHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.missingHandler = handler
+}
+
+// reportMissingKeys calls the missing key handler for `keys` of `language` if handler is set.
+// Note that it should be called without lock, as the handler might use the manager.
+func (m *Manager) reportMissingKeys(ctx context.Context, language string, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	m.mu.RLock()
+	handler := m.missingHandler
+	m.mu.RUnlock()
+	if handler == nil {
+		return
+	}
+	for _, key := range keys {
+		handler(ctx, language, key)
+	}
+}
+
+// sortedKeys returns the keys of `set` in ascending order.
+func sortedKeys(set map[string]struct{}) []string {
+	var keys = make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,128 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseAcceptLanguage parses the value of http header "Accept-Language" and returns the languages
+// sorted by their quality values in descending order, eg:
+//
+//	"zh-CN,zh;q=0.9,en;q=0.8" => ["zh-CN", "zh", "en"]
+//
+// The languages with quality value 0 are ignored.
+func ParseAcceptLanguage(header string) []string {
+	type item struct {
+		language string
+		quality  float64
+	}
+	var items = make([]item, 0)
+	for _, part := range strings.Split(header, ",") {
+		var (
+			language = strings.TrimSpace(part)
+			quality  = 1.0
+		)
+		if pos := strings.Index(language, ";"); pos >= 0 {
+			for _, param := range strings.Split(language[pos+1:], ";") {
+				param = strings.TrimSpace(param)
+				if strings.HasPrefix(param, "q=") {
+					v, err := strconv.ParseFloat(param[2:], 64)
+					if err != nil {
+						v = 0
+					}
+					quality = v
+				}
+			}
+			language = strings.TrimSpace(language[:pos])
+		}
+		if language == "" || quality <= 0 {
+			continue
+		}
+		items = append(items, item{language: language, quality: quality})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].quality > items[j].quality
+	})
+	var languages = make([]string, len(items))
+	for i, v := range items {
+		languages[i] = v.language
+	}
+	return languages
+}
+
+// Languages returns the names of languages loaded in manager in ascending order.
+func (m *Manager) Languages(ctx context.Context) []string {
+	m.init(ctx)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var languages = make([]string, 0, len(m.data))
+	for lang := range m.data {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// MatchLanguage returns the best matched language loaded in manager for `preferred` languages,
+// which are in order of preference, eg: the result of ParseAcceptLanguage.
+// The languages are compared case-insensitively, and "_" is treated as "-".
+//
+// For each preferred language, it matches the loaded language in order of:
+// the exact language, eg: "zh-CN" for "zh-CN";
+// the base language, eg: "en" for "en-US";
+// the first language of the same base, eg: "zh-CN" for "zh" or "zh-HK".
+//
+// It returns an empty string if no language matched.
+func (m *Manager) MatchLanguage(ctx context.Context, preferred ...string) string {
+	var (
+		languages  = m.Languages(ctx)
+		normalized = make([]string, len(languages))
+	)
+	for i, lang := range languages {
+		normalized[i] = normalizeLanguage(lang)
+	}
+	for _, pref := range preferred {
+		pref = normalizeLanguage(pref)
+		if pref == "" || pref == "*" {
+			continue
+		}
+		for i, lang := range normalized {
+			if lang == pref {
+				return languages[i]
+			}
+		}
+		base := baseLanguage(pref)
+		for i, lang := range normalized {
+			if lang == base {
+				return languages[i]
+			}
+		}
+		for i, lang := range normalized {
+			if baseLanguage(lang) == base {
+				return languages[i]
+			}
+		}
+	}
+	return ""
+}
+
+// normalizeLanguage converts `language` to lower case and replaces "_" with "-".
+func normalizeLanguage(language string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(language), "_", "-"))
+}
+
+// baseLanguage returns the base language name of normalized `language`, eg: "zh" for "zh-cn".
+func baseLanguage(language string) string {
+	if pos := strings.Index(language, "-"); pos > 0 {
+		return language[:pos]
+	}
+	return language
+}
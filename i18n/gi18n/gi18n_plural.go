@@ -0,0 +1,269 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Plural categories defined by CLDR.
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// PluralOperands is the operands of number for plural rules, which is defined by CLDR.
+// See https://unicode.org/reports/tr35/tr35-numbers.html#Operands.
+type PluralOperands struct {
+	N float64 // Absolute value of the source number.
+	I int64   // Integer digits of N.
+	V int     // Number of visible fraction digits in N, with trailing zeros.
+	F int64   // Visible fraction digits in N, with trailing zeros.
+	T int64   // Visible fraction digits in N, without trailing zeros.
+}
+
+// PluralRule is the function returning plural category for given number operands.
+type PluralRule func(o PluralOperands) string
+
+var (
+	// pluralRulesMu is the mutex for pluralRules.
+	pluralRulesMu sync.RWMutex
+
+	// pluralRules is the plural rules for languages, the key is the base language name.
+	pluralRules = map[string]PluralRule{}
+)
+
+func init() {
+	for _, lang := range []string{
+		"ja", "zh", "ko", "vi", "th", "id", "ms", "lo", "my", "km", "yue",
+	} {
+		pluralRules[lang] = pluralRuleOther
+	}
+	for _, lang := range []string{
+		"en", "de", "nl", "sv", "nb", "no", "nn", "da", "fi", "et", "it", "ca", "gl", "ur", "sw",
+	} {
+		pluralRules[lang] = pluralRuleOneInteger
+	}
+	for _, lang := range []string{
+		"es", "el", "tr", "hu", "bg", "az", "kk", "ka", "uz", "mn", "ta", "te", "ml", "ne",
+	} {
+		pluralRules[lang] = pluralRuleOneNumber
+	}
+	for _, lang := range []string{"fr", "pt", "hi", "bn", "fa", "am", "zu", "gu", "kn"} {
+		pluralRules[lang] = pluralRuleOneZeroToOne
+	}
+	for _, lang := range []string{"ru", "uk", "be"} {
+		pluralRules[lang] = pluralRuleEastSlavic
+	}
+	for _, lang := range []string{"cs", "sk"} {
+		pluralRules[lang] = pluralRuleCzech
+	}
+	pluralRules["pl"] = pluralRulePolish
+	pluralRules["ro"] = pluralRuleRomanian
+	pluralRules["he"] = pluralRuleHebrew
+	pluralRules["ar"] = pluralRuleArabic
+}
+
+// SetPluralRule sets custom plural rule for `language`, which overwrites the builtin rule.
+// The `language` can be base language name like "en", or full language tag like "pt-PT".
+func SetPluralRule(language string, rule PluralRule) {
+	pluralRulesMu.Lock()
+	defer pluralRulesMu.Unlock()
+	pluralRules[strings.ToLower(language)] = rule
+}
+
+// PluralCategory returns the CLDR plural category of `count` for `language`,
+// which is one of "zero", "one", "two", "few", "many" and "other".
+// The parameter `count` can be integer, float or numeric string, and the numeric string
+// keeps its visible fraction digits, eg: "1.0" is "other" but "1" is "one" in English.
+func PluralCategory(language string, count any) string {
+	return getPluralRule(language)(newPluralOperands(count))
+}
+
+// getPluralRule returns the plural rule for `language`, which searches the full language tag
+// and then the base language name. It uses the English rule if no rule found.
+func getPluralRule(language string) PluralRule {
+	language = strings.ToLower(strings.ReplaceAll(language, "_", "-"))
+	pluralRulesMu.RLock()
+	defer pluralRulesMu.RUnlock()
+	if rule, ok := pluralRules[language]; ok {
+		return rule
+	}
+	if pos := strings.Index(language, "-"); pos > 0 {
+		if rule, ok := pluralRules[language[:pos]]; ok {
+			return rule
+		}
+	}
+	return pluralRuleOneInteger
+}
+
+// newPluralOperands creates and returns the plural operands for `count`.
+func newPluralOperands(count any) PluralOperands {
+	var s string
+	switch v := count.(type) {
+	case float32:
+		s = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		s = strings.TrimSpace(gconv.String(count))
+	}
+	s = strings.TrimPrefix(s, "-")
+	var (
+		o               PluralOperands
+		integer, digits = s, ""
+	)
+	if pos := strings.Index(s, "."); pos >= 0 {
+		integer, digits = s[:pos], s[pos+1:]
+	}
+	o.N = math.Abs(gconv.Float64(s))
+	o.I = gconv.Int64(integer)
+	if digits != "" {
+		o.V = len(digits)
+		o.F = gconv.Int64(digits)
+		o.T = gconv.Int64(strings.TrimRight(digits, "0"))
+	}
+	return o
+}
+
+// inRange checks whether `n` is integer value in range [from, to].
+func inRange(n float64, from, to int64) bool {
+	return n == math.Trunc(n) && n >= float64(from) && n <= float64(to)
+}
+
+// pluralRuleOther is for languages without plural forms, eg: Chinese, Japanese.
+func pluralRuleOther(o PluralOperands) string {
+	return PluralOther
+}
+
+// pluralRuleOneInteger is for languages like English: one is "i = 1 and v = 0".
+func pluralRuleOneInteger(o PluralOperands) string {
+	if o.I == 1 && o.V == 0 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralRuleOneNumber is for languages like Spanish: one is "n = 1".
+func pluralRuleOneNumber(o PluralOperands) string {
+	if o.N == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralRuleOneZeroToOne is for languages like French: one is "i = 0,1".
+func pluralRuleOneZeroToOne(o PluralOperands) string {
+	if o.I == 0 || o.I == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralRuleEastSlavic is for Russian, Ukrainian and Belarusian.
+func pluralRuleEastSlavic(o PluralOperands) string {
+	if o.V != 0 {
+		return PluralOther
+	}
+	var (
+		mod10  = o.I % 10
+		mod100 = o.I % 100
+	)
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// pluralRulePolish is for Polish.
+func pluralRulePolish(o PluralOperands) string {
+	if o.V != 0 {
+		return PluralOther
+	}
+	var (
+		mod10  = o.I % 10
+		mod100 = o.I % 100
+	)
+	switch {
+	case o.I == 1:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// pluralRuleCzech is for Czech and Slovak.
+func pluralRuleCzech(o PluralOperands) string {
+	switch {
+	case o.V != 0:
+		return PluralMany
+	case o.I == 1:
+		return PluralOne
+	case o.I >= 2 && o.I <= 4:
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+// pluralRuleRomanian is for Romanian.
+func pluralRuleRomanian(o PluralOperands) string {
+	switch {
+	case o.I == 1 && o.V == 0:
+		return PluralOne
+	case o.V != 0 || o.N == 0 || (o.N != 1 && inRange(math.Mod(o.N, 100), 1, 19)):
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+// pluralRuleHebrew is for Hebrew.
+func pluralRuleHebrew(o PluralOperands) string {
+	switch {
+	case (o.I == 1 && o.V == 0) || (o.I == 0 && o.V != 0):
+		return PluralOne
+	case o.I == 2 && o.V == 0:
+		return PluralTwo
+	default:
+		return PluralOther
+	}
+}
+
+// pluralRuleArabic is for Arabic.
+func pluralRuleArabic(o PluralOperands) string {
+	var mod100 = math.Mod(o.N, 100)
+	switch {
+	case o.N == 0:
+		return PluralZero
+	case o.N == 1:
+		return PluralOne
+	case o.N == 2:
+		return PluralTwo
+	case inRange(mod100, 3, 10):
+		return PluralFew
+	case inRange(mod100, 11, 99):
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
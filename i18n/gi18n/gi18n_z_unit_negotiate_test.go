@@ -0,0 +1,113 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/i18n/gi18n"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_ParseAcceptLanguage(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gi18n.ParseAcceptLanguage(""), []string{})
+		t.Assert(gi18n.ParseAcceptLanguage("en"), []string{"en"})
+		t.Assert(
+			gi18n.ParseAcceptLanguage("fr;q=0.5, zh-CN,zh;q=0.9,en;q=0.8, de;q=0"),
+			[]string{"zh-CN", "zh", "en", "fr"},
+		)
+		t.Assert(gi18n.ParseAcceptLanguage("en;q=0.5,ru;q=0.5"), []string{"en", "ru"})
+	})
+}
+
+func Test_MatchLanguage(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx  = context.Background()
+			i18n = gi18n.New(gi18n.Options{
+				Path: gtest.DataPath("i18n-plural"),
+			})
+		)
+		t.Assert(i18n.Languages(ctx), []string{"en", "ru", "zh-CN", "zh-TW"})
+		t.Assert(i18n.MatchLanguage(ctx, "zh-TW"), "zh-TW")
+		t.Assert(i18n.MatchLanguage(ctx, "zh_tw"), "zh-TW")
+		t.Assert(i18n.MatchLanguage(ctx, "en-US"), "en")
+		t.Assert(i18n.MatchLanguage(ctx, "zh"), "zh-CN")
+		t.Assert(i18n.MatchLanguage(ctx, "zh-HK"), "zh-CN")
+		t.Assert(i18n.MatchLanguage(ctx, "de", "*", "ru-RU", "en"), "ru")
+		t.Assert(i18n.MatchLanguage(ctx, "de", "fr"), "")
+		t.Assert(i18n.MatchLanguage(ctx), "")
+	})
+}
+
+func Test_MissingKeyRecorder(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx      = gi18n.WithLanguage(context.Background(), "zh-TW")
+			recorder = gi18n.NewMissingKeyRecorder()
+			i18n     = gi18n.New(gi18n.Options{
+				Path: gtest.DataPath("i18n-plural"),
+				Fallbacks: map[string][]string{
+					"zh-TW": {"zh-CN"},
+				},
+			})
+		)
+		i18n.SetMissingKeyHandler(recorder.Record)
+
+		// Found in the language.
+		t.Assert(i18n.T(ctx, "hello"), "妳好")
+		// Found in fallback language.
+		t.Assert(i18n.T(ctx, "world"), "世界")
+		// Not found.
+		t.Assert(i18n.T(ctx, "unknown"), "unknown")
+		// Variables container.
+		t.Assert(i18n.T(ctx, "{#hello} {#nothing}"), "妳好 {#nothing}")
+		t.Assert(i18n.Tn(ctx, "apple", 2, g.Map{"name": "John"}), "John有2个苹果")
+		t.Assert(i18n.Ta(ctx, "none {name}", g.Map{"name": "John"}), "none John")
+		// GetContent does not report.
+		t.Assert(i18n.GetContent(ctx, "probe"), "")
+		t.Assert(i18n.T(context.Background(), "hello"), "Hello")
+		t.Assert(i18n.T(context.Background(), "only_zh"), "only_zh")
+
+		t.Assert(recorder.Keys("zh-TW"), []string{"apple", "none {name}", "nothing", "unknown", "world"})
+		t.Assert(recorder.Report(), map[string][]string{
+			"en":    {"only_zh"},
+			"zh-TW": {"apple", "none {name}", "nothing", "unknown", "world"},
+		})
+
+		recorder.Clear()
+		t.Assert(len(recorder.Report()), 0)
+
+		// Disable reporting.
+		i18n.SetMissingKeyHandler(nil)
+		t.Assert(i18n.T(ctx, "unknown"), "unknown")
+		t.Assert(len(recorder.Report()), 0)
+	})
+	// The whole content is not reported for TranslateContent.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx      = gi18n.WithLanguage(context.Background(), "zh-TW")
+			recorder = gi18n.NewMissingKeyRecorder()
+			i18n     = gi18n.New(gi18n.Options{
+				Path: gtest.DataPath("i18n-plural"),
+			})
+		)
+		i18n.SetMissingKeyHandler(recorder.Record)
+
+		t.Assert(i18n.TranslateContent(ctx, "<p>rendered page</p>"), "<p>rendered page</p>")
+		t.Assert(i18n.TranslateContent(ctx, "hello"), "妳好")
+		t.Assert(len(recorder.Report()), 0)
+
+		t.Assert(i18n.TranslateContent(ctx, "<p>{#hello} {#nothing}</p>"), "<p>妳好 {#nothing}</p>")
+		t.Assert(recorder.Report(), map[string][]string{
+			"zh-TW": {"nothing"},
+		})
+	})
+}
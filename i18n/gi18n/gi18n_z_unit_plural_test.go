@@ -0,0 +1,167 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/i18n/gi18n"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_PluralCategory(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gi18n.PluralCategory("en", 1), gi18n.PluralOne)
+		t.Assert(gi18n.PluralCategory("en", 0), gi18n.PluralOther)
+		t.Assert(gi18n.PluralCategory("en", 2), gi18n.PluralOther)
+		t.Assert(gi18n.PluralCategory("en", "1.0"), gi18n.PluralOther)
+		t.Assert(gi18n.PluralCategory("en-US", 1), gi18n.PluralOne)
+		t.Assert(gi18n.PluralCategory("fr", 0), gi18n.PluralOne)
+		t.Assert(gi18n.PluralCategory("fr", 1.5), gi18n.PluralOne)
+		t.Assert(gi18n.PluralCategory("zh-CN", 1), gi18n.PluralOther)
+		t.Assert(gi18n.PluralCategory("ja", 1), gi18n.PluralOther)
+
+		t.Assert(gi18n.PluralCategory("ru", 1), gi18n.PluralOne)
+		t.Assert(gi18n.PluralCategory("ru", 21), gi18n.PluralOne)
+		t.Assert(gi18n.PluralCategory("ru", 11), gi18n.PluralMany)
+		t.Assert(gi18n.PluralCategory("ru", 3), gi18n.PluralFew)
+		t.Assert(gi18n.PluralCategory("ru", 13), gi18n.PluralMany)
+		t.Assert(gi18n.PluralCategory("ru", 25), gi18n.PluralMany)
+		t.Assert(gi18n.PluralCategory("ru", 1.5), gi18n.PluralOther)
+
+		t.Assert(gi18n.PluralCategory("pl", 22), gi18n.PluralFew)
+		t.Assert(gi18n.PluralCategory("pl", 21), gi18n.PluralMany)
+		t.Assert(gi18n.PluralCategory("cs", 3), gi18n.PluralFew)
+		t.Assert(gi18n.PluralCategory("cs", 0.5), gi18n.PluralMany)
+
+		t.Assert(gi18n.PluralCategory("ar", 0), gi18n.PluralZero)
+		t.Assert(gi18n.PluralCategory("ar", 2), gi18n.PluralTwo)
+		t.Assert(gi18n.PluralCategory("ar", 105), gi18n.PluralFew)
+		t.Assert(gi18n.PluralCategory("ar", 111), gi18n.PluralMany)
+		t.Assert(gi18n.PluralCategory("ar", 100), gi18n.PluralOther)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		gi18n.SetPluralRule("x-test", func(o gi18n.PluralOperands) string {
+			if o.I%2 == 0 {
+				return gi18n.PluralZero
+			}
+			return gi18n.PluralOther
+		})
+		t.Assert(gi18n.PluralCategory("x-test", 2), gi18n.PluralZero)
+		t.Assert(gi18n.PluralCategory("x-test", 3), gi18n.PluralOther)
+	})
+}
+
+func Test_TranslatePlural(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx  = context.Background()
+			i18n = gi18n.New(gi18n.Options{
+				Path: gtest.DataPath("i18n-plural"),
+			})
+			args = g.Map{"name": "John"}
+		)
+		t.Assert(i18n.Tn(ctx, "apple", 0, args), "John has no apples")
+		t.Assert(i18n.Tn(ctx, "apple", 1, args), "John has 1 apple")
+		t.Assert(i18n.Tn(ctx, "apple", 5, args), "John has 5 apples")
+		t.Assert(i18n.Tn(ctx, "apple", "1.0", args), "John has 1.0 apples")
+		// The "other" form is used for normal translation.
+		t.Assert(i18n.T(ctx, "apple"), "{name} has # apples")
+
+		ctx = gi18n.WithLanguage(ctx, "ru")
+		t.Assert(i18n.Tn(ctx, "apple", 1, nil), "1 яблоко")
+		t.Assert(i18n.Tn(ctx, "apple", 3, nil), "3 яблока")
+		t.Assert(i18n.Tn(ctx, "apple", 5, nil), "5 яблок")
+		t.Assert(i18n.Tn(ctx, "apple", 21, nil), "21 яблоко")
+
+		ctx = gi18n.WithLanguage(ctx, "zh-CN")
+		t.Assert(i18n.Tn(ctx, "apple", 3, args), "John有3个苹果")
+
+		// ICU plural message.
+		ctx = gi18n.WithLanguage(ctx, "en")
+		t.Assert(i18n.Tn(ctx, "welcome", 0, args), "Hello John, you have no messages.")
+		t.Assert(i18n.Tn(ctx, "welcome", 1, args), "Hello John, you have 1 message.")
+		t.Assert(i18n.Tn(ctx, "welcome", 2, args), "Hello John, you have 2 messages.")
+
+		// Not found.
+		t.Assert(i18n.Tn(ctx, "{count} pears", 2, nil), "2 pears")
+	})
+}
+
+func Test_TranslateArgs(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx  = context.Background()
+			i18n = gi18n.New(gi18n.Options{
+				Path: gtest.DataPath("i18n-plural"),
+			})
+		)
+		t.Assert(i18n.Ta(ctx, "gender", g.Map{"gender": "female"}), "She liked it.")
+		t.Assert(i18n.Ta(ctx, "gender", g.Map{"gender": "male"}), "He liked it.")
+		t.Assert(i18n.Ta(ctx, "gender", nil), "They liked it.")
+		t.Assert(
+			i18n.Ta(ctx, "welcome", g.Map{"name": "Tom", "count": 3}),
+			"Hello Tom, you have 3 messages.",
+		)
+		// Missing arguments are kept.
+		t.Assert(i18n.Ta(ctx, "Hi {name}, {missing}", g.Map{"name": "Tom"}), "Hi Tom, {missing}")
+		// Quoting.
+		t.Assert(i18n.Ta(ctx, "'{name}' is {name}, it''s", g.Map{"name": "Tom"}), "{name} is Tom, it's")
+		// Offset.
+		t.Assert(
+			i18n.Ta(ctx, "{n, plural, offset:1 =0 {nobody} =1 {{who}} one {{who} and # other} other {{who} and # others}}", g.Map{
+				"n":   3,
+				"who": "Tom",
+			}),
+			"Tom and 2 others",
+		)
+		t.Assert(
+			i18n.Ta(ctx, "{n, plural, offset:1 =0 {nobody} =1 {{who}} one {{who} and # other} other {{who} and # others}}", g.Map{
+				"n":   2,
+				"who": "Tom",
+			}),
+			"Tom and 1 other",
+		)
+		// Invalid syntax is kept.
+		t.Assert(i18n.Ta(ctx, "{ {name} }", g.Map{"name": "Tom"}), "{ Tom }")
+	})
+}
+
+func Test_Fallbacks(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx  = gi18n.WithLanguage(context.Background(), "zh-TW")
+			i18n = gi18n.New(gi18n.Options{
+				Path: gtest.DataPath("i18n-plural"),
+				Fallbacks: map[string][]string{
+					"zh-HK": {"zh-TW"},
+					"zh-TW": {"zh-CN"},
+					"*":     {"en"},
+				},
+			})
+		)
+		t.Assert(i18n.T(ctx, "{#hello}{#world}"), "妳好世界")
+		t.Assert(i18n.T(ctx, "only_en"), "English only")
+		t.Assert(i18n.GetContent(ctx, "world"), "世界")
+		t.Assert(i18n.Tn(ctx, "apple", 2, g.Map{"name": "John"}), "John有2个苹果")
+
+		ctx = gi18n.WithLanguage(ctx, "zh-HK")
+		t.Assert(i18n.T(ctx, "{#hello}{#world}"), "妳好世界")
+
+		// Plural rule of the found language is used.
+		ctx = gi18n.WithLanguage(ctx, "ja")
+		t.Assert(i18n.Tn(ctx, "apple", 1, g.Map{"name": "John"}), "John has 1 apple")
+
+		// No fallback.
+		i18n.SetFallbacks("*")
+		t.Assert(i18n.T(ctx, "only_en"), "only_en")
+		i18n.SetFallbacks("ja", "zh-CN")
+		t.Assert(i18n.T(ctx, "hello"), "你好")
+	})
+}
@@ -37,6 +37,8 @@ type Client struct {
 	middlewareHandler []HandlerFunc     // Interceptor handlers
 	discovery         gsvc.Discovery    // Discovery for service.
 	builder           gsel.Builder      // Builder for request balance.
+	retryPolicy       *RetryPolicy      // Retry policy with exponential backoff.
+	circuitBreaker    *CircuitBreaker   // Per-host circuit breaker.
 }
 
 const (
@@ -90,7 +92,12 @@ func New() *Client {
 	}
 	c.header[httpHeaderUserAgent] = defaultClientAgent
 	// It enables OpenTelemetry for client in default.
-	c.Use(internalMiddlewareObservability, internalMiddlewareDiscovery)
+	c.Use(
+		internalMiddlewareObservability,
+		internalMiddlewareRetry,
+		internalMiddlewareDiscovery,
+		internalMiddlewareCircuitBreaker,
+	)
 	return c
 }
 
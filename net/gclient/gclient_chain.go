@@ -108,6 +108,14 @@ func (c *Client) Retry(retryCount int, retryInterval time.Duration) *Client {
 	return newClient
 }
 
+// RetryPolicy is a chaining function,
+// which sets the retry policy for next request.
+func (c *Client) RetryPolicy(policy RetryPolicy) *Client {
+	newClient := c.Clone()
+	newClient.SetRetryPolicy(policy)
+	return newClient
+}
+
 // Proxy is a chaining function,
 // which sets proxy for next request.
 // Make sure you pass the correct `proxyURL`.
@@ -0,0 +1,260 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// CircuitState is the state of circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitStateClosed is the state that requests are allowed.
+	CircuitStateClosed CircuitState = iota
+	// CircuitStateOpen is the state that requests are rejected immediately.
+	CircuitStateOpen
+	// CircuitStateHalfOpen is the state that limited probe requests are allowed after open timeout.
+	CircuitStateHalfOpen
+)
+
+// CircuitBreakerConfig is the configuration for CircuitBreaker.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the consecutive failure count opening the circuit, which is 5 in default.
+	FailureThreshold int
+
+	// OpenTimeout is the duration of open state before turning into half-open, which is 30s in default.
+	OpenTimeout time.Duration
+
+	// HalfOpenMaxRequests is the max concurrent probe requests in half-open state, which is 1 in default.
+	HalfOpenMaxRequests int
+
+	// IsFailure is the custom predicate deciding whether the request is failed.
+	// It treats request errors and 5xx status codes as failures in default.
+	IsFailure func(resp *Response, err error) bool
+}
+
+// CircuitBreaker is the per-host circuit breaker for client requests.
+// It is safe to share one CircuitBreaker between multiple clients.
+//
+// Only the circuits of hosts failing recently are kept, as the circuit of a host is removed
+// once it turns healthy, so the circuits do not grow with all requested hosts.
+type CircuitBreaker struct {
+	config   CircuitBreakerConfig
+	circuits *gmap.StrAnyMap // Host to its *circuit.
+}
+
+// circuit is the circuit state of one host.
+type circuit struct {
+	mu       sync.Mutex
+	state    CircuitState // Current state.
+	failures int          // Consecutive failure count in closed state.
+	openedAt time.Time    // Time the circuit turns open.
+	probing  int          // Running probe requests in half-open state.
+	removed  bool         // Whether the circuit is removed from breaker.
+}
+
+const (
+	defaultCircuitFailureThreshold    = 5
+	defaultCircuitOpenTimeout         = 30 * time.Second
+	defaultCircuitHalfOpenMaxRequests = 1
+)
+
+// String returns the state as string.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitStateOpen:
+		return "open"
+	case CircuitStateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// NewCircuitBreaker creates and returns a per-host circuit breaker.
+func NewCircuitBreaker(config ...CircuitBreakerConfig) *CircuitBreaker {
+	var c CircuitBreakerConfig
+	if len(config) > 0 {
+		c = config[0]
+	}
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = defaultCircuitFailureThreshold
+	}
+	if c.OpenTimeout <= 0 {
+		c.OpenTimeout = defaultCircuitOpenTimeout
+	}
+	if c.HalfOpenMaxRequests <= 0 {
+		c.HalfOpenMaxRequests = defaultCircuitHalfOpenMaxRequests
+	}
+	if c.IsFailure == nil {
+		c.IsFailure = isCircuitFailure
+	}
+	return &CircuitBreaker{
+		config:   c,
+		circuits: gmap.NewStrAnyMap(true),
+	}
+}
+
+// SetCircuitBreaker sets the circuit breaker for client, which rejects requests to the hosts
+// whose circuits are open. The open hosts are also skipped when selecting service nodes
+// if service discovery is enabled.
+func (c *Client) SetCircuitBreaker(breaker *CircuitBreaker) *Client {
+	c.circuitBreaker = breaker
+	return c
+}
+
+// State returns the circuit state of `host`.
+func (b *CircuitBreaker) State(host string) CircuitState {
+	v := b.circuits.Get(host)
+	if v == nil {
+		return CircuitStateClosed
+	}
+	cc := v.(*circuit)
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	b.checkOpenTimeout(cc)
+	return cc.state
+}
+
+// Allow checks whether the request to `host` is allowed.
+// It returns error if the circuit is open, or the probe requests exceed the limit in half-open state.
+// The caller should call Report with the request result if it is allowed.
+func (b *CircuitBreaker) Allow(host string) error {
+	cc := b.lockCircuit(host)
+	defer cc.mu.Unlock()
+	b.checkOpenTimeout(cc)
+	switch cc.state {
+	case CircuitStateOpen:
+		return gerror.NewCodef(gcode.CodeServerBusy, `circuit of host "%s" is open`, host)
+
+	case CircuitStateHalfOpen:
+		if cc.probing >= b.config.HalfOpenMaxRequests {
+			return gerror.NewCodef(gcode.CodeServerBusy, `circuit of host "%s" is half-open`, host)
+		}
+		cc.probing++
+	}
+	return nil
+}
+
+// Report reports the result of request to `host` which is allowed by Allow.
+func (b *CircuitBreaker) Report(host string, success bool) {
+	cc := b.lockCircuit(host)
+	defer func() {
+		healthy := cc.isHealthy()
+		cc.mu.Unlock()
+		if healthy {
+			b.removeCircuit(host, cc)
+		}
+	}()
+	switch cc.state {
+	case CircuitStateClosed:
+		if success {
+			cc.failures = 0
+			return
+		}
+		cc.failures++
+		if cc.failures >= b.config.FailureThreshold {
+			cc.state = CircuitStateOpen
+			cc.openedAt = time.Now()
+		}
+
+	case CircuitStateHalfOpen:
+		if cc.probing > 0 {
+			cc.probing--
+		}
+		if success {
+			cc.state = CircuitStateClosed
+			cc.failures = 0
+		} else {
+			cc.state = CircuitStateOpen
+			cc.openedAt = time.Now()
+		}
+	}
+}
+
+// Reset resets the circuit of `host` to closed state.
+func (b *CircuitBreaker) Reset(host string) {
+	b.removeCircuit(host, nil)
+}
+
+// lockCircuit retrieves and locks the circuit of `host`, which creates the circuit if it does not exist.
+func (b *CircuitBreaker) lockCircuit(host string) *circuit {
+	for {
+		cc := b.circuits.GetOrSetFuncLock(host, func() any {
+			return &circuit{}
+		}).(*circuit)
+		cc.mu.Lock()
+		if !cc.removed {
+			return cc
+		}
+		// The circuit is removed concurrently, it retrieves again.
+		cc.mu.Unlock()
+	}
+}
+
+// removeCircuit removes the circuit of `host` from breaker.
+// If `expect` is not nil, it removes the circuit only if it is still `expect` and healthy.
+func (b *CircuitBreaker) removeCircuit(host string, expect *circuit) {
+	b.circuits.LockFunc(func(m map[string]any) {
+		v, ok := m[host]
+		if !ok || (expect != nil && v != expect) {
+			return
+		}
+		cc := v.(*circuit)
+		cc.mu.Lock()
+		defer cc.mu.Unlock()
+		if expect != nil && !cc.isHealthy() {
+			return
+		}
+		cc.removed = true
+		delete(m, host)
+	})
+}
+
+// isHealthy checks whether the circuit is closed without any failure,
+// which is the same as the circuit does not exist.
+func (cc *circuit) isHealthy() bool {
+	return cc.state == CircuitStateClosed && cc.failures == 0
+}
+
+// checkOpenTimeout turns the open circuit into half-open if open timeout reaches.
+func (b *CircuitBreaker) checkOpenTimeout(cc *circuit) {
+	if cc.state == CircuitStateOpen && time.Since(cc.openedAt) >= b.config.OpenTimeout {
+		cc.state = CircuitStateHalfOpen
+		cc.probing = 0
+	}
+}
+
+// isCircuitFailure is the default failure predicate of circuit breaker.
+func isCircuitFailure(resp *Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp != nil && resp.Response != nil && resp.StatusCode >= http.StatusInternalServerError
+}
+
+// internalMiddlewareCircuitBreaker is a client middleware that enables circuit breaker for client.
+func internalMiddlewareCircuitBreaker(c *Client, r *http.Request) (response *Response, err error) {
+	var breaker = c.circuitBreaker
+	if breaker == nil {
+		return c.Next(r)
+	}
+	var host = r.URL.Host
+	if err = breaker.Allow(host); err != nil {
+		return nil, err
+	}
+	response, err = c.Next(r)
+	breaker.Report(host, !breaker.config.IsFailure(response, err))
+	return response, err
+}
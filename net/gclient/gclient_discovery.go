@@ -84,7 +84,7 @@ func internalMiddlewareDiscovery(c *Client, r *http.Request) (response *Response
 	}
 	selector := selectorMapValue.(gsel.Selector)
 	// Pick one node from multiple addresses.
-	node, done, err := selector.Pick(ctx)
+	node, done, err := pickNodeWithCircuitBreaker(ctx, c.circuitBreaker, selector, len(service.GetEndpoints()))
 	if err != nil {
 		return nil, err
 	}
@@ -96,6 +96,29 @@ func internalMiddlewareDiscovery(c *Client, r *http.Request) (response *Response
 	return c.Next(r)
 }
 
+// pickNodeWithCircuitBreaker picks one node from `selector`, which skips the nodes whose circuits are open.
+// It tries at most `count` times, and returns error if all picked nodes are open.
+func pickNodeWithCircuitBreaker(
+	ctx context.Context, breaker *CircuitBreaker, selector gsel.Selector, count int,
+) (node gsel.Node, done gsel.DoneFunc, err error) {
+	if breaker == nil {
+		return selector.Pick(ctx)
+	}
+	for i := 0; i < count || i == 0; i++ {
+		if node, done, err = selector.Pick(ctx); err != nil {
+			return nil, nil, err
+		}
+		if breaker.State(node.Address()) != CircuitStateOpen {
+			return node, done, nil
+		}
+		err = gerror.NewCodef(gcode.CodeServerBusy, `circuit of host "%s" is open`, node.Address())
+		if done != nil {
+			done(ctx, gsel.DoneInfo{Err: err})
+		}
+	}
+	return nil, nil, err
+}
+
 func updateSelectorNodesByService(ctx context.Context, selector gsel.Selector, service gsvc.Service) error {
 	nodes := make(gsel.Nodes, 0)
 	for _, endpoint := range service.GetEndpoints() {
@@ -48,3 +48,11 @@ func (m *clientMiddleware) Next(req *http.Request) (resp *Response, err error) {
 	}
 	return m.resp, m.err
 }
+
+// reset resets the middleware to handler `index`, which is used for calling the following
+// handlers again, eg: retrying.
+func (m *clientMiddleware) reset(index int) {
+	m.handlerIndex = index
+	m.resp = nil
+	m.err = nil
+}
@@ -0,0 +1,205 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// RetryPolicy is the policy for retrying failed requests with exponential backoff.
+type RetryPolicy struct {
+	// Count is the max retry count, which is 3 in default.
+	Count int
+
+	// BaseDelay is the delay before the first retry, which is 100ms in default.
+	// The delay grows exponentially with Multiplier for later retries.
+	BaseDelay time.Duration
+
+	// MaxDelay is the max delay between retries, which is 10s in default.
+	MaxDelay time.Duration
+
+	// Multiplier is the growing factor of delay, which is 2 in default.
+	Multiplier float64
+
+	// Jitter is the randomization factor between 0 and 1 applied to delay,
+	// eg: 0.2 means the delay is randomized in [delay*0.8, delay*1.2]. It is 0.2 in default.
+	Jitter float64
+
+	// Methods is the HTTP methods that can be retried,
+	// which are idempotent methods GET, HEAD, OPTIONS, TRACE, PUT and DELETE in default.
+	Methods []string
+
+	// StatusCodes is the response status codes that should be retried,
+	// which are 429, 502, 503 and 504 in default.
+	StatusCodes []int
+
+	// RetryIf is the custom predicate deciding whether the request should be retried.
+	// If it is specified, it replaces the default deciding, which retries on request errors
+	// and StatusCodes.
+	RetryIf func(resp *Response, err error) bool
+}
+
+const (
+	defaultRetryCount      = 3
+	defaultRetryBaseDelay  = 100 * time.Millisecond
+	defaultRetryMaxDelay   = 10 * time.Second
+	defaultRetryMultiplier = 2
+	defaultRetryJitter     = 0.2
+)
+
+var (
+	defaultRetryMethods = []string{
+		http.MethodGet,
+		http.MethodHead,
+		http.MethodOptions,
+		http.MethodTrace,
+		http.MethodPut,
+		http.MethodDelete,
+	}
+	defaultRetryStatusCodes = []int{
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	}
+)
+
+// SetRetryPolicy sets the retry policy for client, which retries the failed requests
+// with exponential backoff. Note that it is different from SetRetry, which retries only
+// the transport errors in fixed interval.
+//
+// As the retrying happens before service discovery, the retried request might be sent to
+// another node of the service.
+func (c *Client) SetRetryPolicy(policy RetryPolicy) *Client {
+	if policy.Count <= 0 {
+		policy.Count = defaultRetryCount
+	}
+	if policy.BaseDelay <= 0 {
+		policy.BaseDelay = defaultRetryBaseDelay
+	}
+	if policy.MaxDelay <= 0 {
+		policy.MaxDelay = defaultRetryMaxDelay
+	}
+	if policy.Multiplier < 1 {
+		policy.Multiplier = defaultRetryMultiplier
+	}
+	if policy.Jitter < 0 || policy.Jitter > 1 {
+		policy.Jitter = defaultRetryJitter
+	}
+	if len(policy.Methods) == 0 {
+		policy.Methods = defaultRetryMethods
+	}
+	if len(policy.StatusCodes) == 0 {
+		policy.StatusCodes = defaultRetryStatusCodes
+	}
+	c.retryPolicy = &policy
+	return c
+}
+
+// Delay calculates and returns the delay before the retry of `attempt`, which starts from 1.
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	delay := float64(p.BaseDelay) * math.Pow(p.Multiplier, float64(attempt-1))
+	if delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	if p.Jitter > 0 {
+		delay = delay * (1 - p.Jitter + 2*p.Jitter*float64(grand.Intn(1000))/1000)
+	}
+	return time.Duration(delay)
+}
+
+// shouldRetry checks and returns whether the request should be retried.
+func (p RetryPolicy) shouldRetry(resp *Response, err error) bool {
+	if p.RetryIf != nil {
+		return p.RetryIf(resp, err)
+	}
+	if err != nil {
+		return true
+	}
+	if resp != nil && resp.Response != nil {
+		for _, code := range p.StatusCodes {
+			if resp.StatusCode == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isRetryableMethod checks and returns whether the request of `method` can be retried.
+func (p RetryPolicy) isRetryableMethod(method string) bool {
+	for _, m := range p.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// internalMiddlewareRetry is a client middleware that retries failed requests using retry policy.
+func internalMiddlewareRetry(c *Client, r *http.Request) (response *Response, err error) {
+	var policy = c.retryPolicy
+	if policy == nil || !policy.isRetryableMethod(r.Method) {
+		return c.Next(r)
+	}
+	middleware, ok := r.Context().Value(clientMiddlewareKey).(*clientMiddleware)
+	if !ok {
+		return c.Next(r)
+	}
+	var (
+		ctx          = r.Context()
+		handlerIndex = middleware.handlerIndex
+		host         = r.Host
+		urlHost      = r.URL.Host
+		body         []byte
+		originBody   = r.Body
+		hasBody      = originBody != nil && originBody != http.NoBody
+	)
+	if hasBody {
+		if body, err = io.ReadAll(r.Body); err != nil {
+			return nil, err
+		}
+	}
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			delay := policy.Delay(attempt)
+			intlog.Printf(
+				ctx, `retry request "%s %s" after %s, attempt %d`,
+				r.Method, r.URL.String(), delay, attempt,
+			)
+			select {
+			case <-ctx.Done():
+				return response, err
+			case <-time.After(delay):
+			}
+			// The response of last attempt is not used anymore.
+			if response != nil {
+				_ = response.Close()
+			}
+			middleware.reset(handlerIndex)
+			// The host might be changed by service discovery.
+			r.Host = host
+			r.URL.Host = urlHost
+		}
+		// The request without body keeps its original empty body.
+		r.Body = originBody
+		if hasBody {
+			r.Body = utils.NewReadCloser(body, false)
+		}
+		response, err = c.Next(r)
+		if attempt >= policy.Count || !policy.shouldRetry(response, err) {
+			return response, err
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// SSEEvent is a Server-Sent Event parsed from event stream.
+type SSEEvent struct {
+	ID    string        // Event id, which is the last event id if it is not specified by this event.
+	Event string        // Event type, which is "message" if it is not specified by this event.
+	Data  string        // Event data, multiple data lines are joined with '\n'.
+	Retry time.Duration // Reconnection time specified by this event, zero if not specified.
+}
+
+// SSEReader reads and parses Server-Sent Events from response.
+type SSEReader struct {
+	response    *Response     // Response of event stream.
+	reader      *bufio.Reader // Reader of the response body.
+	lastEventID string        // Id of the last event.
+	retry       time.Duration // Last reconnection time sent by server.
+}
+
+const (
+	sseDefaultEvent = "message"
+)
+
+// SSE sends GET request for event stream and returns the SSEReader reading events from response.
+// The optional parameter `lastEventID` is sent in header "Last-Event-ID" for resuming the event stream.
+// It returns error if the response status is not 200.
+//
+// Note that the SSEReader MUST be closed if it'll never be used.
+func (c *Client) SSE(ctx context.Context, url string, lastEventID ...string) (*SSEReader, error) {
+	var header = map[string]string{
+		"Accept":        "text/event-stream",
+		"Cache-Control": "no-cache",
+	}
+	if len(lastEventID) > 0 && lastEventID[0] != "" {
+		header["Last-Event-ID"] = lastEventID[0]
+	}
+	response, err := c.Header(header).Get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		_ = response.Close()
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidOperation,
+			`invalid response status "%s" for event stream`,
+			response.Status,
+		)
+	}
+	return response.SSE(), nil
+}
+
+// SSE returns the SSEReader reading events from the response body.
+func (r *Response) SSE() *SSEReader {
+	return &SSEReader{
+		response: r,
+		reader:   bufio.NewReader(r.Body),
+	}
+}
+
+// Next reads and returns the next event from event stream, which blocks until an event is received.
+// It returns io.EOF if the event stream is closed by server.
+func (r *SSEReader) Next() (*SSEEvent, error) {
+	var (
+		event   = &SSEEvent{}
+		data    strings.Builder
+		hasData bool
+	)
+	for {
+		line, err := r.reader.ReadString('\n')
+		if err != nil {
+			// The incomplete event at the end of stream is discarded.
+			if err == io.EOF {
+				return nil, io.EOF
+			}
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			// Dispatch the event if it has data, or else it is ignored.
+			if !hasData {
+				event = &SSEEvent{}
+				continue
+			}
+			event.ID = r.lastEventID
+			event.Data = data.String()
+			if event.Event == "" {
+				event.Event = sseDefaultEvent
+			}
+			return event, nil
+		}
+		// Comment line.
+		if line[0] == ':' {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				r.lastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.ParseInt(value, 10, 64); err == nil && ms >= 0 {
+				r.retry = time.Duration(ms) * time.Millisecond
+				event.Retry = r.retry
+			}
+		}
+	}
+}
+
+// LastEventID returns the id of the last received event,
+// which can be used to resume the event stream by Client.SSE.
+func (r *SSEReader) LastEventID() string {
+	return r.lastEventID
+}
+
+// Retry returns the last reconnection time sent by server, zero if not sent.
+func (r *SSEReader) Retry() time.Duration {
+	return r.retry
+}
+
+// Close closes the underlying response.
+func (r *SSEReader) Close() error {
+	return r.response.Close()
+}
@@ -0,0 +1,309 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func TestClient_RetryPolicy(t *testing.T) {
+	var counter = gtype.NewInt()
+	s := g.Server(guid.S())
+	s.BindHandler("/unavailable", func(r *ghttp.Request) {
+		if counter.Add(1) < 3 {
+			r.Response.WriteStatus(http.StatusServiceUnavailable)
+			return
+		}
+		r.Response.Write(r.GetBodyString())
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	// Retried until success, with the same body.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().Prefix(prefix).RetryPolicy(gclient.RetryPolicy{
+			BaseDelay: 10 * time.Millisecond,
+		})
+		t.Assert(client.PutContent(ctx, "/unavailable", "body"), "body")
+		t.Assert(counter.Val(), 3)
+	})
+	// Request without body is retried without body.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		var noBody = gtype.NewInt()
+		client := g.Client().Prefix(prefix).RetryPolicy(gclient.RetryPolicy{
+			BaseDelay: 10 * time.Millisecond,
+		})
+		client.Use(func(c *gclient.Client, r *http.Request) (*gclient.Response, error) {
+			if r.Body == http.NoBody {
+				noBody.Add(1)
+			}
+			return c.Next(r)
+		})
+		t.Assert(client.GetContent(ctx, "/unavailable"), "")
+		t.Assert(counter.Val(), 3)
+		t.Assert(noBody.Val(), 3)
+	})
+	// Retry count limit.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().Prefix(prefix).RetryPolicy(gclient.RetryPolicy{
+			Count:     1,
+			BaseDelay: 10 * time.Millisecond,
+		})
+		resp, err := client.Get(ctx, "/unavailable")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		t.AssertNil(resp.Close())
+		t.Assert(counter.Val(), 2)
+	})
+	// Not idempotent method is not retried in default.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().Prefix(prefix).RetryPolicy(gclient.RetryPolicy{
+			BaseDelay: 10 * time.Millisecond,
+		})
+		resp, err := client.Post(ctx, "/unavailable")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		t.AssertNil(resp.Close())
+		t.Assert(counter.Val(), 1)
+	})
+	// Custom predicate.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().Prefix(prefix).RetryPolicy(gclient.RetryPolicy{
+			BaseDelay: 10 * time.Millisecond,
+			RetryIf: func(resp *gclient.Response, err error) bool {
+				return false
+			},
+		})
+		resp, err := client.Get(ctx, "/unavailable")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		t.AssertNil(resp.Close())
+		t.Assert(counter.Val(), 1)
+	})
+}
+
+func TestClient_RetryPolicy_Delay(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		policy := gclient.RetryPolicy{
+			BaseDelay:  100 * time.Millisecond,
+			MaxDelay:   time.Second,
+			Multiplier: 2,
+		}
+		t.Assert(policy.Delay(1), 100*time.Millisecond)
+		t.Assert(policy.Delay(2), 200*time.Millisecond)
+		t.Assert(policy.Delay(3), 400*time.Millisecond)
+		t.Assert(policy.Delay(10), time.Second)
+
+		policy.Jitter = 0.5
+		for i := 0; i < 10; i++ {
+			delay := policy.Delay(1)
+			t.AssertGE(delay, 50*time.Millisecond)
+			t.AssertLE(delay, 150*time.Millisecond)
+		}
+	})
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	var counter = gtype.NewInt()
+	s := g.Server(guid.S())
+	s.BindHandler("/error", func(r *ghttp.Request) {
+		counter.Add(1)
+		r.Response.WriteStatus(http.StatusInternalServerError)
+	})
+	s.BindHandler("/ok", func(r *ghttp.Request) {
+		counter.Add(1)
+		r.Response.Write("ok")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			host    = fmt.Sprintf("127.0.0.1:%d", s.GetListenedPort())
+			breaker = gclient.NewCircuitBreaker(gclient.CircuitBreakerConfig{
+				FailureThreshold: 2,
+				OpenTimeout:      200 * time.Millisecond,
+			})
+			client = g.Client().Prefix("http://" + host)
+		)
+		client.SetCircuitBreaker(breaker)
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(ctx, "/error")
+			t.AssertNil(err)
+			t.AssertNil(resp.Close())
+		}
+		t.Assert(breaker.State(host), gclient.CircuitStateOpen)
+
+		// Rejected immediately.
+		_, err := client.Get(ctx, "/ok")
+		t.AssertNE(err, nil)
+		t.Assert(counter.Val(), 2)
+
+		// Half-open after timeout, and closed after the probe succeeds.
+		time.Sleep(250 * time.Millisecond)
+		t.Assert(breaker.State(host), gclient.CircuitStateHalfOpen)
+		t.Assert(client.GetContent(ctx, "/ok"), "ok")
+		t.Assert(breaker.State(host), gclient.CircuitStateClosed)
+		t.Assert(counter.Val(), 3)
+	})
+}
+
+func TestClient_CircuitBreaker_Report(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			host    = "127.0.0.1:80"
+			breaker = gclient.NewCircuitBreaker(gclient.CircuitBreakerConfig{
+				FailureThreshold: 2,
+				OpenTimeout:      time.Minute,
+			})
+		)
+		// Success resets the consecutive failures.
+		t.AssertNil(breaker.Allow(host))
+		breaker.Report(host, false)
+		t.AssertNil(breaker.Allow(host))
+		breaker.Report(host, true)
+		t.AssertNil(breaker.Allow(host))
+		breaker.Report(host, false)
+		t.Assert(breaker.State(host), gclient.CircuitStateClosed)
+		t.AssertNil(breaker.Allow(host))
+		breaker.Report(host, false)
+		t.Assert(breaker.State(host), gclient.CircuitStateOpen)
+
+		breaker.Reset(host)
+		t.Assert(breaker.State(host), gclient.CircuitStateClosed)
+	})
+	// Concurrent reporting of healthy and failing hosts.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg      sync.WaitGroup
+			breaker = gclient.NewCircuitBreaker(gclient.CircuitBreakerConfig{
+				FailureThreshold: 1000,
+			})
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					if breaker.Allow("healthy") == nil {
+						breaker.Report("healthy", true)
+					}
+					if breaker.Allow("failing") == nil {
+						breaker.Report("failing", false)
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+		t.Assert(breaker.State("healthy"), gclient.CircuitStateClosed)
+		t.Assert(breaker.State("failing"), gclient.CircuitStateOpen)
+	})
+}
+
+// testDiscovery is the Discovery with static services for testing.
+type testDiscovery struct {
+	service gsvc.Service
+}
+
+type testWatcher struct {
+	closed chan struct{}
+}
+
+func (d *testDiscovery) Search(ctx context.Context, in gsvc.SearchInput) ([]gsvc.Service, error) {
+	return []gsvc.Service{d.service}, nil
+}
+
+func (d *testDiscovery) Watch(ctx context.Context, key string) (gsvc.Watcher, error) {
+	return &testWatcher{closed: make(chan struct{})}, nil
+}
+
+func (w *testWatcher) Proceed() ([]gsvc.Service, error) {
+	<-w.closed
+	return nil, context.Canceled
+}
+
+func (w *testWatcher) Close() error {
+	close(w.closed)
+	return nil
+}
+
+func TestClient_CircuitBreaker_Discovery(t *testing.T) {
+	var (
+		s1 = g.Server(guid.S())
+		s2 = g.Server(guid.S())
+	)
+	s1.BindHandler("/", func(r *ghttp.Request) {
+		r.Response.WriteStatus(http.StatusInternalServerError)
+	})
+	s2.BindHandler("/", func(r *ghttp.Request) {
+		r.Response.Write("s2")
+	})
+	for _, s := range []*ghttp.Server{s1, s2} {
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			name      = guid.S()
+			address1  = fmt.Sprintf("127.0.0.1:%d", s1.GetListenedPort())
+			address2  = fmt.Sprintf("127.0.0.1:%d", s2.GetListenedPort())
+			discovery = &testDiscovery{service: &gsvc.LocalService{
+				Name: name,
+				Endpoints: gsvc.Endpoints{
+					gsvc.NewEndpoint(address1),
+					gsvc.NewEndpoint(address2),
+				},
+			}}
+			breaker = gclient.NewCircuitBreaker(gclient.CircuitBreakerConfig{
+				FailureThreshold: 1,
+				OpenTimeout:      time.Minute,
+			})
+			client = g.Client()
+		)
+		client.SetDiscovery(discovery)
+		client.SetCircuitBreaker(breaker)
+		// The first node fails and its circuit opens.
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(ctx, "http://"+name+"/")
+			t.AssertNil(err)
+			t.AssertNil(resp.Close())
+		}
+		t.Assert(breaker.State(address1), gclient.CircuitStateOpen)
+		t.Assert(breaker.State(address2), gclient.CircuitStateClosed)
+
+		// The open node is skipped.
+		for i := 0; i < 4; i++ {
+			t.Assert(client.GetContent(ctx, "http://"+name+"/"), "s2")
+		}
+	})
+}
@@ -67,6 +67,8 @@ func MiddlewareHandlerResponse(r *Request) {
 				code = gcode.CodeNotFound
 			case http.StatusForbidden:
 				code = gcode.CodeNotAuthorized
+			case http.StatusTooManyRequests:
+				code = gcode.CodeTooManyRequests
 			default:
 				code = gcode.CodeUnknown
 			}
@@ -0,0 +1,80 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"github.com/gogf/gf/v2/i18n/gi18n"
+)
+
+const (
+	HeaderAcceptLanguage  = "Accept-Language"  // Request header for preferred languages of client.
+	HeaderContentLanguage = "Content-Language" // Response header for negotiated language.
+)
+
+const (
+	defaultI18nQueryName  = "lang"
+	defaultI18nCookieName = "lang"
+)
+
+// I18nConfig is the configuration for i18n language negotiation middleware.
+type I18nConfig struct {
+	Manager               *gi18n.Manager // I18n manager providing loaded languages, gi18n.Instance() in default.
+	QueryName             string         // Query parameter name for language, which is "lang" in default.
+	CookieName            string         // Cookie name for language, which is "lang" in default.
+	DisableQuery          bool           // DisableQuery disables negotiating language from query parameter.
+	DisableCookie         bool           // DisableCookie disables negotiating language from cookie.
+	DisableAcceptLanguage bool           // DisableAcceptLanguage disables negotiating language from header "Accept-Language".
+	DisableHeader         bool           // DisableHeader disables outputting "Content-Language" response header.
+}
+
+// MiddlewareI18n returns a middleware handler negotiating language for request with given `config`.
+//
+// The language is negotiated in order of query parameter, cookie and header "Accept-Language",
+// and the first one matching any language loaded in the i18n manager is used, see gi18n.Manager.MatchLanguage.
+// The negotiated language is set into request context using gi18n.WithLanguage, so that the handlers,
+// templates and validations can translate contents without specifying language manually.
+// The default language of manager is used if no language matched.
+func MiddlewareI18n(config I18nConfig) HandlerFunc {
+	if config.Manager == nil {
+		config.Manager = gi18n.Instance()
+	}
+	if config.QueryName == "" {
+		config.QueryName = defaultI18nQueryName
+	}
+	if config.CookieName == "" {
+		config.CookieName = defaultI18nCookieName
+	}
+	return func(r *Request) {
+		var (
+			ctx      = r.Context()
+			language string
+		)
+		if !config.DisableQuery {
+			if v := r.GetQuery(config.QueryName).String(); v != "" {
+				language = config.Manager.MatchLanguage(ctx, v)
+			}
+		}
+		if language == "" && !config.DisableCookie {
+			if v := r.Cookie.Get(config.CookieName).String(); v != "" {
+				language = config.Manager.MatchLanguage(ctx, v)
+			}
+		}
+		if language == "" && !config.DisableAcceptLanguage {
+			if v := r.Header.Get(HeaderAcceptLanguage); v != "" {
+				language = config.Manager.MatchLanguage(ctx, gi18n.ParseAcceptLanguage(v)...)
+			}
+			r.Response.Header().Add("Vary", HeaderAcceptLanguage)
+		}
+		if language != "" {
+			r.SetCtx(gi18n.WithLanguage(ctx, language))
+			if !config.DisableHeader {
+				r.Response.Header().Set(HeaderContentLanguage, language)
+			}
+		}
+		r.Middleware.Next()
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net/http"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/empty"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/net/goai"
+	"github.com/gogf/gf/v2/util/gmode"
+)
+
+// OpenApiValidateConfig is the configuration for OpenApi validation middleware.
+type OpenApiValidateConfig struct {
+	// Fail responds the drift as error instead of logging it only.
+	// The request drift is responded with http status 400, and the response drift is responded
+	// with http status 500, both in DefaultHandlerResponse json of code gcode.CodeValidationFailed.
+	Fail bool
+
+	// StrictStatus validates the error status codes(>= 400) as well, which are not commonly documented.
+	StrictStatus bool
+
+	// DisableRequest disables validating the request json body.
+	DisableRequest bool
+
+	// Enabled forces enabling validation in any mode. The validation is only enabled in
+	// develop and testing mode of package gmode in default, as it costs much.
+	Enabled bool
+}
+
+// MiddlewareOpenApiValidate returns a middleware handler validating requests and responses against
+// the OpenApi specification generated by server, so that the documentation never silently diverges
+// from behaviors of handlers. It validates the request json body, the response status code and
+// the response json body of strict routes, see goai.OpenApiV3.ValidateRequest and ValidateResponse.
+//
+// It only works in develop and testing mode of package gmode unless `config.Enabled` is true,
+// and the OpenApiPath should be configured for server to generate the specification.
+// It should be used before MiddlewareHandlerResponse, so that it validates the final response content.
+// The response content is validated only if handler returns no error, as the errors are not commonly documented.
+//
+// Note that the response content is validated as it is only if the common response structure is configured
+// by goai.Config.CommonResponse, which documents the content wrapped by MiddlewareHandlerResponse.
+// If it is not configured, the specification documents the handler response object only, so the handler
+// response object is validated instead of the response content, and the content written by handler is not
+// validated if it returns no response object.
+func MiddlewareOpenApiValidate(config OpenApiValidateConfig) HandlerFunc {
+	return func(r *Request) {
+		if !config.Enabled && !gmode.IsDevelop() && !gmode.IsTesting() {
+			r.Middleware.Next()
+			return
+		}
+		var (
+			ctx     = r.Context()
+			handler = r.GetServeHandler()
+		)
+		if handler == nil || handler.Handler.Router == nil || !handler.Handler.Info.IsStrictRoute {
+			r.Middleware.Next()
+			return
+		}
+		var (
+			oai  = r.Server.GetOpenApi()
+			path = handler.Handler.Router.Uri
+		)
+		if oai.GetOperation(path, r.Method) == nil {
+			r.Middleware.Next()
+			return
+		}
+		// Request.
+		if !config.DisableRequest {
+			err := oai.ValidateRequest(goai.ValidateInput{
+				Path:        path,
+				Method:      r.Method,
+				ContentType: r.Header.Get("Content-Type"),
+				Body:        r.GetBody(),
+			})
+			if err != nil {
+				r.Server.Logger().Warningf(ctx, `openapi request drift "%s %s": %s`, r.Method, path, err.Error())
+				if config.Fail {
+					r.Response.WriteHeader(http.StatusBadRequest)
+					writeOpenApiDriftError(r, err)
+					return
+				}
+			}
+		}
+
+		r.Middleware.Next()
+
+		// Response.
+		var (
+			status = r.Response.Status
+			body   = r.Response.Buffer()
+		)
+		if status == 0 {
+			status = http.StatusOK
+			if r.GetError() != nil && r.Response.BufferLength() == 0 {
+				status = http.StatusInternalServerError
+			}
+		}
+		if status >= http.StatusBadRequest && !config.StrictStatus {
+			return
+		}
+		var contentType = r.Response.Header().Get("Content-Type")
+		switch {
+		case r.GetError() != nil:
+			// The error response content is not validated, as errors are not commonly documented.
+			body = nil
+
+		case oai.Config.CommonResponse == nil:
+			// The specification documents the handler response object only.
+			body = nil
+			contentType = "application/json"
+			if res := r.GetHandlerResponse(); !empty.IsNil(res) {
+				// The marshaling error leaves body empty, which is not validated.
+				body, _ = json.Marshal(res)
+			}
+		}
+		err := oai.ValidateResponse(goai.ValidateInput{
+			Path:        path,
+			Method:      r.Method,
+			Status:      status,
+			ContentType: contentType,
+			Body:        body,
+		})
+		if err != nil {
+			r.Server.Logger().Warningf(ctx, `openapi response drift "%s %s": %s`, r.Method, path, err.Error())
+			if config.Fail {
+				r.SetError(err)
+				r.Response.ClearBuffer()
+				r.Response.WriteHeader(http.StatusInternalServerError)
+				writeOpenApiDriftError(r, err)
+			}
+		}
+	}
+}
+
+// writeOpenApiDriftError writes the drift error `err` as DefaultHandlerResponse json.
+func writeOpenApiDriftError(r *Request, err error) {
+	r.Response.WriteJson(DefaultHandlerResponse{
+		Code:    gcode.CodeValidationFailed.Code(),
+		Message: gerror.Current(err).Error(),
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// RateLimitAlgorithm is the algorithm for rate limiting.
+type RateLimitAlgorithm string
+
+const (
+	// RateLimitTokenBucket limits requests using token bucket algorithm,
+	// which allows burst requests up to the limit and refills tokens evenly in the period.
+	RateLimitTokenBucket RateLimitAlgorithm = "token-bucket"
+
+	// RateLimitSlidingWindow limits requests using sliding window counter algorithm,
+	// which approximates the request count in the latest period using current and previous windows.
+	RateLimitSlidingWindow RateLimitAlgorithm = "sliding-window"
+)
+
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"     // Response header for the request quota in the period.
+	HeaderRateLimitRemaining = "RateLimit-Remaining" // Response header for the remaining quota in current period.
+	HeaderRateLimitReset     = "RateLimit-Reset"     // Response header for the seconds until the quota resets.
+	HeaderRetryAfter         = "Retry-After"         // Response header for the seconds to wait before retrying.
+)
+
+const (
+	defaultRateLimitPrefix = "ghttp:ratelimit:"
+)
+
+// RateLimitKeyFunc returns the rate limiting key for request.
+// The request is not limited if it returns empty string.
+type RateLimitKeyFunc func(r *Request) string
+
+// RateLimitConfig is the configuration for rate limiting middleware.
+type RateLimitConfig struct {
+	Algorithm     RateLimitAlgorithm // Rate limiting algorithm, RateLimitTokenBucket in default.
+	Limit         int                // Maximum request count in Period, which is also the burst size for token bucket.
+	Period        time.Duration      // Time period for Limit, one second in default.
+	KeyFunc       RateLimitKeyFunc   // Key function for rate limiting, RateLimitKeyByIP in default.
+	Store         RateLimitStore     // Store for rate limiting state, a new RateLimitMemoryStore in default.
+	Prefix        string             // Key prefix in Store, which is "ghttp:ratelimit:" in default.
+	DisableHeader bool               // DisableHeader disables outputting RateLimit-* response headers.
+}
+
+// RateLimitKeyByIP is a RateLimitKeyFunc that limits requests by client ip.
+func RateLimitKeyByIP(r *Request) string {
+	return r.GetClientIp()
+}
+
+// RateLimitKeyByRoute is a RateLimitKeyFunc that limits requests by matched route and client ip.
+func RateLimitKeyByRoute(r *Request) string {
+	var route = r.URL.Path
+	if handler := r.GetServeHandler(); handler != nil && handler.Handler.Router != nil {
+		route = handler.Handler.Router.Uri
+	}
+	return r.Method + ":" + route + ":" + r.GetClientIp()
+}
+
+// RateLimitKeyByHeader returns a RateLimitKeyFunc that limits requests by value of header `name`,
+// like "X-Api-Key". The request is not limited if the header is absent.
+func RateLimitKeyByHeader(name string) RateLimitKeyFunc {
+	return func(r *Request) string {
+		return r.Header.Get(name)
+	}
+}
+
+// MiddlewareRateLimit returns a middleware handler limiting request rate with given `config`.
+//
+// The limited request is responded with http status 429 and an error of code gcode.CodeTooManyRequests,
+// which is output as common response by MiddlewareHandlerResponse if it is used.
+// Note that the request is not limited if any error occurs in Store.
+func MiddlewareRateLimit(config RateLimitConfig) HandlerFunc {
+	if config.Limit <= 0 {
+		panic(gerror.NewCodef(gcode.CodeInvalidParameter, `invalid rate limit "%d"`, config.Limit))
+	}
+	if config.Algorithm == "" {
+		config.Algorithm = RateLimitTokenBucket
+	}
+	if config.Period <= 0 {
+		config.Period = time.Second
+	}
+	if err := checkRateLimitPeriod(config.Period); err != nil {
+		panic(err)
+	}
+	if config.KeyFunc == nil {
+		config.KeyFunc = RateLimitKeyByIP
+	}
+	if config.Store == nil {
+		config.Store = NewRateLimitMemoryStore()
+	}
+	if config.Prefix == "" {
+		config.Prefix = defaultRateLimitPrefix
+	}
+	return func(r *Request) {
+		key := config.KeyFunc(r)
+		if key == "" {
+			r.Middleware.Next()
+			return
+		}
+		result, err := config.Store.Allow(
+			r.Context(), config.Prefix+key, config.Algorithm, config.Limit, config.Period,
+		)
+		if err != nil {
+			r.Server.Logger().Errorf(r.Context(), `rate limit store error: %+v`, err)
+			r.Middleware.Next()
+			return
+		}
+		if !config.DisableHeader {
+			header := r.Response.Header()
+			header.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+			header.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+			header.Set(HeaderRateLimitReset, formatRateLimitSeconds(result.Reset))
+		}
+		if result.Allowed {
+			r.Middleware.Next()
+			return
+		}
+		r.Response.Header().Set(HeaderRetryAfter, formatRateLimitSeconds(result.RetryAfter))
+		r.Response.WriteHeader(http.StatusTooManyRequests)
+		r.SetError(gerror.NewCode(gcode.CodeTooManyRequests, http.StatusText(http.StatusTooManyRequests)))
+	}
+}
+
+// formatRateLimitSeconds formats duration `d` to seconds string rounded up.
+func formatRateLimitSeconds(d time.Duration) string {
+	if d <= 0 {
+		return "0"
+	}
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
@@ -0,0 +1,282 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gcache"
+)
+
+// RateLimitStore is the interface for rate limiting state storage.
+type RateLimitStore interface {
+	// Allow consumes one request quota for `key` using `algorithm`,
+	// which allows at most `limit` requests in `period`.
+	Allow(
+		ctx context.Context, key string, algorithm RateLimitAlgorithm, limit int, period time.Duration,
+	) (result RateLimitResult, err error)
+}
+
+// RateLimitResult is the result of RateLimitStore.Allow.
+type RateLimitResult struct {
+	Allowed    bool          // Whether the request is allowed.
+	Limit      int           // Maximum request count in period.
+	Remaining  int           // Remaining request count in current period.
+	Reset      time.Duration // Duration until the quota fully resets.
+	RetryAfter time.Duration // Duration to wait before retrying, only available if not allowed.
+}
+
+// RateLimitMemoryStore implements RateLimitStore using memory, which is for single process usage.
+type RateLimitMemoryStore struct {
+	mu    sync.Mutex    // Mutex for atomic state updating.
+	cache *gcache.Cache // Expiring states cache, the value is *rateLimitMemoryState.
+}
+
+// RateLimitRedisStore implements RateLimitStore using redis, which is for cluster usage.
+type RateLimitRedisStore struct {
+	redis *gredis.Redis // Redis client for rate limiting state.
+}
+
+// rateLimitMemoryState is the rate limiting state for a key in memory.
+type rateLimitMemoryState struct {
+	Tokens      float64 // Remaining tokens for token bucket.
+	Last        int64   // Last refilling time in milliseconds for token bucket.
+	WindowStart int64   // Current window start time in milliseconds for sliding window.
+	Previous    int64   // Request count of previous window for sliding window.
+	Current     int64   // Request count of current window for sliding window.
+}
+
+// NewRateLimitMemoryStore creates and returns a memory store for rate limiting.
+func NewRateLimitMemoryStore() *RateLimitMemoryStore {
+	return &RateLimitMemoryStore{
+		cache: gcache.New(),
+	}
+}
+
+// NewRateLimitRedisStore creates and returns a redis store for rate limiting.
+func NewRateLimitRedisStore(redis *gredis.Redis) *RateLimitRedisStore {
+	if redis == nil {
+		panic("redis instance for rate limit store cannot be empty")
+	}
+	return &RateLimitRedisStore{
+		redis: redis,
+	}
+}
+
+// Allow consumes one request quota for `key`.
+func (s *RateLimitMemoryStore) Allow(
+	ctx context.Context, key string, algorithm RateLimitAlgorithm, limit int, period time.Duration,
+) (result RateLimitResult, err error) {
+	if err = checkRateLimitPeriod(period); err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var (
+		now      = time.Now().UnixMilli()
+		periodMs = period.Milliseconds()
+		state    *rateLimitMemoryState
+	)
+	v, err := s.cache.Get(ctx, key)
+	if err != nil {
+		return result, err
+	}
+	if !v.IsNil() {
+		state = v.Val().(*rateLimitMemoryState)
+	}
+	switch algorithm {
+	case RateLimitTokenBucket:
+		if state == nil {
+			state = &rateLimitMemoryState{Tokens: float64(limit), Last: now}
+		}
+		elapsed := max(now-state.Last, 0)
+		state.Tokens = math.Min(float64(limit), state.Tokens+float64(elapsed)*float64(limit)/float64(periodMs))
+		state.Last = now
+		allowed := state.Tokens >= 1
+		if allowed {
+			state.Tokens--
+		}
+		result = newRateLimitTokenBucketResult(allowed, state.Tokens, limit, period)
+
+	case RateLimitSlidingWindow:
+		windowStart := now - now%periodMs
+		if state == nil {
+			state = &rateLimitMemoryState{WindowStart: windowStart}
+		}
+		switch state.WindowStart {
+		case windowStart:
+		case windowStart - periodMs:
+			state.Previous, state.Current = state.Current, 0
+		default:
+			state.Previous, state.Current = 0, 0
+		}
+		state.WindowStart = windowStart
+		var (
+			elapsed  = now - windowStart
+			estimate = float64(state.Previous)*float64(periodMs-elapsed)/float64(periodMs) + float64(state.Current)
+			allowed  = estimate+1 <= float64(limit)
+		)
+		if allowed {
+			state.Current++
+			estimate++
+		}
+		result = newRateLimitSlidingWindowResult(allowed, estimate, elapsed, limit, period)
+
+	default:
+		return result, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid rate limit algorithm "%s"`, algorithm)
+	}
+	// The state expires after two periods without any request, which is enough for both algorithms.
+	err = s.cache.Set(ctx, key, state, 2*period)
+	return
+}
+
+// rateLimitTokenBucketScript is the lua script for token bucket algorithm.
+// It returns the allowed flag and the remaining tokens as string, as float number is truncated by redis.
+const rateLimitTokenBucketScript = `
+local limit  = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local now    = tonumber(ARGV[3])
+local data   = redis.call('HMGET', KEYS[1], 'tokens', 'last')
+local tokens = tonumber(data[1])
+local last   = tonumber(data[2])
+if tokens == nil or last == nil then
+	tokens = limit
+	last   = now
+end
+tokens = math.min(limit, tokens + math.max(now - last, 0) * limit / period)
+local allowed = 0
+if tokens >= 1 then
+	tokens  = tokens - 1
+	allowed = 1
+end
+redis.call('HMSET', KEYS[1], 'tokens', tostring(tokens), 'last', now)
+redis.call('PEXPIRE', KEYS[1], period * 2)
+return {allowed, tostring(tokens)}
+`
+
+// rateLimitSlidingWindowScript is the lua script for sliding window counter algorithm.
+// It returns the allowed flag, the estimated request count as string and the elapsed milliseconds in window.
+const rateLimitSlidingWindowScript = `
+local limit   = tonumber(ARGV[1])
+local period  = tonumber(ARGV[2])
+local now     = tonumber(ARGV[3])
+local start   = now - now % period
+local data    = redis.call('HMGET', KEYS[1], 'start', 'previous', 'current')
+local lastStart = tonumber(data[1])
+local previous  = tonumber(data[2]) or 0
+local current   = tonumber(data[3]) or 0
+if lastStart == start - period then
+	previous = current
+	current  = 0
+elseif lastStart ~= start then
+	previous = 0
+	current  = 0
+end
+local elapsed  = now - start
+local estimate = previous * (period - elapsed) / period + current
+local allowed  = 0
+if estimate + 1 <= limit then
+	current  = current + 1
+	estimate = estimate + 1
+	allowed  = 1
+end
+redis.call('HMSET', KEYS[1], 'start', start, 'previous', previous, 'current', current)
+redis.call('PEXPIRE', KEYS[1], period * 2)
+return {allowed, tostring(estimate), elapsed}
+`
+
+// Allow consumes one request quota for `key`.
+// Note that it uses the time of current node, so the time of nodes in cluster should be synchronized.
+func (s *RateLimitRedisStore) Allow(
+	ctx context.Context, key string, algorithm RateLimitAlgorithm, limit int, period time.Duration,
+) (result RateLimitResult, err error) {
+	if err = checkRateLimitPeriod(period); err != nil {
+		return
+	}
+	var (
+		script string
+		args   = []any{limit, period.Milliseconds(), time.Now().UnixMilli()}
+	)
+	switch algorithm {
+	case RateLimitTokenBucket:
+		script = rateLimitTokenBucketScript
+	case RateLimitSlidingWindow:
+		script = rateLimitSlidingWindowScript
+	default:
+		return result, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid rate limit algorithm "%s"`, algorithm)
+	}
+	v, err := s.redis.Eval(ctx, script, 1, []string{key}, args)
+	if err != nil {
+		return result, err
+	}
+	values := v.Vars()
+	if len(values) < 2 {
+		return result, gerror.NewCodef(gcode.CodeInternalError, `invalid rate limit script result: %v`, v)
+	}
+	allowed := values[0].Int() == 1
+	switch algorithm {
+	case RateLimitTokenBucket:
+		result = newRateLimitTokenBucketResult(allowed, values[1].Float64(), limit, period)
+	case RateLimitSlidingWindow:
+		var elapsed int64
+		if len(values) > 2 {
+			elapsed = values[2].Int64()
+		}
+		result = newRateLimitSlidingWindowResult(allowed, values[1].Float64(), elapsed, limit, period)
+	}
+	return
+}
+
+// newRateLimitTokenBucketResult creates result of token bucket algorithm using remaining `tokens`.
+func newRateLimitTokenBucketResult(allowed bool, tokens float64, limit int, period time.Duration) RateLimitResult {
+	var (
+		tokenDuration = float64(period) / float64(limit)
+		result        = RateLimitResult{
+			Allowed:   allowed,
+			Limit:     limit,
+			Remaining: int(math.Floor(tokens)),
+			Reset:     time.Duration((float64(limit) - tokens) * tokenDuration),
+		}
+	)
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) * tokenDuration)
+	}
+	return result
+}
+
+// newRateLimitSlidingWindowResult creates result of sliding window algorithm
+// using `estimate` request count and `elapsed` milliseconds in current window.
+func newRateLimitSlidingWindowResult(
+	allowed bool, estimate float64, elapsed int64, limit int, period time.Duration,
+) RateLimitResult {
+	var result = RateLimitResult{
+		Allowed:   allowed,
+		Limit:     limit,
+		Remaining: max(limit-int(math.Ceil(estimate)), 0),
+		Reset:     period - time.Duration(elapsed)*time.Millisecond,
+	}
+	if !allowed {
+		result.RetryAfter = result.Reset
+	}
+	return result
+}
+
+// checkRateLimitPeriod checks the `period` of rate limiting, which is calculated in milliseconds.
+func checkRateLimitPeriod(period time.Duration) error {
+	if period < time.Millisecond {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter, `invalid rate limit period "%s", which should be at least 1ms`, period,
+		)
+	}
+	return nil
+}
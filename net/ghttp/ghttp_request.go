@@ -61,6 +61,7 @@ type Request struct {
 	viewObject      *gview.View          // Custom template view engine object for this response.
 	viewParams      gview.Params         // Custom template view variables for this response.
 	originUrlPath   string               // Original URL path that passed from client.
+	sseWriter       *SSEWriter           // Event stream writer, which is created by SSE function.
 }
 
 // staticFile is the file struct for static file service.
@@ -100,6 +101,12 @@ func newRequest(s *Server, r *http.Request, w http.ResponseWriter) *Request {
 	if err != nil {
 		panic(err)
 	}
+	// Client fingerprint binding for session.
+	if s.config.SessionFingerprint.IsEnabled() {
+		if err = request.Session.SetFingerprint(r.UserAgent(), request.GetClientIp()); err != nil {
+			panic(err)
+		}
+	}
 	// Remove char '/' in the tail of URI.
 	if request.URL.Path != "/" {
 		for len(request.URL.Path) > 0 && request.URL.Path[len(request.URL.Path)-1] == '/' {
@@ -0,0 +1,199 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// HeaderLastEventID is the request header carrying the id of the last received event,
+	// which is sent by client when it reconnects the event stream.
+	HeaderLastEventID = "Last-Event-ID"
+)
+
+// SSEWriter writes Server-Sent Events to the client.
+// It is created by Request.SSE, and is closed automatically after the request handler done.
+//
+// All its functions are concurrent safe, but note that the response should not be written
+// by other ways after SSEWriter created.
+type SSEWriter struct {
+	mu            sync.Mutex    // Mutex for concurrent writing.
+	request       *Request      // According request.
+	closed        bool          // Whether the writer is closed.
+	closeChan     chan struct{} // Closed when the writer is closed, which stops heartbeat.
+	heartbeatOnce sync.Once     // Make sure heartbeat is started only once.
+}
+
+// SSE converts the response to an event stream and returns the SSEWriter for it.
+// It sets the event stream headers and sends them to the client immediately.
+// It returns the same SSEWriter if it is called multiple times for the same request.
+//
+// The client is considered disconnected if the request context is done,
+// in which case the writing functions of SSEWriter return error.
+func (r *Request) SSE() *SSEWriter {
+	if r.sseWriter != nil {
+		return r.sseWriter
+	}
+	r.sseWriter = &SSEWriter{
+		request:   r,
+		closeChan: make(chan struct{}),
+	}
+	header := r.Response.Header()
+	header.Set("Content-Type", contentTypeEventStream)
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// It disables the response buffering of nginx proxy.
+	header.Set("X-Accel-Buffering", "no")
+	r.Response.WriteHeader(http.StatusOK)
+	r.Response.Flush()
+	if flusher, ok := r.Response.RawWriter().(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return r.sseWriter
+}
+
+// LastEventID returns the id of the last event received by client,
+// which is sent by client in header "Last-Event-ID" when it reconnects.
+// It is used to resume the event stream from the last event.
+func (w *SSEWriter) LastEventID() string {
+	return w.request.Header.Get(HeaderLastEventID)
+}
+
+// Context returns the context of the request, which is done when the client disconnects.
+func (w *SSEWriter) Context() context.Context {
+	return w.request.Context()
+}
+
+// Done returns a channel that is closed when the client disconnects.
+func (w *SSEWriter) Done() <-chan struct{} {
+	return w.request.Context().Done()
+}
+
+// Send sends an event to the client.
+// The parameters `event` and `id` are optional, which are not sent if they are empty.
+// The parameter `data` is sent as it is if it is string or []byte, or else it is encoded as json.
+// The multiline data is sent in multiple "data" fields, which are joined with '\n' by client.
+func (w *SSEWriter) Send(event, id string, data any) error {
+	var content string
+	switch v := data.(type) {
+	case nil:
+	case string:
+		content = v
+	case []byte:
+		content = string(v)
+	default:
+		b, err := gjson.Marshal(v)
+		if err != nil {
+			return err
+		}
+		content = string(b)
+	}
+	var buffer bytes.Buffer
+	if id != "" {
+		buffer.WriteString("id: " + sseFieldValue(id) + "\n")
+	}
+	if event != "" {
+		buffer.WriteString("event: " + sseFieldValue(event) + "\n")
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		buffer.WriteString("data: " + line + "\n")
+	}
+	buffer.WriteString("\n")
+	return w.write(buffer.Bytes())
+}
+
+// SendData sends an event with only data to the client. See Send.
+func (w *SSEWriter) SendData(data any) error {
+	return w.Send("", "", data)
+}
+
+// SetRetry sends the reconnection time to the client,
+// which is the time client waits before reconnecting after the connection lost.
+func (w *SSEWriter) SetRetry(retry time.Duration) error {
+	return w.write([]byte("retry: " + strconv.FormatInt(retry.Milliseconds(), 10) + "\n\n"))
+}
+
+// Comment sends a comment to the client, which is ignored by client
+// and commonly used for keeping the connection alive.
+func (w *SSEWriter) Comment(comment string) error {
+	var buffer bytes.Buffer
+	for _, line := range strings.Split(comment, "\n") {
+		buffer.WriteString(": " + line + "\n")
+	}
+	buffer.WriteString("\n")
+	return w.write(buffer.Bytes())
+}
+
+// Heartbeat starts sending heartbeat comments to the client every `interval` in background,
+// which keeps the connection alive through proxies. It stops when the writer is closed
+// or the client disconnects. It takes effect only once for the same writer.
+func (w *SSEWriter) Heartbeat(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	w.heartbeatOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-w.closeChan:
+					return
+				case <-w.Done():
+					return
+				case <-ticker.C:
+					if err := w.Comment("heartbeat"); err != nil {
+						return
+					}
+				}
+			}
+		}()
+	})
+}
+
+// Close closes the writer and stops the heartbeat.
+// The writing functions return error after the writer is closed.
+func (w *SSEWriter) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.closed {
+		w.closed = true
+		close(w.closeChan)
+	}
+}
+
+// write writes `data` to the client and flushes it immediately.
+func (w *SSEWriter) write(data []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `event stream writer is closed`)
+	}
+	if err := w.request.Context().Err(); err != nil {
+		return gerror.WrapCode(gcode.CodeInvalidOperation, err, `client disconnected`)
+	}
+	w.request.Response.Write(data)
+	w.request.Response.Flush()
+	return nil
+}
+
+// sseFieldValue removes the line breaks from field value,
+// as the line breaks are not allowed in field value except data.
+func sseFieldValue(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
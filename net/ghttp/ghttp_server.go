@@ -173,6 +173,9 @@ func (s *Server) Start() error {
 		s.config.SessionMaxAge,
 		s.config.SessionStorage,
 	)
+	s.sessionManager.SetAbsoluteTTL(s.config.SessionAbsoluteMaxAge)
+	s.sessionManager.SetFingerprint(s.config.SessionFingerprint)
+	s.sessionManager.SetRotateKeys(s.config.SessionRotateKeys...)
 
 	// PProf feature.
 	if s.config.PProfEnabled {
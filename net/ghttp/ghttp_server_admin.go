@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/glog"
 	"github.com/gogf/gf/v2/os/gproc"
 	"github.com/gogf/gf/v2/os/gtimer"
 	"github.com/gogf/gf/v2/os/gview"
@@ -108,5 +109,7 @@ func (s *Server) Shutdown() error {
 		v.Shutdown(ctx)
 	}
 	s.Logger().Infof(ctx, "pid[%d]: all servers shutdown", gproc.Pid())
+	// Flush the async buffered logging content of all loggers before exiting.
+	glog.FlushAll()
 	return nil
 }
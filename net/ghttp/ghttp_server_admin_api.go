@@ -0,0 +1,287 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"database/sql"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/debug/gdebug"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gcron"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/os/grpool"
+)
+
+// utilAdminApi is the controller for administration JSON api.
+type utilAdminApi struct {
+	server *Server
+}
+
+// AdminRouteItem is the route information of administration api.
+type AdminRouteItem struct {
+	Server     string   `json:"server"`     // Server name.
+	Domain     string   `json:"domain"`     // Bound domain.
+	Type       string   `json:"type"`       // Route handler type.
+	Method     string   `json:"method"`     // Handler method name, eg: GET, POST.
+	Route      string   `json:"route"`      // Route URI, eg: /api/v1/user/{id}.
+	Handler    string   `json:"handler"`    // Handler name.
+	Source     string   `json:"source"`     // Registering source file `path:line`.
+	Middleware []string `json:"middleware"` // Middleware chain in executing order.
+}
+
+// AdminDatabaseItem is the database pool stats of administration api.
+type AdminDatabaseItem struct {
+	Group string      `json:"group"` // Configuration group name.
+	Node  string      `json:"node"`  // Node info, eg: mysql@127.0.0.1:3306/test.
+	Stats sql.DBStats `json:"stats"` // Connection pool stats.
+}
+
+// AdminCronItem is the cron entry information of administration api.
+type AdminCronItem struct {
+	Name         string           `json:"name"`         // Entry name.
+	Pattern      string           `json:"pattern"`      // Crontab pattern.
+	Status       int              `json:"status"`       // Entry status.
+	Singleton    bool             `json:"singleton"`    // Whether running in singleton mode.
+	Running      bool             `json:"running"`      // Whether the job is running.
+	RegisterTime time.Time        `json:"registerTime"` // Registered time.
+	NextTime     time.Time        `json:"nextTime"`     // Next scheduled run time.
+	LastRecord   *gcron.RunRecord `json:"lastRecord"`   // Record of the latest finished run.
+}
+
+// AdminLoggerItem is the logger level information of administration api.
+type AdminLoggerItem struct {
+	Name   string   `json:"name"`   // Logger name, which is "default" or "server".
+	Level  int      `json:"level"`  // Level value.
+	Levels []string `json:"levels"` // Enabled level names.
+}
+
+const (
+	defaultAdminApiPattern = "/debug/admin/api"
+	adminLoggerNameServer  = "server"
+)
+
+// adminLevelNames is the level names for logger level displaying, in ASC order.
+var adminLevelNames = []struct {
+	Level int
+	Name  string
+}{
+	{glog.LEVEL_DEBU, "DEBU"},
+	{glog.LEVEL_INFO, "INFO"},
+	{glog.LEVEL_NOTI, "NOTI"},
+	{glog.LEVEL_WARN, "WARN"},
+	{glog.LEVEL_ERRO, "ERRO"},
+	{glog.LEVEL_CRIT, "CRIT"},
+}
+
+// EnableAdminApi enables the administration JSON api for runtime introspection of the process.
+// The optional parameter `pattern` specifies the URI prefix for the api, which is "/debug/admin/api" in default.
+//
+// The api includes:
+// GET {pattern}/routes:   registered routes and their middleware chains.
+// GET {pattern}/database: pool stats of all databases.
+// GET {pattern}/cron:     entries of the default cron.
+// GET {pattern}/pool:     size and jobs of the default goroutine pool.
+// GET {pattern}/logger:   levels of the default logger and server logger.
+// PUT {pattern}/logger:   changes the level of logger with parameter `name` and `level`.
+//
+// Note that it exposes the internal information and allows changing the logger level,
+// so it should be protected using middleware or enabled only for internal network.
+func (s *Server) EnableAdminApi(pattern ...string) {
+	p := defaultAdminApiPattern
+	if len(pattern) > 0 && pattern[0] != "" {
+		p = pattern[0]
+	}
+	api := &utilAdminApi{server: s}
+	p = strings.TrimRight(p, "/")
+	s.Group(p, func(group *RouterGroup) {
+		group.GET("/routes", api.Routes)
+		group.GET("/database", api.Database)
+		group.GET("/cron", api.Cron)
+		group.GET("/pool", api.Pool)
+		group.GET("/logger", api.Logger)
+		group.Map(map[string]any{
+			"PUT:/logger":  api.SetLogger,
+			"POST:/logger": api.SetLogger,
+		})
+	})
+}
+
+// Routes responds the registered routes and their middleware chains.
+func (a *utilAdminApi) Routes(r *Request) {
+	var (
+		routes      = a.server.GetRoutes()
+		middlewares = make([]RouterItem, 0)
+		items       = make([]AdminRouteItem, 0, len(routes))
+	)
+	for _, route := range routes {
+		if route.Type == HandlerTypeMiddleware {
+			middlewares = append(middlewares, route)
+		}
+	}
+	// Global middlewares are executed in registering order.
+	sort.Slice(middlewares, func(i, j int) bool {
+		return middlewares[i].Handler.Id < middlewares[j].Handler.Id
+	})
+	for _, route := range routes {
+		if route.Type == HandlerTypeMiddleware {
+			continue
+		}
+		item := AdminRouteItem{
+			Server:     route.Server,
+			Domain:     route.Domain,
+			Type:       string(route.Type),
+			Method:     route.Method,
+			Route:      route.Route,
+			Handler:    route.Handler.Name,
+			Source:     route.Handler.Source,
+			Middleware: make([]string, 0),
+		}
+		if route.Type != HandlerTypeHook {
+			for _, middleware := range middlewares {
+				if middleware.Domain != route.Domain && middleware.Domain != DefaultDomainName {
+					continue
+				}
+				if middleware.Method != defaultMethod && middleware.Method != route.Method {
+					continue
+				}
+				if middleware.Handler.Router != nil {
+					if match, _ := regexp.MatchString(middleware.Handler.Router.RegRule, route.Route); !match {
+						continue
+					}
+				}
+				item.Middleware = append(item.Middleware, middleware.Handler.Name)
+			}
+			for _, middleware := range route.Handler.Middleware {
+				item.Middleware = append(item.Middleware, gdebug.FuncPath(middleware))
+			}
+		}
+		items = append(items, item)
+	}
+	a.writeData(r, items)
+}
+
+// Database responds the pool stats of all databases.
+func (a *utilAdminApi) Database(r *Request) {
+	var items = make([]AdminDatabaseItem, 0)
+	for group, stats := range gdb.GetAllStats(r.Context()) {
+		for _, item := range stats {
+			node := item.Node()
+			items = append(items, AdminDatabaseItem{
+				Group: group,
+				Node:  node.Type + "@" + node.Host + ":" + node.Port + "/" + node.Name,
+				Stats: item.Stats(),
+			})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Group == items[j].Group {
+			return items[i].Node < items[j].Node
+		}
+		return items[i].Group < items[j].Group
+	})
+	a.writeData(r, items)
+}
+
+// Cron responds the entries of the default cron.
+func (a *utilAdminApi) Cron(r *Request) {
+	var (
+		entries = gcron.Entries()
+		items   = make([]AdminCronItem, 0, len(entries))
+	)
+	for _, entry := range entries {
+		items = append(items, AdminCronItem{
+			Name:         entry.Name,
+			Pattern:      entry.Pattern(),
+			Status:       entry.Status(),
+			Singleton:    entry.IsSingleton(),
+			Running:      entry.IsRunning(),
+			RegisterTime: entry.RegisterTime,
+			NextTime:     entry.Next(),
+			LastRecord:   entry.LastRecord(),
+		})
+	}
+	a.writeData(r, items)
+}
+
+// Pool responds the size and jobs of the default goroutine pool.
+func (a *utilAdminApi) Pool(r *Request) {
+	a.writeData(r, map[string]int{
+		"size": grpool.Size(),
+		"jobs": grpool.Jobs(),
+	})
+}
+
+// Logger responds the levels of the default logger and server logger.
+func (a *utilAdminApi) Logger(r *Request) {
+	a.writeData(r, []AdminLoggerItem{
+		a.newLoggerItem(glog.DefaultName, glog.DefaultLogger()),
+		a.newLoggerItem(adminLoggerNameServer, a.server.Logger()),
+	})
+}
+
+// SetLogger changes the level of logger with parameter `name` and `level`.
+// The parameter `name` is "default" or "server", which is "default" if not given.
+// The parameter `level` is level string, eg: all, dev, prod, info, error.
+func (a *utilAdminApi) SetLogger(r *Request) {
+	var (
+		name   = r.Get("name", glog.DefaultName).String()
+		level  = r.Get("level").String()
+		logger *glog.Logger
+	)
+	switch name {
+	case glog.DefaultName:
+		logger = glog.DefaultLogger()
+	case adminLoggerNameServer:
+		logger = a.server.Logger()
+	default:
+		a.writeError(r, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid logger name "%s"`, name))
+		return
+	}
+	if err := logger.SetLevelStr(level); err != nil {
+		a.writeError(r, gerror.WrapCode(gcode.CodeInvalidParameter, err))
+		return
+	}
+	a.writeData(r, a.newLoggerItem(name, logger))
+}
+
+func (a *utilAdminApi) newLoggerItem(name string, logger *glog.Logger) AdminLoggerItem {
+	var item = AdminLoggerItem{
+		Name:   name,
+		Level:  logger.GetLevel(),
+		Levels: make([]string, 0),
+	}
+	for _, v := range adminLevelNames {
+		if item.Level&v.Level > 0 {
+			item.Levels = append(item.Levels, v.Name)
+		}
+	}
+	return item
+}
+
+func (a *utilAdminApi) writeData(r *Request, data any) {
+	r.Response.WriteJson(DefaultHandlerResponse{
+		Code:    gcode.CodeOK.Code(),
+		Message: gcode.CodeOK.Message(),
+		Data:    data,
+	})
+}
+
+func (a *utilAdminApi) writeError(r *Request, err error) {
+	code := gerror.Code(err)
+	r.Response.WriteHeader(http.StatusBadRequest)
+	r.Response.WriteJson(DefaultHandlerResponse{
+		Code:    code.Code(),
+		Message: err.Error(),
+	})
+}
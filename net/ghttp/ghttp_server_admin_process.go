@@ -272,6 +272,8 @@ func shutdownWebServersGracefully(ctx context.Context, signal os.Signal) {
 			}
 		}
 	})
+	// Flush the async buffered logging content of all loggers before exiting.
+	glog.FlushAll()
 }
 
 // forceCloseWebServers forced shuts down all servers.
@@ -184,9 +184,21 @@ type ServerConfig struct {
 	// SessionIdName specifies the session id name.
 	SessionIdName string `json:"sessionIdName"`
 
-	// SessionMaxAge specifies max TTL for session items.
+	// SessionMaxAge specifies max TTL for session items, which is the idle timeout refreshed on each access.
 	SessionMaxAge time.Duration `json:"sessionMaxAge"`
 
+	// SessionAbsoluteMaxAge specifies the absolute lifetime for sessions since they are created,
+	// which is not refreshed on access. It is disabled if it is 0.
+	SessionAbsoluteMaxAge time.Duration `json:"sessionAbsoluteMaxAge"`
+
+	// SessionFingerprint specifies the client fingerprint binding for sessions,
+	// the session is invalidated if the fingerprint of request does not match.
+	SessionFingerprint gsession.FingerprintConfig `json:"sessionFingerprint"`
+
+	// SessionRotateKeys specifies the privilege keys of sessions,
+	// the session id is regenerated if any of these keys is changed.
+	SessionRotateKeys []string `json:"sessionRotateKeys"`
+
 	// SessionPath specifies the session storage directory path for storing session files.
 	// It only makes sense if the session storage is type of file storage.
 	SessionPath string `json:"sessionPath"`
@@ -227,6 +239,7 @@ type ServerConfig struct {
 	// ======================================================================================================
 
 	OpenApiPath       string `json:"openapiPath"`       // OpenApiPath specifies the OpenApi specification file path.
+	OpenApiVersion    string `json:"openapiVersion"`    // OpenApiVersion specifies the OpenApi specification version, like "3.0.0" and "3.1.0".
 	SwaggerPath       string `json:"swaggerPath"`       // SwaggerPath specifies the swagger UI path for route registering.
 	SwaggerUITemplate string `json:"swaggerUITemplate"` // SwaggerUITemplate specifies the swagger UI custom template
 
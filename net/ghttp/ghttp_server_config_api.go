@@ -26,3 +26,14 @@ func (s *Server) SetOpenApiPath(path string) {
 func (s *Server) GetOpenApiPath() string {
 	return s.config.OpenApiPath
 }
+
+// SetOpenApiVersion sets the OpenApiVersion for server, which is goai.OpenAPIVersion30 in default.
+// For example: SetOpenApiVersion(goai.OpenAPIVersion31)
+func (s *Server) SetOpenApiVersion(version string) {
+	s.config.OpenApiVersion = version
+}
+
+// GetOpenApiVersion returns the `OpenApiVersion` configuration of the server.
+func (s *Server) GetOpenApiVersion() string {
+	return s.config.OpenApiVersion
+}
@@ -37,6 +37,21 @@ func (s *Server) SetSessionCookieMaxAge(maxAge time.Duration) {
 	s.config.SessionCookieMaxAge = maxAge
 }
 
+// SetSessionAbsoluteMaxAge sets the SessionAbsoluteMaxAge for server.
+func (s *Server) SetSessionAbsoluteMaxAge(ttl time.Duration) {
+	s.config.SessionAbsoluteMaxAge = ttl
+}
+
+// SetSessionFingerprint sets the SessionFingerprint for server.
+func (s *Server) SetSessionFingerprint(config gsession.FingerprintConfig) {
+	s.config.SessionFingerprint = config
+}
+
+// SetSessionRotateKeys sets the SessionRotateKeys for server.
+func (s *Server) SetSessionRotateKeys(keys ...string) {
+	s.config.SessionRotateKeys = keys
+}
+
 // GetSessionMaxAge returns the SessionMaxAge of server.
 func (s *Server) GetSessionMaxAge() time.Duration {
 	return s.config.SessionMaxAge
@@ -51,3 +66,15 @@ func (s *Server) GetSessionIdName() string {
 func (s *Server) GetSessionCookieMaxAge() time.Duration {
 	return s.config.SessionCookieMaxAge
 }
+
+// GetSessionAbsoluteMaxAge returns the SessionAbsoluteMaxAge of server.
+func (s *Server) GetSessionAbsoluteMaxAge() time.Duration {
+	return s.config.SessionAbsoluteMaxAge
+}
+
+// GetSessionManager returns the session manager of server, which can be used for managing
+// sessions of users, eg: revoking all sessions of a user.
+// Note that it returns nil before the server starts.
+func (s *Server) GetSessionManager() *gsession.Manager {
+	return s.sessionManager
+}
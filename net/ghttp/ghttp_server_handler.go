@@ -101,6 +101,11 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Event stream writer should not write anything after serving.
+	if request.sseWriter != nil {
+		request.sseWriter.Close()
+	}
+
 	// HOOK - AfterServe
 	if !request.IsExited() {
 		s.callHookHandler(HookAfterServe, request)
@@ -23,6 +23,10 @@ func (s *Server) initOpenApi() {
 		err     error
 		methods []string
 	)
+	// The version should be set before adding objects, as the schemas are generated in adding.
+	if s.config.OpenApiVersion != "" {
+		s.openapi.OpenAPI = s.config.OpenApiVersion
+	}
 	for _, item := range s.GetRoutes() {
 		switch item.Type {
 		case HandlerTypeMiddleware, HandlerTypeHook:
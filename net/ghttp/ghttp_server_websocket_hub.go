@@ -0,0 +1,603 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// WebSocketOverflowPolicy is the policy for message sending when the send buffer of client is full,
+// which usually happens for slow clients.
+type WebSocketOverflowPolicy string
+
+const (
+	// WebSocketOverflowClose closes the slow client if its send buffer is full.
+	WebSocketOverflowClose WebSocketOverflowPolicy = "close"
+
+	// WebSocketOverflowDrop drops the message for the slow client if its send buffer is full.
+	WebSocketOverflowDrop WebSocketOverflowPolicy = "drop"
+)
+
+const (
+	defaultWebSocketPingInterval   = 30 * time.Second
+	defaultWebSocketPongTimeout    = 60 * time.Second
+	defaultWebSocketWriteTimeout   = 10 * time.Second
+	defaultWebSocketSendBufferSize = 256
+	webSocketBackplaneRetryDelay   = time.Second
+)
+
+// WebSocketHubConfig is the configuration for WebSocketHub.
+type WebSocketHubConfig struct {
+	PingInterval   time.Duration           // Interval sending ping to clients, 30 seconds in default.
+	PongTimeout    time.Duration           // Client is closed if no pong or message received in this duration, 60 seconds in default.
+	WriteTimeout   time.Duration           // Timeout writing a message to client, 10 seconds in default.
+	SendBufferSize int                     // Buffered message count for each client, 256 in default.
+	MaxMessageSize int64                   // Max size in bytes of message read from client, no limit if it is 0.
+	OverflowPolicy WebSocketOverflowPolicy // Policy when the send buffer of client is full, WebSocketOverflowClose in default.
+	Backplane      WebSocketBackplane      // Backplane for delivering messages to clients of other instances, it is optional.
+
+	// OnConnect is called after the client is registered and before its messages are read.
+	OnConnect func(client *WebSocketClient)
+
+	// OnMessage is called for each text or binary message received from client in sequence.
+	OnMessage func(client *WebSocketClient, messageType int, data []byte)
+
+	// OnClose is called after the client is closed and unregistered.
+	OnClose func(client *WebSocketClient)
+}
+
+// WebSocketHub manages websocket clients with user and room membership,
+// which supports broadcast and targeted sending across instances using backplane.
+type WebSocketHub struct {
+	config     WebSocketHubConfig
+	instanceId string                                 // Unique id of the hub, which is used for ignoring messages published by itself.
+	mu         sync.RWMutex                           // Mutex for users and rooms.
+	clients    *gmap.StrAnyMap                        // Registered clients, id -> *WebSocketClient.
+	users      map[string]map[string]*WebSocketClient // User id -> client id -> client.
+	rooms      map[string]map[string]*WebSocketClient // Room -> client id -> client.
+	closed     *gtype.Bool                            // Whether the hub is closed.
+	cancel     context.CancelFunc                     // Cancels the backplane subscription.
+}
+
+// WebSocketClient is a websocket connection registered in WebSocketHub.
+type WebSocketClient struct {
+	id        string                // Unique id of client.
+	userId    string                // User id of client, which can be empty.
+	hub       *WebSocketHub         // Belonged hub.
+	conn      *websocket.Conn       // Underlying websocket connection.
+	request   *Request              // Upgraded request.
+	send      chan webSocketMessage // Buffered messages waiting for writing.
+	rooms     map[string]struct{}   // Joined rooms, which is guarded by hub.mu.
+	metadata  *gmap.StrAnyMap       // Custom metadata of client.
+	closed    *gtype.Bool           // Whether the client is closed.
+	closeChan chan struct{}         // Closed when the client is closed.
+	closeOnce sync.Once             // Ensures the closing is done only once.
+}
+
+// webSocketMessage is a message waiting for writing to client.
+type webSocketMessage struct {
+	Type int
+	Data []byte
+}
+
+// webSocketTarget is the target kind of message delivering.
+type webSocketTarget int
+
+const (
+	webSocketTargetAll webSocketTarget = iota
+	webSocketTargetUser
+	webSocketTargetRoom
+	webSocketTargetClient
+)
+
+// webSocketEnvelope is the message published to backplane.
+type webSocketEnvelope struct {
+	Instance string          `json:"instance"`
+	Target   webSocketTarget `json:"target"`
+	Key      string          `json:"key,omitempty"`
+	Type     int             `json:"type"`
+	Data     []byte          `json:"data"`
+}
+
+// NewWebSocketHub creates and returns a websocket hub with optional `config`.
+// It starts subscribing the backplane if it is configured, which stops after the hub is closed.
+func NewWebSocketHub(config ...WebSocketHubConfig) *WebSocketHub {
+	var c WebSocketHubConfig
+	if len(config) > 0 {
+		c = config[0]
+	}
+	if c.PingInterval <= 0 {
+		c.PingInterval = defaultWebSocketPingInterval
+	}
+	if c.PongTimeout <= 0 {
+		c.PongTimeout = defaultWebSocketPongTimeout
+	}
+	if c.WriteTimeout <= 0 {
+		c.WriteTimeout = defaultWebSocketWriteTimeout
+	}
+	if c.SendBufferSize <= 0 {
+		c.SendBufferSize = defaultWebSocketSendBufferSize
+	}
+	if c.OverflowPolicy == "" {
+		c.OverflowPolicy = WebSocketOverflowClose
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	h := &WebSocketHub{
+		config:     c,
+		instanceId: guid.S(),
+		clients:    gmap.NewStrAnyMap(true),
+		users:      make(map[string]map[string]*WebSocketClient),
+		rooms:      make(map[string]map[string]*WebSocketClient),
+		closed:     gtype.NewBool(),
+		cancel:     cancel,
+	}
+	if c.Backplane != nil {
+		go h.subscribeBackplane(ctx)
+	}
+	return h
+}
+
+// Serve upgrades the request `r` as websocket connection and registers it to the hub,
+// then it blocks reading messages from client until the client is closed.
+// The optional parameter `userId` specifies the user of the client for SendToUser.
+//
+// It is usually called in the handler of websocket route, eg:
+//
+//	s.BindHandler("/ws", func(r *ghttp.Request) {
+//		_ = hub.Serve(r, userId)
+//	})
+func (h *WebSocketHub) Serve(r *Request, userId ...string) error {
+	if h.closed.Val() {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `websocket hub is closed`)
+	}
+	conn, err := wsUpGrader.Upgrade(r.Response.Writer, r.Request, nil)
+	if err != nil {
+		return err
+	}
+	client := &WebSocketClient{
+		id:        guid.S(),
+		hub:       h,
+		conn:      conn,
+		request:   r,
+		send:      make(chan webSocketMessage, h.config.SendBufferSize),
+		rooms:     make(map[string]struct{}),
+		metadata:  gmap.NewStrAnyMap(true),
+		closed:    gtype.NewBool(),
+		closeChan: make(chan struct{}),
+	}
+	if len(userId) > 0 {
+		client.userId = userId[0]
+	}
+	h.register(client)
+	// The hub might be closed concurrently before the client registered.
+	if h.closed.Val() {
+		client.Close()
+		return nil
+	}
+	if h.config.OnConnect != nil {
+		h.config.OnConnect(client)
+	}
+	go client.writePump()
+	client.readPump()
+	return nil
+}
+
+// Broadcast sends message to all clients, including clients of other instances using backplane.
+func (h *WebSocketHub) Broadcast(ctx context.Context, messageType int, data []byte) error {
+	return h.dispatch(ctx, webSocketTargetAll, "", messageType, data)
+}
+
+// SendToUser sends message to all clients of user `userId`,
+// including clients of other instances using backplane.
+func (h *WebSocketHub) SendToUser(ctx context.Context, userId string, messageType int, data []byte) error {
+	return h.dispatch(ctx, webSocketTargetUser, userId, messageType, data)
+}
+
+// SendToRoom sends message to all clients in `room`,
+// including clients of other instances using backplane.
+func (h *WebSocketHub) SendToRoom(ctx context.Context, room string, messageType int, data []byte) error {
+	return h.dispatch(ctx, webSocketTargetRoom, room, messageType, data)
+}
+
+// SendToClient sends message to client of `clientId`.
+// It publishes the message to backplane if the client is not connected to current instance,
+// or else it returns error if no backplane configured.
+func (h *WebSocketHub) SendToClient(ctx context.Context, clientId string, messageType int, data []byte) error {
+	if client := h.GetClient(clientId); client != nil {
+		return client.Send(messageType, data)
+	}
+	if h.config.Backplane == nil {
+		return gerror.NewCodef(gcode.CodeNotFound, `websocket client "%s" not found`, clientId)
+	}
+	return h.publish(ctx, webSocketTargetClient, clientId, messageType, data)
+}
+
+// GetClient returns the client of `clientId` connected to current instance, or nil if it does not exist.
+func (h *WebSocketHub) GetClient(clientId string) *WebSocketClient {
+	if v := h.clients.Get(clientId); v != nil {
+		return v.(*WebSocketClient)
+	}
+	return nil
+}
+
+// GetClients returns all clients connected to current instance, which are sorted by their ids.
+func (h *WebSocketHub) GetClients() []*WebSocketClient {
+	var clients = make([]*WebSocketClient, 0, h.clients.Size())
+	h.clients.Iterator(func(_ string, v any) bool {
+		clients = append(clients, v.(*WebSocketClient))
+		return true
+	})
+	return sortWebSocketClients(clients)
+}
+
+// GetClientCount returns the count of clients connected to current instance.
+func (h *WebSocketHub) GetClientCount() int {
+	return h.clients.Size()
+}
+
+// GetUserClients returns the clients of user `userId` connected to current instance.
+func (h *WebSocketHub) GetUserClients(userId string) []*WebSocketClient {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return sortWebSocketClients(webSocketClientMapToSlice(h.users[userId]))
+}
+
+// GetRoomClients returns the clients in `room` connected to current instance.
+func (h *WebSocketHub) GetRoomClients(room string) []*WebSocketClient {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return sortWebSocketClients(webSocketClientMapToSlice(h.rooms[room]))
+}
+
+// Close closes all clients of current instance and stops the backplane subscription.
+// The hub cannot serve any more after it is closed.
+func (h *WebSocketHub) Close() {
+	if !h.closed.Cas(false, true) {
+		return
+	}
+	h.cancel()
+	for _, client := range h.GetClients() {
+		client.Close()
+	}
+}
+
+// dispatch delivers message to local clients and publishes it to backplane.
+func (h *WebSocketHub) dispatch(
+	ctx context.Context, target webSocketTarget, key string, messageType int, data []byte,
+) error {
+	h.deliver(target, key, messageType, data)
+	if h.config.Backplane == nil {
+		return nil
+	}
+	return h.publish(ctx, target, key, messageType, data)
+}
+
+// deliver sends message to matched clients of current instance.
+// The failure of a single client does not affect the others.
+func (h *WebSocketHub) deliver(target webSocketTarget, key string, messageType int, data []byte) {
+	var clients []*WebSocketClient
+	switch target {
+	case webSocketTargetAll:
+		clients = h.GetClients()
+	case webSocketTargetUser:
+		clients = h.GetUserClients(key)
+	case webSocketTargetRoom:
+		clients = h.GetRoomClients(key)
+	case webSocketTargetClient:
+		if client := h.GetClient(key); client != nil {
+			clients = append(clients, client)
+		}
+	}
+	for _, client := range clients {
+		_ = client.Send(messageType, data)
+	}
+}
+
+// publish publishes message to backplane.
+func (h *WebSocketHub) publish(
+	ctx context.Context, target webSocketTarget, key string, messageType int, data []byte,
+) error {
+	payload, err := json.Marshal(webSocketEnvelope{
+		Instance: h.instanceId,
+		Target:   target,
+		Key:      key,
+		Type:     messageType,
+		Data:     data,
+	})
+	if err != nil {
+		return err
+	}
+	return h.config.Backplane.Publish(ctx, payload)
+}
+
+// subscribeBackplane subscribes the backplane and delivers messages from other instances,
+// it re-subscribes after a delay if the subscription fails, until `ctx` is done.
+func (h *WebSocketHub) subscribeBackplane(ctx context.Context) {
+	for {
+		err := h.config.Backplane.Subscribe(ctx, h.handleBackplaneMessage)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			intlog.Errorf(ctx, `websocket hub subscribe backplane failed: %+v`, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(webSocketBackplaneRetryDelay):
+		}
+	}
+}
+
+// handleBackplaneMessage delivers message from backplane to clients of current instance.
+func (h *WebSocketHub) handleBackplaneMessage(payload []byte) {
+	var envelope webSocketEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		intlog.Errorf(context.Background(), `websocket hub invalid backplane message: %+v`, err)
+		return
+	}
+	if envelope.Instance == h.instanceId {
+		return
+	}
+	h.deliver(envelope.Target, envelope.Key, envelope.Type, envelope.Data)
+}
+
+// register adds `client` to the hub.
+func (h *WebSocketHub) register(client *WebSocketClient) {
+	h.clients.Set(client.id, client)
+	if client.userId == "" {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.users[client.userId] == nil {
+		h.users[client.userId] = make(map[string]*WebSocketClient)
+	}
+	h.users[client.userId][client.id] = client
+}
+
+// unregister removes `client` and its membership from the hub.
+func (h *WebSocketHub) unregister(client *WebSocketClient) {
+	h.clients.Remove(client.id)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if client.userId != "" {
+		deleteWebSocketMember(h.users, client.userId, client.id)
+	}
+	for room := range client.rooms {
+		deleteWebSocketMember(h.rooms, room, client.id)
+	}
+	client.rooms = make(map[string]struct{})
+}
+
+// Id returns the unique id of client.
+func (c *WebSocketClient) Id() string {
+	return c.id
+}
+
+// UserId returns the user id of client, which is specified in WebSocketHub.Serve.
+func (c *WebSocketClient) UserId() string {
+	return c.userId
+}
+
+// Request returns the request that is upgraded as the client.
+func (c *WebSocketClient) Request() *Request {
+	return c.request
+}
+
+// Conn returns the underlying websocket connection.
+// Note that the writing should be done using Send, as the connection supports only one concurrent writer.
+func (c *WebSocketClient) Conn() *websocket.Conn {
+	return c.conn
+}
+
+// Metadata returns the custom metadata map of client, which is concurrent-safe.
+func (c *WebSocketClient) Metadata() *gmap.StrAnyMap {
+	return c.metadata
+}
+
+// Join adds the client to `rooms`.
+func (c *WebSocketClient) Join(rooms ...string) {
+	c.hub.mu.Lock()
+	defer c.hub.mu.Unlock()
+	// It checks under the lock, as the closed client might be unregistered concurrently,
+	// which marks the client closed before unregistering using the same lock.
+	if c.closed.Val() {
+		return
+	}
+	for _, room := range rooms {
+		if c.hub.rooms[room] == nil {
+			c.hub.rooms[room] = make(map[string]*WebSocketClient)
+		}
+		c.hub.rooms[room][c.id] = c
+		c.rooms[room] = struct{}{}
+	}
+}
+
+// Leave removes the client from `rooms`.
+func (c *WebSocketClient) Leave(rooms ...string) {
+	c.hub.mu.Lock()
+	defer c.hub.mu.Unlock()
+	for _, room := range rooms {
+		deleteWebSocketMember(c.hub.rooms, room, c.id)
+		delete(c.rooms, room)
+	}
+}
+
+// Rooms returns the rooms that the client joined, which are sorted by names.
+func (c *WebSocketClient) Rooms() []string {
+	c.hub.mu.RLock()
+	defer c.hub.mu.RUnlock()
+	var rooms = make([]string, 0, len(c.rooms))
+	for room := range c.rooms {
+		rooms = append(rooms, room)
+	}
+	sort.Strings(rooms)
+	return rooms
+}
+
+// Send puts the message to the send buffer of client, which is written to connection asynchronously.
+// If the send buffer is full, the client is closed or the message is dropped according to
+// WebSocketHubConfig.OverflowPolicy, and it returns error.
+func (c *WebSocketClient) Send(messageType int, data []byte) (err error) {
+	if c.closed.Val() {
+		return gerror.NewCodef(gcode.CodeInvalidOperation, `websocket client "%s" is closed`, c.id)
+	}
+	// The send channel is never closed, so it is safe sending even if the client is closed concurrently.
+	select {
+	case c.send <- webSocketMessage{Type: messageType, Data: data}:
+		return nil
+	default:
+	}
+	if c.hub.config.OverflowPolicy == WebSocketOverflowClose {
+		c.Close()
+		return gerror.NewCodef(
+			gcode.CodeInvalidOperation, `websocket client "%s" is closed as its send buffer is full`, c.id,
+		)
+	}
+	return gerror.NewCodef(
+		gcode.CodeInvalidOperation, `websocket client "%s" message dropped as its send buffer is full`, c.id,
+	)
+}
+
+// SendText sends text message to client.
+func (c *WebSocketClient) SendText(text string) error {
+	return c.Send(WsMsgText, []byte(text))
+}
+
+// SendJSON sends `data` as JSON text message to client.
+func (c *WebSocketClient) SendJSON(data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return c.Send(WsMsgText, b)
+}
+
+// Close closes the client and unregisters it from hub, it is safe to be called multiple times.
+func (c *WebSocketClient) Close() {
+	c.closeOnce.Do(func() {
+		c.closed.Set(true)
+		close(c.closeChan)
+		c.hub.unregister(c)
+		// WriteControl is safe being called concurrently with other writing methods.
+		_ = c.conn.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+			time.Now().Add(c.hub.config.WriteTimeout),
+		)
+		_ = c.conn.Close()
+		if c.hub.config.OnClose != nil {
+			c.hub.config.OnClose(c)
+		}
+	})
+}
+
+// readPump reads messages from client until the connection fails or the client is closed.
+func (c *WebSocketClient) readPump() {
+	defer c.Close()
+	var (
+		config   = c.hub.config
+		deadline = func() error {
+			return c.conn.SetReadDeadline(time.Now().Add(config.PongTimeout))
+		}
+	)
+	if config.MaxMessageSize > 0 {
+		c.conn.SetReadLimit(config.MaxMessageSize)
+	}
+	_ = deadline()
+	c.conn.SetPongHandler(func(string) error {
+		return deadline()
+	})
+	for {
+		messageType, data, err := c.conn.ReadMessage()
+		if err != nil {
+			if !c.closed.Val() && websocket.IsUnexpectedCloseError(
+				err, websocket.CloseNormalClosure, websocket.CloseGoingAway, websocket.CloseNoStatusReceived,
+			) {
+				intlog.Errorf(c.request.Context(), `websocket client "%s" read failed: %+v`, c.id, err)
+			}
+			return
+		}
+		_ = deadline()
+		if config.OnMessage != nil {
+			config.OnMessage(c, messageType, data)
+		}
+	}
+}
+
+// writePump writes buffered messages and pings to client until the client is closed.
+func (c *WebSocketClient) writePump() {
+	var (
+		config = c.hub.config
+		ticker = time.NewTicker(config.PingInterval)
+	)
+	defer func() {
+		ticker.Stop()
+		c.Close()
+	}()
+	for {
+		select {
+		case <-c.closeChan:
+			return
+
+		case message := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(config.WriteTimeout))
+			if err := c.conn.WriteMessage(message.Type, message.Data); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			if err := c.conn.WriteControl(
+				websocket.PingMessage, nil, time.Now().Add(config.WriteTimeout),
+			); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// deleteWebSocketMember removes client `clientId` from `members` of `key`,
+// and removes the `key` if it has no member.
+func deleteWebSocketMember(members map[string]map[string]*WebSocketClient, key, clientId string) {
+	if m, ok := members[key]; ok {
+		delete(m, clientId)
+		if len(m) == 0 {
+			delete(members, key)
+		}
+	}
+}
+
+// webSocketClientMapToSlice converts client map to slice.
+func webSocketClientMapToSlice(m map[string]*WebSocketClient) []*WebSocketClient {
+	var clients = make([]*WebSocketClient, 0, len(m))
+	for _, client := range m {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+// sortWebSocketClients sorts `clients` by their ids.
+func sortWebSocketClients(clients []*WebSocketClient) []*WebSocketClient {
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].id < clients[j].id
+	})
+	return clients
+}
@@ -0,0 +1,87 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gredis"
+)
+
+const (
+	defaultWebSocketBackplaneChannel = "ghttp:websocket:hub"
+)
+
+// WebSocketBackplane is the interface for delivering messages between WebSocketHub instances,
+// so that the messages reach clients connected to other instances.
+type WebSocketBackplane interface {
+	// Publish publishes `payload` to all the hub instances.
+	Publish(ctx context.Context, payload []byte) error
+
+	// Subscribe receives payloads published by hub instances and calls `handler` for each payload.
+	// It blocks until `ctx` is done or the subscription fails.
+	Subscribe(ctx context.Context, handler func(payload []byte)) error
+}
+
+// WebSocketRedisBackplane implements WebSocketBackplane using redis pub/sub.
+type WebSocketRedisBackplane struct {
+	redis   *gredis.Redis // Redis client for pub/sub.
+	channel string        // Redis channel for publishing.
+}
+
+// NewWebSocketRedisBackplane creates and returns a redis backplane for WebSocketHub.
+// The optional parameter `channel` specifies the redis channel, which is "ghttp:websocket:hub" in default.
+// The hubs of the same channel deliver messages to each other.
+func NewWebSocketRedisBackplane(redis *gredis.Redis, channel ...string) *WebSocketRedisBackplane {
+	if redis == nil {
+		panic("redis instance for websocket backplane cannot be empty")
+	}
+	b := &WebSocketRedisBackplane{
+		redis:   redis,
+		channel: defaultWebSocketBackplaneChannel,
+	}
+	if len(channel) > 0 && channel[0] != "" {
+		b.channel = channel[0]
+	}
+	return b
+}
+
+// Publish publishes `payload` to the redis channel.
+func (b *WebSocketRedisBackplane) Publish(ctx context.Context, payload []byte) error {
+	_, err := b.redis.Publish(ctx, b.channel, string(payload))
+	return err
+}
+
+// Subscribe subscribes the redis channel and calls `handler` for each payload received,
+// it blocks until `ctx` is done or the subscription fails.
+func (b *WebSocketRedisBackplane) Subscribe(ctx context.Context, handler func(payload []byte)) error {
+	conn, _, err := b.redis.Subscribe(ctx, b.channel)
+	if err != nil {
+		return err
+	}
+	// The connection is closed to interrupt the message receiving when `ctx` is done.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close(context.Background())
+		case <-done:
+			_ = conn.Close(context.Background())
+		}
+	}()
+	for {
+		msg, err := conn.ReceiveMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		handler([]byte(msg.Payload))
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gcron"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func testAdminApiMiddleware(r *ghttp.Request) {
+	r.Middleware.Next()
+}
+
+func TestServer_EnableAdminApi(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Use(testAdminApiMiddleware)
+	s.Group("/api", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse)
+		group.GET("/user", func(r *ghttp.Request) {
+			r.Response.Write("user")
+		})
+	})
+	s.EnableAdminApi("/admin")
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	client := g.Client()
+	client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+	gtest.C(t, func(t *gtest.T) {
+		j, err := gjson.LoadContent([]byte(client.GetContent(ctx, "/admin/routes")))
+		t.AssertNil(err)
+		t.Assert(j.Get("code"), 0)
+		var found bool
+		for _, item := range j.Get("data").Maps() {
+			if item["route"] == "/api/user" {
+				found = true
+				t.Assert(item["method"], "GET")
+				t.Assert(item["middleware"], g.Slice{
+					"github.com/gogf/gf/v2/net/ghttp_test.testAdminApiMiddleware",
+					"github.com/gogf/gf/v2/net/ghttp.MiddlewareHandlerResponse",
+				})
+			}
+		}
+		t.Assert(found, true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		name := guid.S()
+		_, err := gcron.Add(ctx, "@every 1h", func(ctx context.Context) {}, name)
+		t.AssertNil(err)
+		defer gcron.Remove(name)
+
+		j, err := gjson.LoadContent([]byte(client.GetContent(ctx, "/admin/cron")))
+		t.AssertNil(err)
+		var found bool
+		for _, item := range j.Get("data").Maps() {
+			if item["name"] == name {
+				found = true
+				t.Assert(item["pattern"], "@every 1h")
+				t.Assert(item["running"], false)
+			}
+		}
+		t.Assert(found, true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		j, err := gjson.LoadContent([]byte(client.GetContent(ctx, "/admin/pool")))
+		t.AssertNil(err)
+		t.Assert(j.Contains("data.size"), true)
+		t.Assert(j.Contains("data.jobs"), true)
+
+		j, err = gjson.LoadContent([]byte(client.GetContent(ctx, "/admin/database")))
+		t.AssertNil(err)
+		t.Assert(j.Get("code"), 0)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			logger = s.Logger()
+			level  = logger.GetLevel()
+		)
+		defer logger.SetLevel(level)
+
+		j, err := gjson.LoadContent([]byte(client.PutContent(ctx, "/admin/logger", g.Map{
+			"name":  "server",
+			"level": "error",
+		})))
+		t.AssertNil(err)
+		t.Assert(j.Get("data.levels"), g.Slice{"ERRO", "CRIT"})
+		t.Assert(logger.GetLevel(), glog.LEVEL_ERRO|glog.LEVEL_CRIT)
+
+		j, err = gjson.LoadContent([]byte(client.GetContent(ctx, "/admin/logger")))
+		t.AssertNil(err)
+		t.Assert(j.Get("data.1.name"), "server")
+		t.Assert(j.Get("data.1.levels"), g.Slice{"ERRO", "CRIT"})
+
+		j, err = gjson.LoadContent([]byte(client.PutContent(ctx, "/admin/logger", g.Map{
+			"name":  "unknown",
+			"level": "error",
+		})))
+		t.AssertNil(err)
+		t.AssertNE(j.Get("code"), 0)
+	})
+}
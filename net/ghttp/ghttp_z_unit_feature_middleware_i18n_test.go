@@ -0,0 +1,81 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/i18n/gi18n"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_I18n(t *testing.T) {
+	i18n := gi18n.New(gi18n.Options{
+		Path: gtest.DataPath("i18n"),
+	})
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareI18n(ghttp.I18nConfig{
+			Manager: i18n,
+		}))
+		group.GET("/", func(r *ghttp.Request) {
+			r.Response.Write(i18n.T(r.Context(), "hello"))
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// Default language.
+		resp, err := client.Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "Hello")
+		t.Assert(resp.Header.Get(ghttp.HeaderContentLanguage), "")
+		resp.Close()
+
+		// Accept-Language.
+		resp, err = client.Header(g.MapStrStr{
+			ghttp.HeaderAcceptLanguage: "de;q=0.9, zh;q=0.8, en;q=0.5",
+		}).Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "你好")
+		t.Assert(resp.Header.Get(ghttp.HeaderContentLanguage), "zh-CN")
+		resp.Close()
+
+		// Cookie has higher priority than Accept-Language.
+		resp, err = client.Header(g.MapStrStr{
+			ghttp.HeaderAcceptLanguage: "zh-CN",
+		}).Cookie(g.MapStrStr{"lang": "ru"}).Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "Привет")
+		resp.Close()
+
+		// Query has the highest priority.
+		resp, err = client.Cookie(g.MapStrStr{"lang": "ru"}).Get(ctx, "/?lang=zh-cn")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "你好")
+		resp.Close()
+
+		// Unknown language in query is ignored.
+		resp, err = client.Header(g.MapStrStr{
+			ghttp.HeaderAcceptLanguage: "ru-RU",
+		}).Get(ctx, "/?lang=fr")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "Привет")
+		t.Assert(resp.Header.Get(ghttp.HeaderContentLanguage), "ru")
+		resp.Close()
+	})
+}
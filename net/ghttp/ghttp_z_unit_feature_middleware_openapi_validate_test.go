@@ -0,0 +1,168 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+type openapiValidateReq struct {
+	g.Meta `path:"/user" method:"post"`
+	Name   string `json:"name"`
+	Age    int    `json:"age"`
+}
+
+type openapiValidateRes struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type openapiValidateStatusReq struct {
+	g.Meta `path:"/status" method:"get"`
+}
+
+type openapiValidateStatusRes struct{}
+
+type openapiValidateController struct{}
+
+func (openapiValidateController) User(ctx context.Context, req *openapiValidateReq) (res *openapiValidateRes, err error) {
+	r := g.RequestFromCtx(ctx)
+	if req.Name == "drift" {
+		r.Response.WriteJson(g.Map{
+			"code":    0,
+			"message": "OK",
+			"data":    g.Map{"id": "1", "unknown": true},
+		})
+		return
+	}
+	return &openapiValidateRes{Id: 1, Name: req.Name}, nil
+}
+
+func (openapiValidateController) Status(ctx context.Context, req *openapiValidateStatusReq) (res *openapiValidateStatusRes, err error) {
+	g.RequestFromCtx(ctx).Response.WriteHeader(202)
+	return
+}
+
+func Test_Middleware_OpenApiValidate(t *testing.T) {
+	s := g.Server(guid.S())
+	s.SetOpenApiPath("/api.json")
+	oai := s.GetOpenApi()
+	oai.Config.CommonResponse = ghttp.DefaultHandlerResponse{}
+	oai.Config.CommonResponseDataField = "Data"
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(
+			ghttp.MiddlewareOpenApiValidate(ghttp.OpenApiValidateConfig{
+				Fail:    true,
+				Enabled: true,
+			}),
+			ghttp.MiddlewareHandlerResponse,
+		)
+		group.Bind(openapiValidateController{})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// Conforming.
+		resp, err := client.ContentJson().Post(ctx, "/user", g.Map{"name": "john", "age": 18})
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 200)
+		t.Assert(resp.ReadAllString(), `{"code":0,"message":"OK","data":{"id":1,"name":"john"}}`)
+		resp.Close()
+
+		// Request drift.
+		resp, err = client.ContentJson().Post(ctx, "/user", `{"name":"john","age":"18"}`)
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 400)
+		t.Assert(
+			resp.ReadAllString(),
+			`{"code":51,"message":"$.age: expected type \"integer\" but got string","data":null}`,
+		)
+		resp.Close()
+
+		// Response drift.
+		resp, err = client.ContentJson().Post(ctx, "/user", g.Map{"name": "drift"})
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 500)
+		content := resp.ReadAllString()
+		t.Assert(gstr.Contains(content, `$.data.id: expected type \"integer\" but got string`), true)
+		t.Assert(gstr.Contains(content, `$.data.unknown: property is not documented`), true)
+		resp.Close()
+
+		// Status drift.
+		resp, err = client.Get(ctx, "/status")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 500)
+		t.Assert(gstr.Contains(resp.ReadAllString(), `status code \"202\" of operation \"GET /status\" is not documented`), true)
+		resp.Close()
+	})
+}
+
+type openapiValidateProfileReq struct {
+	g.Meta `path:"/profile" method:"get"`
+	Name   string `json:"name"`
+}
+
+type openapiValidateProfileRes struct {
+	Id   int    `json:"id"`
+	Name string `json:"name,omitempty" v:"required"`
+}
+
+type openapiValidateProfileController struct{}
+
+func (openapiValidateProfileController) Profile(ctx context.Context, req *openapiValidateProfileReq) (res *openapiValidateProfileRes, err error) {
+	return &openapiValidateProfileRes{Id: 1, Name: req.Name}, nil
+}
+
+func Test_Middleware_OpenApiValidate_DefaultCommonResponse(t *testing.T) {
+	s := g.Server(guid.S())
+	s.SetOpenApiPath("/api.json")
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(
+			ghttp.MiddlewareOpenApiValidate(ghttp.OpenApiValidateConfig{
+				Fail:    true,
+				Enabled: true,
+			}),
+			ghttp.MiddlewareHandlerResponse,
+		)
+		group.Bind(openapiValidateProfileController{})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// Conforming, the wrapped content is not validated against the handler response object.
+		t.Assert(
+			client.GetContent(ctx, "/profile?name=john"),
+			`{"code":0,"message":"OK","data":{"id":1,"name":"john"}}`,
+		)
+
+		// Response drift.
+		resp, err := client.Get(ctx, "/profile")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 500)
+		t.Assert(gstr.Contains(resp.ReadAllString(), `$: required property \"name\" is missing`), true)
+		resp.Close()
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_RateLimit_TokenBucket(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse, ghttp.MiddlewareRateLimit(ghttp.RateLimitConfig{
+			Limit:  2,
+			Period: time.Minute,
+		}))
+		group.GET("/", func(r *ghttp.Request) {
+			r.Response.Write("ok")
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		resp, err := client.Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 200)
+		t.Assert(resp.Header.Get(ghttp.HeaderRateLimitLimit), "2")
+		t.Assert(resp.Header.Get(ghttp.HeaderRateLimitRemaining), "1")
+		t.Assert(resp.ReadAllString(), "ok")
+		resp.Close()
+
+		resp, err = client.Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 200)
+		t.Assert(resp.Header.Get(ghttp.HeaderRateLimitRemaining), "0")
+		resp.Close()
+
+		resp, err = client.Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 429)
+		t.Assert(resp.Header.Get(ghttp.HeaderRateLimitRemaining), "0")
+		t.Assert(resp.Header.Get(ghttp.HeaderRetryAfter), "30")
+		t.Assert(resp.ReadAllString(), `{"code":69,"message":"Too Many Requests","data":null}`)
+		resp.Close()
+	})
+}
+
+func Test_Middleware_RateLimit_SlidingWindow(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareRateLimit(ghttp.RateLimitConfig{
+			Algorithm: ghttp.RateLimitSlidingWindow,
+			Limit:     3,
+			Period:    time.Hour,
+			KeyFunc:   ghttp.RateLimitKeyByHeader("X-Api-Key"),
+		}))
+		group.GET("/", func(r *ghttp.Request) {
+			r.Response.Write("ok")
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+		client := g.Client().SetPrefix(prefix).Header(g.MapStrStr{"X-Api-Key": "a"})
+		for i := 0; i < 3; i++ {
+			t.Assert(client.GetContent(ctx, "/"), "ok")
+		}
+		resp, err := client.Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 429)
+		resp.Close()
+
+		// Different key has its own quota.
+		t.Assert(g.Client().SetPrefix(prefix).Header(g.MapStrStr{"X-Api-Key": "b"}).GetContent(ctx, "/"), "ok")
+
+		// No key, no limit.
+		for i := 0; i < 5; i++ {
+			t.Assert(g.Client().SetPrefix(prefix).GetContent(ctx, "/"), "ok")
+		}
+	})
+}
+
+func Test_RateLimitMemoryStore_TokenBucket_Refill(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		store := ghttp.NewRateLimitMemoryStore()
+		for i := 0; i < 10; i++ {
+			result, err := store.Allow(ctx, "key", ghttp.RateLimitTokenBucket, 10, time.Second)
+			t.AssertNil(err)
+			t.Assert(result.Allowed, true)
+		}
+		result, err := store.Allow(ctx, "key", ghttp.RateLimitTokenBucket, 10, time.Second)
+		t.AssertNil(err)
+		t.Assert(result.Allowed, false)
+		t.AssertGT(result.RetryAfter, 0)
+		t.AssertLE(result.RetryAfter, 100*time.Millisecond)
+
+		time.Sleep(200 * time.Millisecond)
+		result, err = store.Allow(ctx, "key", ghttp.RateLimitTokenBucket, 10, time.Second)
+		t.AssertNil(err)
+		t.Assert(result.Allowed, true)
+	})
+}
+
+func Test_RateLimit_InvalidPeriod(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		store := ghttp.NewRateLimitMemoryStore()
+		for _, algorithm := range []ghttp.RateLimitAlgorithm{
+			ghttp.RateLimitTokenBucket, ghttp.RateLimitSlidingWindow,
+		} {
+			_, err := store.Allow(ctx, "key", algorithm, 10, time.Microsecond)
+			t.AssertNE(err, nil)
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		defer func() {
+			t.AssertNE(recover(), nil)
+		}()
+		ghttp.MiddlewareRateLimit(ghttp.RateLimitConfig{Limit: 10, Period: time.Microsecond})
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/net/goai"
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/text/gstr"
 	"github.com/gogf/gf/v2/util/gmeta"
@@ -210,3 +211,34 @@ func openApiBasicAuth(r *ghttp.Request) {
 		return
 	}
 }
+
+func Test_OpenApi_Version31(t *testing.T) {
+	type TestReq struct {
+		gmeta.Meta `method:"post" summary:"Test summary" tags:"Test"`
+		Name       *string
+	}
+	type TestRes struct {
+		Id int
+	}
+	s := g.Server(guid.S())
+	s.SetOpenApiPath("/api.json")
+	s.SetOpenApiVersion(goai.OpenAPIVersion31)
+	s.Use(ghttp.MiddlewareHandlerResponse)
+	s.BindHandler("/test", func(ctx context.Context, req *TestReq) (res *TestRes, err error) {
+		return &TestRes{Id: 1}, nil
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		c := g.Client()
+		c.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(s.GetOpenApiVersion(), goai.OpenAPIVersion31)
+		content := c.GetContent(ctx, "/api.json")
+		t.Assert(gstr.Contains(content, `"openapi":"3.1.0"`), true)
+		t.Assert(gstr.Contains(content, `"type":["string","null"]`), true)
+	})
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gsession"
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/util/guid"
 )
@@ -278,3 +279,33 @@ func Test_Session_New_Id(t *testing.T) {
 		t.Assert(r.GetCookie(s.GetSessionIdName()), newSessionId2)
 	})
 }
+
+func Test_Session_Fingerprint(t *testing.T) {
+	s := g.Server(guid.S())
+	s.SetSessionFingerprint(gsession.FingerprintConfig{UserAgent: true})
+	s.BindHandler("/set", func(r *ghttp.Request) {
+		r.Session.Set(r.Get("k").String(), r.Get("v").String())
+	})
+	s.BindHandler("/get", func(r *ghttp.Request) {
+		r.Response.Write(r.Session.Get(r.Get("k").String()))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetBrowserMode(true)
+		client.SetAgent("agent1")
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(client.GetContent(ctx, "/set?k=key1&v=100"), "")
+		t.Assert(client.GetContent(ctx, "/get?k=key1"), "100")
+
+		// The session is invalidated for another client.
+		client.SetAgent("agent2")
+		t.Assert(client.GetContent(ctx, "/get?k=key1"), "")
+		client.SetAgent("agent1")
+		t.Assert(client.GetContent(ctx, "/get?k=key1"), "")
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_SSE_Send(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse)
+		group.GET("/sse", func(r *ghttp.Request) {
+			var (
+				sse   = r.SSE()
+				start = gconv.Int(sse.LastEventID()) + 1
+			)
+			_ = sse.SetRetry(3 * time.Second)
+			for i := start; i <= 3; i++ {
+				_ = sse.Send("count", gconv.String(i), g.Map{"n": i})
+			}
+			_ = sse.Send("", "", "line1\nline2")
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		reader, err := client.SSE(ctx, "/sse")
+		t.AssertNil(err)
+		defer reader.Close()
+		t.Assert(reader.Retry(), time.Duration(0))
+		for i := 1; i <= 3; i++ {
+			event, err := reader.Next()
+			t.AssertNil(err)
+			t.Assert(event.Event, "count")
+			t.Assert(event.ID, i)
+			t.Assert(event.Data, fmt.Sprintf(`{"n":%d}`, i))
+		}
+		t.Assert(reader.Retry(), 3*time.Second)
+		event, err := reader.Next()
+		t.AssertNil(err)
+		t.Assert(event.Event, "message")
+		t.Assert(event.ID, "3")
+		t.Assert(event.Data, "line1\nline2")
+		_, err = reader.Next()
+		t.Assert(err, io.EOF)
+	})
+
+	// Resume with Last-Event-ID.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		reader, err := client.SSE(ctx, "/sse", "2")
+		t.AssertNil(err)
+		defer reader.Close()
+		event, err := reader.Next()
+		t.AssertNil(err)
+		t.Assert(event.ID, "3")
+		t.Assert(reader.LastEventID(), "3")
+	})
+}
+
+func Test_SSE_Heartbeat_Disconnect(t *testing.T) {
+	var disconnected = make(chan struct{})
+	s := g.Server(guid.S())
+	s.BindHandler("/sse", func(r *ghttp.Request) {
+		sse := r.SSE()
+		sse.Heartbeat(50 * time.Millisecond)
+		_ = sse.SendData("hello")
+		<-sse.Done()
+		if sse.SendData("world") != nil {
+			close(disconnected)
+		}
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		clientCtx, cancel := context.WithCancel(ctx)
+		reader, err := client.SSE(clientCtx, "/sse")
+		t.AssertNil(err)
+		event, err := reader.Next()
+		t.AssertNil(err)
+		t.Assert(event.Data, "hello")
+
+		// Heartbeat comments are ignored by reader, so it blocks until disconnected.
+		time.Sleep(200 * time.Millisecond)
+		cancel()
+		_, err = reader.Next()
+		t.AssertNE(err, nil)
+		_ = reader.Close()
+
+		select {
+		case <-disconnected:
+		case <-time.After(time.Second):
+			t.Error("client disconnect not detected")
+		}
+	})
+}
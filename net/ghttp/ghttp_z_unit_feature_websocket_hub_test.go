@@ -0,0 +1,235 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// memoryBackplane is a WebSocketBackplane delivering payloads in memory for testing.
+type memoryBackplane struct {
+	mu       sync.Mutex
+	handlers []func(payload []byte)
+}
+
+func (b *memoryBackplane) Publish(ctx context.Context, payload []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, handler := range b.handlers {
+		handler(payload)
+	}
+	return nil
+}
+
+func (b *memoryBackplane) Subscribe(ctx context.Context, handler func(payload []byte)) error {
+	b.mu.Lock()
+	b.handlers = append(b.handlers, handler)
+	b.mu.Unlock()
+	<-ctx.Done()
+	return nil
+}
+
+func startWebSocketHubServer(hub *ghttp.WebSocketHub) *ghttp.Server {
+	s := g.Server(guid.S())
+	s.BindHandler("/ws", func(r *ghttp.Request) {
+		_ = hub.Serve(r, r.Get("user").String())
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	time.Sleep(100 * time.Millisecond)
+	return s
+}
+
+func dialWebSocketHub(s *ghttp.Server, user string) (*websocket.Conn, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf(
+		"ws://127.0.0.1:%d/ws?user=%s", s.GetListenedPort(), user,
+	), nil)
+	return conn, err
+}
+
+func readWebSocketText(conn *websocket.Conn) string {
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+func Test_WebSocketHub_Basic(t *testing.T) {
+	hub := ghttp.NewWebSocketHub(ghttp.WebSocketHubConfig{
+		OnMessage: func(client *ghttp.WebSocketClient, messageType int, data []byte) {
+			text := string(data)
+			switch {
+			case strings.HasPrefix(text, "join:"):
+				client.Join(strings.TrimPrefix(text, "join:"))
+				_ = client.SendText("joined")
+			case strings.HasPrefix(text, "leave:"):
+				client.Leave(strings.TrimPrefix(text, "leave:"))
+				_ = client.SendText("left")
+			default:
+				_ = client.SendText("echo:" + text)
+			}
+		},
+	})
+	defer hub.Close()
+	s := startWebSocketHubServer(hub)
+	defer s.Shutdown()
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx = context.Background()
+			msg = []byte("hi")
+		)
+		john1, err := dialWebSocketHub(s, "john")
+		t.AssertNil(err)
+		defer john1.Close()
+		john2, err := dialWebSocketHub(s, "john")
+		t.AssertNil(err)
+		defer john2.Close()
+		smith, err := dialWebSocketHub(s, "smith")
+		t.AssertNil(err)
+		defer smith.Close()
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(hub.GetClientCount(), 3)
+		t.Assert(len(hub.GetUserClients("john")), 2)
+
+		// Echo.
+		t.AssertNil(john1.WriteMessage(websocket.TextMessage, []byte("hello")))
+		t.Assert(readWebSocketText(john1), "echo:hello")
+
+		// Room.
+		t.AssertNil(john1.WriteMessage(websocket.TextMessage, []byte("join:news")))
+		t.Assert(readWebSocketText(john1), "joined")
+		t.AssertNil(smith.WriteMessage(websocket.TextMessage, []byte("join:news")))
+		t.Assert(readWebSocketText(smith), "joined")
+		t.Assert(len(hub.GetRoomClients("news")), 2)
+		t.Assert(hub.GetRoomClients("news")[0].Rooms(), g.Slice{"news"})
+		t.AssertNil(hub.SendToRoom(ctx, "news", ghttp.WsMsgText, msg))
+		t.Assert(readWebSocketText(john1), msg)
+		t.Assert(readWebSocketText(smith), msg)
+
+		// User.
+		t.AssertNil(hub.SendToUser(ctx, "john", ghttp.WsMsgText, msg))
+		t.Assert(readWebSocketText(john1), msg)
+		t.Assert(readWebSocketText(john2), msg)
+
+		// Client.
+		client := hub.GetUserClients("smith")[0]
+		t.Assert(client.UserId(), "smith")
+		t.AssertNil(hub.SendToClient(ctx, client.Id(), ghttp.WsMsgText, msg))
+		t.Assert(readWebSocketText(smith), msg)
+		t.AssertNE(hub.SendToClient(ctx, "none", ghttp.WsMsgText, msg), nil)
+
+		// Broadcast.
+		t.AssertNil(hub.Broadcast(ctx, ghttp.WsMsgText, msg))
+		t.Assert(readWebSocketText(john1), msg)
+		t.Assert(readWebSocketText(john2), msg)
+		t.Assert(readWebSocketText(smith), msg)
+
+		// Leave and close.
+		t.AssertNil(smith.WriteMessage(websocket.TextMessage, []byte("leave:news")))
+		t.Assert(readWebSocketText(smith), "left")
+		t.Assert(len(hub.GetRoomClients("news")), 1)
+		t.AssertNil(john1.Close())
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(hub.GetClientCount(), 2)
+		t.Assert(len(hub.GetUserClients("john")), 1)
+		t.Assert(len(hub.GetRoomClients("news")), 0)
+	})
+}
+
+func Test_WebSocketHub_Backplane(t *testing.T) {
+	var (
+		backplane = &memoryBackplane{}
+		hub1      = ghttp.NewWebSocketHub(ghttp.WebSocketHubConfig{Backplane: backplane})
+		hub2      = ghttp.NewWebSocketHub(ghttp.WebSocketHubConfig{Backplane: backplane})
+	)
+	defer hub1.Close()
+	defer hub2.Close()
+	s1 := startWebSocketHubServer(hub1)
+	defer s1.Shutdown()
+	s2 := startWebSocketHubServer(hub2)
+	defer s2.Shutdown()
+
+	gtest.C(t, func(t *gtest.T) {
+		ctx := context.Background()
+		john, err := dialWebSocketHub(s1, "john")
+		t.AssertNil(err)
+		defer john.Close()
+		smith, err := dialWebSocketHub(s2, "smith")
+		t.AssertNil(err)
+		defer smith.Close()
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(hub1.GetClientCount(), 1)
+		t.Assert(hub2.GetClientCount(), 1)
+
+		// Broadcast reaches clients of both instances exactly once.
+		t.AssertNil(hub1.Broadcast(ctx, ghttp.WsMsgText, []byte("all")))
+		t.Assert(readWebSocketText(john), "all")
+		t.Assert(readWebSocketText(smith), "all")
+
+		// Targeted sending to client of other instance.
+		t.AssertNil(hub1.SendToUser(ctx, "smith", ghttp.WsMsgText, []byte("user")))
+		t.Assert(readWebSocketText(smith), "user")
+		clientId := hub1.GetUserClients("john")[0].Id()
+		t.AssertNil(hub2.SendToClient(ctx, clientId, ghttp.WsMsgText, []byte("client")))
+		t.Assert(readWebSocketText(john), "client")
+
+		_ = john.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		_, _, err = john.ReadMessage()
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_WebSocketHub_Overflow(t *testing.T) {
+	closed := make(chan string, 1)
+	hub := ghttp.NewWebSocketHub(ghttp.WebSocketHubConfig{
+		SendBufferSize: 1,
+		OnClose: func(client *ghttp.WebSocketClient) {
+			closed <- client.UserId()
+		},
+	})
+	defer hub.Close()
+	s := startWebSocketHubServer(hub)
+	defer s.Shutdown()
+
+	gtest.C(t, func(t *gtest.T) {
+		// The client never reads, so the send buffer gets full finally.
+		conn, err := dialWebSocketHub(s, "slow")
+		t.AssertNil(err)
+		defer conn.Close()
+		time.Sleep(100 * time.Millisecond)
+		client := hub.GetUserClients("slow")[0]
+		data := make([]byte, 1<<18)
+		for i := 0; i < 1000; i++ {
+			if err = client.Send(ghttp.WsMsgBinary, data); err != nil {
+				break
+			}
+		}
+		t.AssertNE(err, nil)
+		select {
+		case userId := <-closed:
+			t.Assert(userId, "slow")
+		case <-time.After(time.Second):
+			t.Error("slow client is not closed")
+		}
+		t.Assert(hub.GetClientCount(), 0)
+	})
+}
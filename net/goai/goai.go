@@ -25,6 +25,11 @@ import (
 // OpenApiV3 is the structure defined from:
 // https://swagger.io/specification/
 // https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.0.md
+// https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.1.0.md
+//
+// It produces OpenAPI 3.0 specification in default. The OpenAPI 3.1 specification, whose schemas
+// use JSON Schema 2020-12, is produced if attribute `OpenAPI` is set to OpenAPIVersion31.
+// Note that the `OpenAPI` should be set before adding any object, as the schemas are generated in adding.
 type OpenApiV3 struct {
 	Config       Config                `json:"-"`
 	OpenAPI      string                `json:"openapi"`
@@ -37,6 +42,11 @@ type OpenApiV3 struct {
 	ExternalDocs *ExternalDocs         `json:"externalDocs,omitempty"`
 }
 
+const (
+	OpenAPIVersion30 = `3.0.0` // OpenAPI specification version 3.0.
+	OpenAPIVersion31 = `3.1.0` // OpenAPI specification version 3.1, which uses JSON Schema 2020-12.
+)
+
 const (
 	TypeInteger    = `integer`
 	TypeNumber     = `number`
@@ -45,6 +55,7 @@ const (
 	TypeString     = `string`
 	TypeFile       = `file`
 	TypeObject     = `object`
+	TypeNull       = `null`
 	FormatInt32    = `int32`
 	FormatInt64    = `int64`
 	FormatDouble   = `double`
@@ -140,6 +151,11 @@ func (oai OpenApiV3) String() string {
 	return string(b)
 }
 
+// isOpenApi31 checks and returns whether the specification is in version 3.1.
+func (oai *OpenApiV3) isOpenApi31() bool {
+	return gstr.HasPrefix(oai.OpenAPI, "3.1")
+}
+
 func (oai *OpenApiV3) golangTypeToOAIType(t reflect.Type) string {
 	for t.Kind() == reflect.Pointer {
 		t = t.Elem()
@@ -237,6 +253,21 @@ func formatRefAndDescToBytes(ref, desc string) []byte {
 	return []byte(fmt.Sprintf(`{"$ref":"#/components/schemas/%s","description":"%s"}`, ref, desc))
 }
 
+// formatNullableRefAndDescToBytes formats nullable reference in OpenAPI 3.1 as:
+// {"anyOf":[{"$ref":"#/components/schemas/xxx"},{"type":"null"}],"description":"xxx"}.
+func formatNullableRefAndDescToBytes(ref, desc string) ([]byte, error) {
+	var m = map[string]any{
+		"anyOf": []any{
+			json.RawMessage(formatRefToBytes(ref)),
+			map[string]string{"type": TypeNull},
+		},
+	}
+	if desc != "" {
+		m["description"] = desc
+	}
+	return json.Marshal(m)
+}
+
 func isValidParameterName(key string) bool {
 	return key != "-"
 }
@@ -19,10 +19,12 @@ import (
 	"github.com/gogf/gf/v2/text/gstr"
 	"github.com/gogf/gf/v2/util/gconv"
 	"github.com/gogf/gf/v2/util/gmeta"
+	"github.com/gogf/gf/v2/util/gtag"
 	"github.com/gogf/gf/v2/util/gvalid"
 )
 
 // Schema is specified by OpenAPI/Swagger 3.0 standard.
+// It is also marshaled as JSON Schema 2020-12 for OpenAPI 3.1 specification.
 type Schema struct {
 	OneOf                SchemaRefs     `json:"oneOf,omitempty"`
 	AnyOf                SchemaRefs     `json:"anyOf,omitempty"`
@@ -35,6 +37,8 @@ type Schema struct {
 	Enum                 []any          `json:"enum,omitempty"`
 	Default              any            `json:"default,omitempty"`
 	Example              any            `json:"example,omitempty"`
+	Examples             []any          `json:"examples,omitempty"`
+	Const                any            `json:"const,omitempty"`
 	ExternalDocs         *ExternalDocs  `json:"externalDocs,omitempty"`
 	UniqueItems          bool           `json:"uniqueItems,omitempty"`
 	ExclusiveMin         bool           `json:"exclusiveMinimum,omitempty"`
@@ -62,6 +66,7 @@ type Schema struct {
 	Discriminator        *Discriminator `json:"discriminator,omitempty"`
 	XExtensions          XExtensions    `json:"-"`
 	ValidationRules      string         `json:"-"`
+	openapi31            bool           // Whether marshaling as JSON Schema 2020-12 for OpenAPI 3.1.
 }
 
 // Clone only clones necessary attributes.
@@ -87,6 +92,11 @@ func (s Schema) MarshalJSON() ([]byte, error) {
 	if err = json.Unmarshal(b, &m); err != nil {
 		return nil, err
 	}
+	if s.openapi31 {
+		if err = s.convertToJsonSchema2020(m); err != nil {
+			return nil, err
+		}
+	}
 	for k, v := range s.XExtensions {
 		if b, err = json.Marshal(v); err != nil {
 			return nil, err
@@ -96,6 +106,48 @@ func (s Schema) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m)
 }
 
+// convertToJsonSchema2020 converts the marshaled OpenAPI 3.0 schema `m` to JSON Schema 2020-12,
+// which is used in OpenAPI 3.1 specification.
+func (s Schema) convertToJsonSchema2020(m map[string]json.RawMessage) (err error) {
+	var schemaType any = s.Type
+	// The "file" type is not supported by JSON Schema.
+	if s.Type == TypeFile {
+		schemaType = TypeString
+		if m["format"], err = json.Marshal(FormatBinary); err != nil {
+			return err
+		}
+	}
+	// The "nullable" keyword is replaced with type array.
+	delete(m, "nullable")
+	if s.Nullable && s.Type != "" {
+		schemaType = []string{gconv.String(schemaType), TypeNull}
+	}
+	if s.Type != "" {
+		if m["type"], err = json.Marshal(schemaType); err != nil {
+			return err
+		}
+	}
+	// The "exclusiveMinimum" and "exclusiveMaximum" are numbers instead of booleans.
+	delete(m, "exclusiveMinimum")
+	delete(m, "exclusiveMaximum")
+	if s.ExclusiveMin && s.Min != nil {
+		m["exclusiveMinimum"] = m["minimum"]
+		delete(m, "minimum")
+	}
+	if s.ExclusiveMax && s.Max != nil {
+		m["exclusiveMaximum"] = m["maximum"]
+		delete(m, "maximum")
+	}
+	// The "example" keyword is deprecated in favor of "examples".
+	if s.Example != nil && len(s.Examples) == 0 {
+		if m["examples"], err = json.Marshal([]any{s.Example}); err != nil {
+			return err
+		}
+		delete(m, "example")
+	}
+	return nil
+}
+
 // Discriminator is specified by OpenAPI/Swagger standard version 3.0.
 type Discriminator struct {
 	PropertyName string            `json:"propertyName"`
@@ -149,6 +201,7 @@ func (oai *OpenApiV3) structToSchema(object any) (*Schema, error) {
 		schema = &Schema{
 			Properties:  createSchemas(),
 			XExtensions: make(XExtensions),
+			openapi31:   oai.isOpenApi31(),
 		}
 		ignoreProperties []any
 	)
@@ -156,6 +209,13 @@ func (oai *OpenApiV3) structToSchema(object any) (*Schema, error) {
 		if err := oai.tagMapToSchema(tagMap, schema); err != nil {
 			return nil, err
 		}
+		// Schema composition, eg: oneOf, anyOf.
+		if tagMap[gtag.OneOf] != "" || tagMap[gtag.AnyOf] != "" {
+			if err := oai.structToCompositionSchema(object, tagMap, schema); err != nil {
+				return nil, err
+			}
+			return schema, nil
+		}
 	}
 	if schema.Type != "" && schema.Type != TypeObject {
 		return schema, nil
@@ -270,10 +330,27 @@ func (oai *OpenApiV3) structToSchema(object any) (*Schema, error) {
 }
 
 func (oai *OpenApiV3) tagMapToSchema(tagMap map[string]string, schema *Schema) error {
-	var mergedTagMap = oai.fillMapWithShortTags(tagMap)
-	if err := gconv.Struct(mergedTagMap, schema); err != nil {
+	var (
+		mergedTagMap = oai.fillMapWithShortTags(tagMap)
+		schemaTagMap = make(map[string]string, len(mergedTagMap))
+	)
+	// The tags that cannot be mapped to Schema attributes directly.
+	for k, v := range mergedTagMap {
+		switch k {
+		case gtag.OneOf, gtag.AnyOf, gtag.Discriminator, gtag.Examples:
+		default:
+			schemaTagMap[k] = v
+		}
+	}
+	if err := gconv.Struct(schemaTagMap, schema); err != nil {
 		return gerror.Wrap(err, `mapping struct tags to Schema failed`)
 	}
+	// The examples of schema should be a json array.
+	if examples := gstr.Trim(mergedTagMap[gtag.Examples]); gstr.HasPrefix(examples, "[") {
+		if err := json.Unmarshal([]byte(examples), &schema.Examples); err != nil {
+			return gerror.Wrapf(err, `invalid schema examples "%s"`, examples)
+		}
+	}
 	oai.tagMapToXExtensions(mergedTagMap, schema.XExtensions)
 	// Validation info to OpenAPI schema pattern.
 	for _, tag := range gvalid.GetTags() {
@@ -0,0 +1,96 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package goai
+
+import (
+	"reflect"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gstructs"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gtag"
+)
+
+// structToCompositionSchema converts struct `object` to composition schema using tags `oneOf`, `anyOf`
+// and `discriminator` in its g.Meta, eg:
+//
+//	type PetRes struct {
+//	    g.Meta `oneOf:"cat,dog" discriminator:"petType"`
+//	    Cat    *Cat `json:"cat"`
+//	    Dog    *Dog `json:"dog"`
+//	}
+//
+// The tag `oneOf` or `anyOf` specifies the field names (or their json names) of struct,
+// whose types are the alternatives of the schema. The tag `discriminator` specifies the property name
+// distinguishing the alternatives, and the json names of the fields are used as the discriminator
+// values mapping to their schemas, like: {"cat": "#/components/schemas/Cat"}.
+func (oai *OpenApiV3) structToCompositionSchema(object any, tagMap map[string]string, schema *Schema) error {
+	structFields, _ := gstructs.Fields(gstructs.FieldsInput{
+		Pointer:         object,
+		RecursiveOption: gstructs.RecursiveOptionEmbedded,
+	})
+	var fieldMap = make(map[string]gstructs.Field)
+	for _, structField := range structFields {
+		if !gstr.IsLetterUpper(structField.Name()[0]) {
+			continue
+		}
+		fieldMap[structField.Name()] = structField
+		if name := gstr.Split(gstr.Trim(structField.TagPriorityName()), ",")[0]; name != "" {
+			fieldMap[name] = structField
+		}
+	}
+	var (
+		mapping     = make(map[string]string)
+		newRefsFunc = func(tag string) (SchemaRefs, error) {
+			var refs SchemaRefs
+			for _, name := range gstr.SplitAndTrim(tagMap[tag], ",") {
+				structField, ok := fieldMap[name]
+				if !ok {
+					return nil, gerror.NewCodef(
+						gcode.CodeInvalidParameter,
+						`field "%s" in tag "%s" is not found in struct "%s"`,
+						name, tag, reflect.TypeOf(object).String(),
+					)
+				}
+				schemaRef, err := oai.newSchemaRefWithGolangType(structField.Type().Type, nil)
+				if err != nil {
+					return nil, err
+				}
+				// The alternative itself is not nullable.
+				if schemaRef.Value != nil {
+					schemaRef.Value.Nullable = false
+				}
+				if schemaRef.Ref != "" {
+					var value = gstr.Split(gstr.Trim(structField.TagPriorityName()), ",")[0]
+					if value == "" {
+						value = structField.Name()
+					}
+					mapping[value] = "#/components/schemas/" + schemaRef.Ref
+				}
+				refs = append(refs, *schemaRef)
+			}
+			return refs, nil
+		}
+		err error
+	)
+	if schema.OneOf, err = newRefsFunc(gtag.OneOf); err != nil {
+		return err
+	}
+	if schema.AnyOf, err = newRefsFunc(gtag.AnyOf); err != nil {
+		return err
+	}
+	if propertyName := tagMap[gtag.Discriminator]; propertyName != "" {
+		schema.Discriminator = &Discriminator{
+			PropertyName: propertyName,
+			Mapping:      mapping,
+		}
+	}
+	schema.Type = ""
+	schema.Properties = nil
+	return nil
+}
@@ -51,8 +51,12 @@ func (oai *OpenApiV3) newSchemaRefWithGolangType(golangType reflect.Type, tagMap
 			Type:        oaiType,
 			Format:      oaiFormat,
 			XExtensions: make(XExtensions),
+			openapi31:   oai.isOpenApi31(),
 		}
+		// Pointer type is nullable in OpenAPI 3.1, which is marshaled as type array with "null".
+		nullable = golangType.Kind() == reflect.Pointer && schema.openapi31
 	)
+	schema.Nullable = nullable
 	if pkgPath == "" {
 		switch golangType.Kind() {
 		case reflect.Pointer, reflect.Array, reflect.Slice:
@@ -87,6 +91,12 @@ func (oai *OpenApiV3) newSchemaRefWithGolangType(golangType reflect.Type, tagMap
 		if schemaRef.Value.Default != nil {
 			schemaRef.Value.Default = gconv.Int64(schemaRef.Value.Default)
 		}
+		if schemaRef.Value.Const != nil {
+			schemaRef.Value.Const = gconv.Int64(schemaRef.Value.Const)
+		}
+		for i, v := range schemaRef.Value.Examples {
+			schemaRef.Value.Examples[i] = gconv.Int64(v)
+		}
 		// keep the default value as nil.
 
 		// example value needs to be converted just like default value
@@ -98,6 +108,12 @@ func (oai *OpenApiV3) newSchemaRefWithGolangType(golangType reflect.Type, tagMap
 		if schemaRef.Value.Default != nil {
 			schemaRef.Value.Default = gconv.Float64(schemaRef.Value.Default)
 		}
+		if schemaRef.Value.Const != nil {
+			schemaRef.Value.Const = gconv.Float64(schemaRef.Value.Const)
+		}
+		for i, v := range schemaRef.Value.Examples {
+			schemaRef.Value.Examples[i] = gconv.Float64(v)
+		}
 		// keep the default value as nil.
 
 		// example value needs to be converted just like default value
@@ -109,6 +125,12 @@ func (oai *OpenApiV3) newSchemaRefWithGolangType(golangType reflect.Type, tagMap
 		if schemaRef.Value.Default != nil {
 			schemaRef.Value.Default = gconv.Bool(schemaRef.Value.Default)
 		}
+		if schemaRef.Value.Const != nil {
+			schemaRef.Value.Const = gconv.Bool(schemaRef.Value.Const)
+		}
+		for i, v := range schemaRef.Value.Examples {
+			schemaRef.Value.Examples[i] = gconv.Bool(v)
+		}
 		// keep the default value as nil.
 
 		// example value needs to be converted just like default value
@@ -159,6 +181,7 @@ func (oai *OpenApiV3) newSchemaRefWithGolangType(golangType reflect.Type, tagMap
 				if err != nil {
 					return nil, err
 				}
+				schema.Nullable = nullable
 				schemaRef.Ref = ""
 				schemaRef.Value = schema
 			} else {
@@ -179,6 +202,10 @@ func (oai *OpenApiV3) newSchemaRefWithGolangType(golangType reflect.Type, tagMap
 
 func (r SchemaRef) MarshalJSON() ([]byte, error) {
 	if r.Ref != "" {
+		// Nullable reference in OpenAPI 3.1.
+		if r.Value != nil && r.Value.openapi31 && r.Value.Nullable {
+			return formatNullableRefAndDescToBytes(r.Ref, r.Description)
+		}
 		return formatRefAndDescToBytes(r.Ref, r.Description), nil
 	}
 	return json.Marshal(r.Value)
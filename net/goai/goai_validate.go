@@ -0,0 +1,326 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package goai
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+const (
+	responseDefaultKey = `default`
+	validateRootPath   = `$`
+)
+
+// ValidateInput is the input parameter for validating request or response against the specification.
+type ValidateInput struct {
+	Path        string // Route path in specification, eg: /user/{id}.
+	Method      string // HTTP method, eg: GET.
+	Status      int    // HTTP status code of response, which is only used for response validation.
+	ContentType string // Content type of body, the json content type is used if it is empty.
+	Body        []byte // Body content.
+}
+
+// schemaValidator validates json values against schemas of specification.
+type schemaValidator struct {
+	oai             *OpenApiV3
+	allowAdditional bool     // Whether the properties not defined in schema are allowed.
+	drifts          []string // Drifts between values and schemas.
+}
+
+// GetOperation returns the operation of `method` for route `path` in specification.
+// It returns nil if the operation is not found.
+func (oai *OpenApiV3) GetOperation(path, method string) *Operation {
+	p, ok := oai.Paths[path]
+	if !ok {
+		return nil
+	}
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		return p.Get
+	case http.MethodPut:
+		return p.Put
+	case http.MethodPost:
+		return p.Post
+	case http.MethodDelete:
+		return p.Delete
+	case http.MethodConnect:
+		return p.Connect
+	case http.MethodHead:
+		return p.Head
+	case http.MethodOptions:
+		return p.Options
+	case http.MethodPatch:
+		return p.Patch
+	case http.MethodTrace:
+		return p.Trace
+	}
+	return nil
+}
+
+// ValidateResponse validates the response status code and json body against the operation of
+// `in.Path` and `in.Method` in specification, and returns error of code gcode.CodeValidationFailed
+// describing all the drifts if the response diverges from the specification.
+//
+// The status code should be documented in operation responses or the "default" response.
+// The body is only validated if it is json content and the json schema of the response is documented.
+// Note that the null value is accepted for any schema, as golang encodes nil slice, map and pointer as null.
+func (oai *OpenApiV3) ValidateResponse(in ValidateInput) error {
+	operation := oai.GetOperation(in.Path, in.Method)
+	if operation == nil {
+		return gerror.NewCodef(
+			gcode.CodeValidationFailed,
+			`operation "%s %s" is not documented`, strings.ToUpper(in.Method), in.Path,
+		)
+	}
+	responseRef, ok := operation.Responses[gconv.String(in.Status)]
+	if !ok {
+		if responseRef, ok = operation.Responses[responseDefaultKey]; !ok {
+			return gerror.NewCodef(
+				gcode.CodeValidationFailed,
+				`status code "%d" of operation "%s %s" is not documented`,
+				in.Status, strings.ToUpper(in.Method), in.Path,
+			)
+		}
+	}
+	if responseRef.Value == nil {
+		return nil
+	}
+	return oai.validateContent(in, responseRef.Value.Content, false)
+}
+
+// ValidateRequest validates the request json body against the operation of `in.Path` and `in.Method`
+// in specification, and returns error of code gcode.CodeValidationFailed describing all the drifts
+// if the request diverges from the specification.
+// The properties not defined in schema are allowed in request, as they are ignored in handling.
+func (oai *OpenApiV3) ValidateRequest(in ValidateInput) error {
+	operation := oai.GetOperation(in.Path, in.Method)
+	if operation == nil {
+		return gerror.NewCodef(
+			gcode.CodeValidationFailed,
+			`operation "%s %s" is not documented`, strings.ToUpper(in.Method), in.Path,
+		)
+	}
+	if operation.RequestBody == nil || operation.RequestBody.Value == nil {
+		return nil
+	}
+	return oai.validateContent(in, operation.RequestBody.Value.Content, true)
+}
+
+// validateContent validates the json body of `in` against its schema in `content`.
+func (oai *OpenApiV3) validateContent(in ValidateInput, content Content, allowAdditional bool) error {
+	if len(bytes.TrimSpace(in.Body)) == 0 {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(in.ContentType)
+	if mediaType == "" {
+		// Content without type is validated only if it is json.
+		if !json.Valid(in.Body) {
+			return nil
+		}
+		mediaType = defaultReadContentTypes[0]
+	}
+	if !gstr.HasSuffix(mediaType, "json") {
+		return nil
+	}
+	item, ok := content[mediaType]
+	if !ok || item.Schema == nil {
+		return nil
+	}
+	var value any
+	if err := json.UnmarshalUseNumber(in.Body, &value); err != nil {
+		return gerror.WrapCode(gcode.CodeValidationFailed, err, `invalid json body`)
+	}
+	validator := &schemaValidator{
+		oai:             oai,
+		allowAdditional: allowAdditional,
+	}
+	validator.validate(validateRootPath, item.Schema, value)
+	if len(validator.drifts) > 0 {
+		return gerror.NewCode(gcode.CodeValidationFailed, strings.Join(validator.drifts, "; "))
+	}
+	return nil
+}
+
+// validate validates `value` at `path` against schema `ref`.
+func (v *schemaValidator) validate(path string, ref *SchemaRef, value any) {
+	schema := v.resolve(ref)
+	if schema == nil || value == nil {
+		return
+	}
+	v.drifts = append(v.drifts, v.check(path, schema, value)...)
+}
+
+// check checks `value` at `path` against `schema` and returns the drifts.
+func (v *schemaValidator) check(path string, schema *Schema, value any) (drifts []string) {
+	if value == nil {
+		return nil
+	}
+	// Composition.
+	for _, item := range schema.AllOf {
+		drifts = append(drifts, v.checkRef(path, &item, value)...)
+	}
+	if len(schema.AnyOf) > 0 && v.countMatched(path, schema.AnyOf, value) == 0 {
+		drifts = append(drifts, fmt.Sprintf(`%s: value matches none of anyOf schemas`, path))
+	}
+	if len(schema.OneOf) > 0 {
+		if count := v.countMatched(path, schema.OneOf, value); count != 1 {
+			drifts = append(drifts, fmt.Sprintf(`%s: value matches %d of oneOf schemas`, path, count))
+		}
+	}
+	// Type.
+	if drift := checkType(path, schema.Type, value); drift != "" {
+		return append(drifts, drift)
+	}
+	// Enum.
+	if len(schema.Enum) > 0 {
+		var found bool
+		for _, item := range schema.Enum {
+			if gconv.String(item) == gconv.String(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			drifts = append(drifts, fmt.Sprintf(`%s: value "%v" is not in enum %v`, path, value, schema.Enum))
+		}
+	}
+	switch realValue := value.(type) {
+	case map[string]any:
+		for _, name := range schema.Required {
+			if _, ok := realValue[name]; !ok {
+				drifts = append(drifts, fmt.Sprintf(`%s: required property "%s" is missing`, path, name))
+			}
+		}
+		var properties = map[string]SchemaRef{}
+		if schema.Properties != nil {
+			properties = schema.Properties.Map()
+		}
+		var names = make([]string, 0, len(realValue))
+		for name := range realValue {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			var (
+				item     = realValue[name]
+				itemPath = path + "." + name
+			)
+			if propertyRef, ok := properties[name]; ok {
+				drifts = append(drifts, v.checkRef(itemPath, &propertyRef, item)...)
+				continue
+			}
+			if schema.AdditionalProperties != nil {
+				drifts = append(drifts, v.checkRef(itemPath, schema.AdditionalProperties, item)...)
+				continue
+			}
+			if !v.allowAdditional && len(properties) > 0 {
+				drifts = append(drifts, fmt.Sprintf(`%s: property is not documented`, itemPath))
+			}
+		}
+
+	case []any:
+		if schema.Items != nil {
+			for i, item := range realValue {
+				drifts = append(drifts, v.checkRef(fmt.Sprintf(`%s[%d]`, path, i), schema.Items, item)...)
+			}
+		}
+	}
+	return drifts
+}
+
+// checkRef checks `value` at `path` against schema `ref` and returns the drifts.
+func (v *schemaValidator) checkRef(path string, ref *SchemaRef, value any) []string {
+	schema := v.resolve(ref)
+	if schema == nil {
+		return nil
+	}
+	return v.check(path, schema, value)
+}
+
+// countMatched returns the count of schemas in `refs` that `value` matches.
+func (v *schemaValidator) countMatched(path string, refs SchemaRefs, value any) int {
+	var count int
+	for _, item := range refs {
+		if len(v.checkRef(path, &item, value)) == 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// resolve returns the schema of `ref`, which searches the components for reference.
+func (v *schemaValidator) resolve(ref *SchemaRef) *Schema {
+	if ref == nil {
+		return nil
+	}
+	if ref.Ref == "" {
+		return ref.Value
+	}
+	if component := v.oai.Components.Schemas.Get(ref.Ref); component != nil {
+		return component.Value
+	}
+	return nil
+}
+
+// checkType checks whether json `value` is of schema type `schemaType`, and returns the drift if not.
+func checkType(path, schemaType string, value any) string {
+	var ok bool
+	switch schemaType {
+	case TypeObject:
+		_, ok = value.(map[string]any)
+	case TypeArray:
+		_, ok = value.([]any)
+	case TypeString:
+		_, ok = value.(string)
+	case TypeBoolean:
+		_, ok = value.(bool)
+	case TypeNumber:
+		_, ok = value.(json.Number)
+	case TypeInteger:
+		var number json.Number
+		if number, ok = value.(json.Number); ok {
+			f, err := number.Float64()
+			ok = err == nil && f == math.Trunc(f)
+		}
+	default:
+		return ""
+	}
+	if ok {
+		return ""
+	}
+	return fmt.Sprintf(`%s: expected type "%s" but got %s`, path, schemaType, jsonTypeName(value))
+}
+
+// jsonTypeName returns the json type name of decoded `value`.
+func jsonTypeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return TypeObject
+	case []any:
+		return TypeArray
+	case string:
+		return TypeString
+	case bool:
+		return TypeBoolean
+	case json.Number:
+		return TypeNumber
+	default:
+		return TypeNull
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package goai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/goai"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+type openapi31Address struct {
+	City string `json:"city"`
+}
+
+type openapi31Cat struct {
+	PetType string `json:"petType"`
+	Lives   int    `json:"lives"`
+}
+
+type openapi31Dog struct {
+	PetType string `json:"petType"`
+	Barks   bool   `json:"barks"`
+}
+
+type openapi31Req struct {
+	g.Meta  `path:"/pet" method:"post"`
+	Name    *string           `json:"name"`
+	Age     int               `json:"age" min:"0" exclusiveMinimum:"true" example:"3"`
+	Kind    string            `json:"kind" const:"pet"`
+	Score   int               `json:"score" examples:"[1, 2]"`
+	Address *openapi31Address `json:"address" dc:"Address"`
+	Tags    []string          `json:"tags"`
+}
+
+type openapi31Res struct {
+	g.Meta `oneOf:"cat,Dog" discriminator:"petType"`
+	Cat    *openapi31Cat `json:"cat"`
+	Dog    *openapi31Dog `json:"dog"`
+}
+
+type openapi31AnyOfRes struct {
+	g.Meta `anyOf:"cat,dog"`
+	Cat    *openapi31Cat `json:"cat"`
+	Dog    openapi31Dog  `json:"dog"`
+}
+
+func Test_OpenApi31(t *testing.T) {
+	f := func(ctx context.Context, req *openapi31Req) (res *openapi31Res, err error) {
+		return
+	}
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		oai.Config.IgnorePkgPath = true
+		oai.OpenAPI = goai.OpenAPIVersion31
+		t.AssertNil(oai.Add(goai.AddInput{Object: f}))
+
+		j, err := gjson.LoadContent([]byte(oai.String()))
+		t.AssertNil(err)
+		// The schema names contain char '.'.
+		j.SetViolenceCheck(true)
+		t.Assert(j.Get("openapi"), goai.OpenAPIVersion31)
+
+		// Nullable pointer types.
+		var req = j.GetJson("components.schemas.goai_test.openapi31Req.properties")
+		t.Assert(req.Get("name.type"), []string{"string", "null"})
+		t.Assert(req.Get("name.nullable"), nil)
+		t.Assert(req.Get("tags.type"), "array")
+		t.Assert(req.Get("address.anyOf.0.$ref"), "#/components/schemas/goai_test.openapi31Address")
+		t.Assert(req.Get("address.anyOf.1.type"), "null")
+		t.Assert(req.Get("address.description"), "Address")
+		// JSON Schema 2020-12 keywords.
+		t.Assert(req.Get("age.exclusiveMinimum"), 0)
+		t.Assert(req.Get("age.minimum"), nil)
+		t.Assert(req.Get("age.examples"), []int{3})
+		t.Assert(req.Get("age.example"), nil)
+		t.Assert(req.Get("kind.const"), "pet")
+		t.Assert(req.Get("score.examples"), []int{1, 2})
+
+		// Composition.
+		var res = j.GetJson("components.schemas.goai_test.openapi31Res")
+		t.Assert(res.Get("type"), nil)
+		t.Assert(res.Get("properties"), nil)
+		t.Assert(res.Get("oneOf.0.$ref"), "#/components/schemas/goai_test.openapi31Cat")
+		t.Assert(res.Get("oneOf.1.$ref"), "#/components/schemas/goai_test.openapi31Dog")
+		t.Assert(res.Get("discriminator.propertyName"), "petType")
+		t.Assert(res.Get("discriminator.mapping").MapStrStr(), g.MapStrStr{
+			"cat": "#/components/schemas/goai_test.openapi31Cat",
+			"dog": "#/components/schemas/goai_test.openapi31Dog",
+		})
+		t.Assert(j.Get("components.schemas.goai_test.openapi31Cat.properties.lives.type"), "integer")
+	})
+}
+
+func Test_OpenApi30_Compatible(t *testing.T) {
+	f := func(ctx context.Context, req *openapi31Req) (res *openapi31AnyOfRes, err error) {
+		return
+	}
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		oai.Config.IgnorePkgPath = true
+		t.AssertNil(oai.Add(goai.AddInput{Object: f}))
+
+		j, err := gjson.LoadContent([]byte(oai.String()))
+		t.AssertNil(err)
+		// The schema names contain char '.'.
+		j.SetViolenceCheck(true)
+		t.Assert(j.Get("openapi"), goai.OpenAPIVersion30)
+
+		var req = j.GetJson("components.schemas.goai_test.openapi31Req.properties")
+		t.Assert(req.Get("name.type"), "string")
+		t.Assert(req.Get("address.$ref"), "#/components/schemas/goai_test.openapi31Address")
+		t.Assert(req.Get("age.exclusiveMinimum"), true)
+		t.Assert(req.Get("age.minimum"), 0)
+		t.Assert(req.Get("age.example"), 3)
+
+		var res = j.GetJson("components.schemas.goai_test.openapi31AnyOfRes")
+		t.Assert(res.Get("anyOf.0.$ref"), "#/components/schemas/goai_test.openapi31Cat")
+		t.Assert(res.Get("anyOf.1.$ref"), "#/components/schemas/goai_test.openapi31Dog")
+		t.Assert(res.Get("discriminator"), nil)
+	})
+}
+
+func Test_OpenApi31_InvalidComposition(t *testing.T) {
+	type Res struct {
+		g.Meta `oneOf:"cat,bird"`
+		Cat    *openapi31Cat `json:"cat"`
+	}
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		t.AssertNE(oai.Add(goai.AddInput{Object: Res{}}), nil)
+	})
+}
@@ -0,0 +1,134 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package goai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/goai"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+type validateItem struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type validateReq struct {
+	g.Meta `path:"/user/{id}" method:"post"`
+	Id     int    `json:"id" in:"path"`
+	Name   string `json:"name" v:"required"`
+	Role   string `json:"role" v:"in:admin,guest"`
+}
+
+type validateRes struct {
+	Total int             `json:"total"`
+	Items []*validateItem `json:"items"`
+	Extra map[string]int  `json:"extra"`
+}
+
+func Test_ValidateResponse(t *testing.T) {
+	f := func(ctx context.Context, req *validateReq) (res *validateRes, err error) {
+		return
+	}
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		t.AssertNil(oai.Add(goai.AddInput{Object: f}))
+		t.AssertNE(oai.GetOperation("/user/{id}", "post"), nil)
+		t.Assert(oai.GetOperation("/user/{id}", "get"), nil)
+
+		var in = goai.ValidateInput{
+			Path:        "/user/{id}",
+			Method:      "POST",
+			Status:      200,
+			ContentType: "application/json; charset=utf-8",
+		}
+		// Valid.
+		in.Body = []byte(`{"total":1,"items":[{"id":1,"name":"john"},null],"extra":{"a":1}}`)
+		t.AssertNil(oai.ValidateResponse(in))
+		in.Body = []byte(`{"total":0,"items":null}`)
+		t.AssertNil(oai.ValidateResponse(in))
+
+		// Drifts.
+		in.Body = []byte(`{"total":"1","items":[{"id":1.5,"age":1}],"extra":{"a":"1"},"more":true}`)
+		err := oai.ValidateResponse(in)
+		t.Assert(gerror.Code(err), gcode.CodeValidationFailed)
+		t.Assert(err.Error(), `$.extra.a: expected type "integer" but got string; `+
+			`$.items[0].age: property is not documented; `+
+			`$.items[0].id: expected type "integer" but got number; `+
+			`$.more: property is not documented; `+
+			`$.total: expected type "integer" but got string`)
+
+		// Status.
+		in.Status = 201
+		in.Body = nil
+		t.Assert(gerror.Code(oai.ValidateResponse(in)), gcode.CodeValidationFailed)
+
+		// Not json.
+		in.Status = 200
+		in.ContentType = "text/plain"
+		in.Body = []byte(`ok`)
+		t.AssertNil(oai.ValidateResponse(in))
+
+		// Not documented operation.
+		in.Method = "PUT"
+		t.Assert(gerror.Code(oai.ValidateResponse(in)), gcode.CodeValidationFailed)
+	})
+}
+
+func Test_ValidateRequest(t *testing.T) {
+	f := func(ctx context.Context, req *validateReq) (res *validateRes, err error) {
+		return
+	}
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		t.AssertNil(oai.Add(goai.AddInput{Object: f}))
+
+		var in = goai.ValidateInput{
+			Path:   "/user/{id}",
+			Method: "POST",
+		}
+		// Additional properties are allowed.
+		in.Body = []byte(`{"name":"john","role":"admin","other":1}`)
+		t.AssertNil(oai.ValidateRequest(in))
+		// Form content without type.
+		in.Body = []byte(`name=john`)
+		t.AssertNil(oai.ValidateRequest(in))
+
+		in.Body = []byte(`{"role":"root"}`)
+		err := oai.ValidateRequest(in)
+		t.Assert(gerror.Code(err), gcode.CodeValidationFailed)
+		t.Assert(err.Error(), `$: required property "name" is missing; `+
+			`$.role: value "root" is not in enum [admin guest]`)
+	})
+}
+
+func Test_Validate_Composition(t *testing.T) {
+	f := func(ctx context.Context, req *validateReq) (res *openapi31Res, err error) {
+		return
+	}
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		t.AssertNil(oai.Add(goai.AddInput{Object: f}))
+
+		var in = goai.ValidateInput{
+			Path:   "/user/{id}",
+			Method: "POST",
+			Status: 200,
+		}
+		in.Body = []byte(`{"petType":"cat","lives":9}`)
+		t.AssertNil(oai.ValidateResponse(in))
+		in.Body = []byte(`{"petType":"dog","barks":true}`)
+		t.AssertNil(oai.ValidateResponse(in))
+		in.Body = []byte(`{"petType":"bird","wings":2}`)
+		t.Assert(oai.ValidateResponse(in).Error(), `$: value matches 0 of oneOf schemas`)
+	})
+}
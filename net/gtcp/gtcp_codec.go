@@ -0,0 +1,242 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// DefaultFrameMaxLength is the default max length in bytes of frame data.
+	DefaultFrameMaxLength = 1 << 20
+)
+
+// FrameCodec is the interface for splitting the TCP stream into frames.
+type FrameCodec interface {
+	// ReadFrame reads and returns the data of next frame from `reader`.
+	ReadFrame(reader *bufio.Reader) ([]byte, error)
+
+	// WriteFrame writes `data` as a frame to `writer`.
+	WriteFrame(writer io.Writer, data []byte) error
+}
+
+// LengthFieldCodec is the frame codec of which the frame is prefixed with the data length,
+// the data length is an unsigned integer of HeaderSize bytes encoded using BigEndian order.
+type LengthFieldCodec struct {
+	headerSize int // Header size in bytes, which is 1, 2, 4 or 8.
+	maxLength  int // Max length of frame data.
+}
+
+// DelimiterCodec is the frame codec of which the frame is terminated with the delimiter,
+// note that the frame data should not contain the delimiter.
+type DelimiterCodec struct {
+	delimiter []byte // Delimiter terminating the frame.
+	maxLength int    // Max length of frame data.
+}
+
+// VarintCodec is the frame codec of which the frame is prefixed with the data length in unsigned varint,
+// which is the same as the length delimited message format of protobuf.
+type VarintCodec struct {
+	maxLength int // Max length of frame data.
+}
+
+// NewLengthFieldCodec creates and returns a length-prefixed frame codec.
+// The parameter `headerSize` should be 1, 2, 4 or 8, or else it uses 4 as the header size.
+// The optional parameter `maxLength` specifies the max length of frame data,
+// which is DefaultFrameMaxLength in default and also limited by the header size.
+func NewLengthFieldCodec(headerSize int, maxLength ...int) *LengthFieldCodec {
+	switch headerSize {
+	case 1, 2, 4, 8:
+	default:
+		headerSize = 4
+	}
+	c := &LengthFieldCodec{
+		headerSize: headerSize,
+		maxLength:  getFrameMaxLength(maxLength...),
+	}
+	if headerSize < 8 {
+		if headerMax := uint64(1)<<(headerSize*8) - 1; uint64(c.maxLength) > headerMax {
+			c.maxLength = int(headerMax)
+		}
+	}
+	return c
+}
+
+// ReadFrame reads and returns the data of next frame from `reader`.
+func (c *LengthFieldCodec) ReadFrame(reader *bufio.Reader) ([]byte, error) {
+	header := make([]byte, c.headerSize)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, err
+	}
+	var length uint64
+	switch c.headerSize {
+	case 1:
+		length = uint64(header[0])
+	case 2:
+		length = uint64(binary.BigEndian.Uint16(header))
+	case 4:
+		length = uint64(binary.BigEndian.Uint32(header))
+	default:
+		length = binary.BigEndian.Uint64(header)
+	}
+	return readFrameData(reader, length, c.maxLength)
+}
+
+// WriteFrame writes `data` as a frame to `writer`.
+func (c *LengthFieldCodec) WriteFrame(writer io.Writer, data []byte) error {
+	if err := checkFrameLength(len(data), c.maxLength); err != nil {
+		return err
+	}
+	buffer := make([]byte, c.headerSize+len(data))
+	switch c.headerSize {
+	case 1:
+		buffer[0] = byte(len(data))
+	case 2:
+		binary.BigEndian.PutUint16(buffer, uint16(len(data)))
+	case 4:
+		binary.BigEndian.PutUint32(buffer, uint32(len(data)))
+	default:
+		binary.BigEndian.PutUint64(buffer, uint64(len(data)))
+	}
+	copy(buffer[c.headerSize:], data)
+	return writeFrame(writer, buffer)
+}
+
+// NewDelimiterCodec creates and returns a delimiter terminated frame codec.
+// It uses "\n" as delimiter if given `delimiter` is empty.
+// The optional parameter `maxLength` specifies the max length of frame data,
+// which is DefaultFrameMaxLength in default.
+func NewDelimiterCodec(delimiter []byte, maxLength ...int) *DelimiterCodec {
+	if len(delimiter) == 0 {
+		delimiter = []byte{'\n'}
+	}
+	return &DelimiterCodec{
+		delimiter: delimiter,
+		maxLength: getFrameMaxLength(maxLength...),
+	}
+}
+
+// ReadFrame reads and returns the data of next frame from `reader`, the delimiter is not included.
+func (c *DelimiterCodec) ReadFrame(reader *bufio.Reader) ([]byte, error) {
+	var (
+		buffer    []byte
+		lastByte  = c.delimiter[len(c.delimiter)-1]
+		maxLength = c.maxLength + len(c.delimiter)
+	)
+	for {
+		data, err := reader.ReadSlice(lastByte)
+		buffer = append(buffer, data...)
+		if len(buffer) > maxLength {
+			return nil, gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`frame data size exceeds allowed max length %d`, c.maxLength,
+			)
+		}
+		switch err {
+		case nil:
+			if bytes.HasSuffix(buffer, c.delimiter) {
+				return buffer[:len(buffer)-len(c.delimiter)], nil
+			}
+		case bufio.ErrBufferFull:
+		default:
+			return nil, err
+		}
+	}
+}
+
+// WriteFrame writes `data` as a frame to `writer`.
+func (c *DelimiterCodec) WriteFrame(writer io.Writer, data []byte) error {
+	if err := checkFrameLength(len(data), c.maxLength); err != nil {
+		return err
+	}
+	if bytes.Contains(data, c.delimiter) {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `frame data should not contain the delimiter`)
+	}
+	buffer := make([]byte, 0, len(data)+len(c.delimiter))
+	buffer = append(buffer, data...)
+	buffer = append(buffer, c.delimiter...)
+	return writeFrame(writer, buffer)
+}
+
+// NewVarintCodec creates and returns a varint length-prefixed frame codec.
+// The optional parameter `maxLength` specifies the max length of frame data,
+// which is DefaultFrameMaxLength in default.
+func NewVarintCodec(maxLength ...int) *VarintCodec {
+	return &VarintCodec{
+		maxLength: getFrameMaxLength(maxLength...),
+	}
+}
+
+// ReadFrame reads and returns the data of next frame from `reader`.
+func (c *VarintCodec) ReadFrame(reader *bufio.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	return readFrameData(reader, length, c.maxLength)
+}
+
+// WriteFrame writes `data` as a frame to `writer`.
+func (c *VarintCodec) WriteFrame(writer io.Writer, data []byte) error {
+	if err := checkFrameLength(len(data), c.maxLength); err != nil {
+		return err
+	}
+	buffer := make([]byte, 0, binary.MaxVarintLen64+len(data))
+	buffer = binary.AppendUvarint(buffer, uint64(len(data)))
+	buffer = append(buffer, data...)
+	return writeFrame(writer, buffer)
+}
+
+// getFrameMaxLength returns the max length of frame data from optional parameter `maxLength`.
+func getFrameMaxLength(maxLength ...int) int {
+	if len(maxLength) > 0 && maxLength[0] > 0 {
+		return maxLength[0]
+	}
+	return DefaultFrameMaxLength
+}
+
+// checkFrameLength checks whether the frame data `length` exceeds `maxLength`.
+func checkFrameLength(length, maxLength int) error {
+	if length > maxLength {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`frame data size %d exceeds allowed max length %d`,
+			length, maxLength,
+		)
+	}
+	return nil
+}
+
+// readFrameData reads frame data of `length` from `reader`.
+func readFrameData(reader *bufio.Reader, length uint64, maxLength int) ([]byte, error) {
+	if length > uint64(maxLength) {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`frame data size %d exceeds allowed max length %d`,
+			length, maxLength,
+		)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// writeFrame writes the whole frame `buffer` to `writer`.
+func writeFrame(writer io.Writer, buffer []byte) error {
+	if _, err := writer.Write(buffer); err != nil {
+		return gerror.Wrap(err, `write frame failed`)
+	}
+	return nil
+}
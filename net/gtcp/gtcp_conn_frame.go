@@ -0,0 +1,56 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp
+
+import "time"
+
+// defaultFrameCodec is the default frame codec, which is length-prefixed with 4 bytes header.
+var defaultFrameCodec = NewLengthFieldCodec(4)
+
+// SendFrame sends `data` as a frame using `codec`.
+// The optional parameter `codec` specifies the frame codec, which is 4 bytes length-prefixed codec in default.
+func (c *Conn) SendFrame(data []byte, codec ...FrameCodec) error {
+	return getFrameCodec(codec...).WriteFrame(c.Conn, data)
+}
+
+// RecvFrame receives and returns the data of next frame using `codec`.
+// The optional parameter `codec` specifies the frame codec, which is 4 bytes length-prefixed codec in default.
+func (c *Conn) RecvFrame(codec ...FrameCodec) ([]byte, error) {
+	return getFrameCodec(codec...).ReadFrame(c.reader)
+}
+
+// SendFrameWithTimeout sends `data` as a frame using `codec` with write timeout.
+func (c *Conn) SendFrameWithTimeout(data []byte, timeout time.Duration, codec ...FrameCodec) (err error) {
+	if err = c.SetDeadlineSend(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+	defer func() {
+		_ = c.SetDeadlineSend(time.Time{})
+	}()
+	err = c.SendFrame(data, codec...)
+	return
+}
+
+// RecvFrameWithTimeout receives and returns the data of next frame using `codec` with read timeout.
+func (c *Conn) RecvFrameWithTimeout(timeout time.Duration, codec ...FrameCodec) (data []byte, err error) {
+	if err = c.SetDeadlineRecv(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = c.SetDeadlineRecv(time.Time{})
+	}()
+	data, err = c.RecvFrame(codec...)
+	return
+}
+
+// getFrameCodec returns the frame codec from optional parameter `codec`.
+func getFrameCodec(codec ...FrameCodec) FrameCodec {
+	if len(codec) > 0 && codec[0] != nil {
+		return codec[0]
+	}
+	return defaultFrameCodec
+}
@@ -7,12 +7,16 @@
 package gtcp
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/text/gstr"
@@ -30,11 +34,18 @@ const (
 
 // Server is a TCP server.
 type Server struct {
-	mu        sync.Mutex   // Used for Server.listen concurrent safety. -- The golang test with data race checks this.
-	listen    net.Listener // TCP address listener.
-	address   string       // Server listening address.
-	handler   func(*Conn)  // Connection handler.
-	tlsConfig *tls.Config  // TLS configuration.
+	mu           sync.Mutex      // Used for Server.listen concurrent safety. -- The golang test with data race checks this.
+	listen       net.Listener    // TCP address listener.
+	address      string          // Server listening address.
+	handler      func(*Conn)     // Connection handler.
+	tlsConfig    *tls.Config     // TLS configuration.
+	frameConfig  FrameConfig     // Frame mode configuration.
+	frameHandler FrameHandler    // Frame handler, which is not nil if the server is in frame mode.
+	frameConns   *gmap.IntAnyMap // Registry of frame connections, id -> *FrameConn.
+	frameConnId  *gtype.Int      // Id sequence for frame connections.
+	activeConns  *gset.Set       // All active connections handled by server.
+	connWg       sync.WaitGroup  // Wait group for active connections, which is used for graceful shutdown.
+	shutdown     *gtype.Bool     // Whether the server is shutting down.
 }
 
 // Map for name to server, for singleton purpose.
@@ -57,8 +68,12 @@ func GetServer(name ...any) *Server {
 // The parameter `name` is optional, which is used to specify the instance name of the server.
 func NewServer(address string, handler func(*Conn), name ...string) *Server {
 	s := &Server{
-		address: address,
-		handler: handler,
+		address:     address,
+		handler:     handler,
+		frameConns:  gmap.NewIntAnyMap(true),
+		frameConnId: gtype.NewInt(),
+		activeConns: gset.New(true),
+		shutdown:    gtype.NewBool(),
 	}
 	if len(name) > 0 && name[0] != "" {
 		serverMapping.Set(name[0], s)
@@ -130,6 +145,7 @@ func (s *Server) Run() (err error) {
 		err = gerror.NewCode(gcode.CodeMissingConfiguration, "start running failed: socket handler not defined")
 		return
 	}
+	s.shutdown.Set(false)
 	if s.tlsConfig != nil {
 		// TLS Server
 		s.mu.Lock()
@@ -158,14 +174,72 @@ func (s *Server) Run() (err error) {
 	for {
 		var conn net.Conn
 		if conn, err = s.listen.Accept(); err != nil {
+			if s.shutdown.Val() {
+				return nil
+			}
 			err = gerror.Wrapf(err, `Listener.Accept failed`)
 			return err
 		} else if conn != nil {
-			go s.handler(NewConnByNetConn(conn))
+			s.mu.Lock()
+			if s.shutdown.Val() {
+				s.mu.Unlock()
+				_ = conn.Close()
+				return nil
+			}
+			s.connWg.Add(1)
+			s.mu.Unlock()
+			go s.serveConn(NewConnByNetConn(conn))
 		}
 	}
 }
 
+// serveConn handles the connection `conn` using the handler of server.
+func (s *Server) serveConn(conn *Conn) {
+	defer s.connWg.Done()
+	s.activeConns.Add(conn)
+	defer s.activeConns.Remove(conn)
+	s.handler(conn)
+}
+
+// Shutdown gracefully shuts down the server without interrupting any active connections.
+// It closes the listener, and then waits for the active connections to be handled done.
+// For server in frame mode, the connections are closed after their handling frames are done.
+// For server in normal mode, it waits the connection handlers to return.
+//
+// If the context `ctx` expires before the shutdown is complete, it closes all active connections
+// forcibly and returns the context's error.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.shutdown.Set(true)
+	var err error
+	if s.listen != nil {
+		err = s.listen.Close()
+	}
+	s.mu.Unlock()
+
+	// Interrupt the frame reading of connections.
+	s.frameConns.Iterator(func(_ int, v any) bool {
+		_ = v.(*FrameConn).Conn.Conn.SetReadDeadline(time.Now())
+		return true
+	})
+	done := make(chan struct{})
+	go func() {
+		s.connWg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return err
+
+	case <-ctx.Done():
+		s.activeConns.Iterator(func(v any) bool {
+			_ = v.(*Conn).Close()
+			return true
+		})
+		return ctx.Err()
+	}
+}
+
 // GetListenedAddress retrieves and returns the address string which are listened by current server.
 func (s *Server) GetListenedAddress() string {
 	if !gstr.Contains(s.address, FreePortAddress) {
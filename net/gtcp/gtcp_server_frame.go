@@ -0,0 +1,262 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+// FrameHandler is the handler for frames received from connection.
+// The frames of the same connection are handled in sequence.
+type FrameHandler func(conn *FrameConn, data []byte)
+
+// FrameConfig is the configuration for frame mode of server.
+type FrameConfig struct {
+	// Codec splits the TCP stream into frames, it is 4 bytes length-prefixed codec in default.
+	Codec FrameCodec
+
+	// IdleTimeout closes the connection if no frame is received in this duration.
+	// The heartbeat frames received also keep the connection alive. It is disabled if it is 0.
+	IdleTimeout time.Duration
+
+	// WriteTimeout is the timeout for writing a frame to connection. It is disabled if it is 0.
+	WriteTimeout time.Duration
+
+	// HeartbeatInterval is the interval sending heartbeat frame to connections. It is disabled if it is 0.
+	HeartbeatInterval time.Duration
+
+	// HeartbeatData is the data of heartbeat frame, which is empty frame in default.
+	// The frames of the same data received from connections are considered as heartbeats
+	// and not passed to handler, if HeartbeatInterval is not 0 or HeartbeatData is not nil.
+	HeartbeatData []byte
+
+	// OnConnect is called after connection is accepted and before its frames are handled.
+	OnConnect func(conn *FrameConn)
+
+	// OnClose is called after the connection is closed.
+	OnClose func(conn *FrameConn)
+}
+
+// FrameConn is the connection of server in frame mode, which is registered in server
+// for broadcasting and retrieving by id.
+type FrameConn struct {
+	*Conn                        // Underlying TCP connection object.
+	id        int                // Unique id in server.
+	server    *Server            // Belonged server.
+	codec     FrameCodec         // Frame codec.
+	writeMu   sync.Mutex         // Mutex for writing frames concurrently.
+	metadata  *gmap.StrAnyMap    // Custom metadata of connection.
+	closed    *gtype.Bool        // Whether the connection is closed.
+	closeChan chan struct{}      // Closed when the connection is closed.
+	ctx       context.Context    // Context of connection, which is done when the connection is closed.
+	cancel    context.CancelFunc // Cancel function for ctx.
+}
+
+// NewFrameServer creates and returns a TCP server in frame mode, which reads frames from connections
+// using codec and calls `handler` for each frame. See FrameConfig.
+// The parameter `name` is optional, which is used to specify the instance name of the server.
+func NewFrameServer(address string, config FrameConfig, handler FrameHandler, name ...string) *Server {
+	s := NewServer(address, nil, name...)
+	s.SetFrameHandler(config, handler)
+	return s
+}
+
+// SetFrameHandler sets the server in frame mode using `config` and frame `handler`,
+// which replaces the connection handler of server.
+func (s *Server) SetFrameHandler(config FrameConfig, handler FrameHandler) {
+	if config.Codec == nil {
+		config.Codec = defaultFrameCodec
+	}
+	s.frameConfig = config
+	s.frameHandler = handler
+	s.handler = s.serveFrameConn
+}
+
+// GetConn returns the frame connection of `id`, it returns nil if it does not exist.
+func (s *Server) GetConn(id int) *FrameConn {
+	if v := s.frameConns.Get(id); v != nil {
+		return v.(*FrameConn)
+	}
+	return nil
+}
+
+// GetConns returns all the frame connections of server, which are sorted by their ids.
+func (s *Server) GetConns() []*FrameConn {
+	var conns = make([]*FrameConn, 0, s.frameConns.Size())
+	s.frameConns.Iterator(func(_ int, v any) bool {
+		conns = append(conns, v.(*FrameConn))
+		return true
+	})
+	sort.Slice(conns, func(i, j int) bool {
+		return conns[i].id < conns[j].id
+	})
+	return conns
+}
+
+// GetConnCount returns the count of frame connections of server.
+func (s *Server) GetConnCount() int {
+	return s.frameConns.Size()
+}
+
+// Broadcast sends `data` as a frame to all frame connections of server.
+// The optional parameter `filter` specifies which connections are sent to.
+// It sends to all the connections even if some of them fail, and returns the first error.
+func (s *Server) Broadcast(data []byte, filter ...func(conn *FrameConn) bool) error {
+	var firstErr error
+	for _, conn := range s.GetConns() {
+		if len(filter) > 0 && filter[0] != nil && !filter[0](conn) {
+			continue
+		}
+		if err := conn.SendFrame(data); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// serveFrameConn reads frames from `conn` and calls the frame handler in sequence.
+func (s *Server) serveFrameConn(conn *Conn) {
+	var (
+		config    = s.frameConfig
+		frameConn = s.newFrameConn(conn)
+		heartbeat = config.HeartbeatInterval > 0 || config.HeartbeatData != nil
+	)
+	s.frameConns.Set(frameConn.id, frameConn)
+	defer func() {
+		s.frameConns.Remove(frameConn.id)
+		_ = frameConn.Close()
+		if config.OnClose != nil {
+			config.OnClose(frameConn)
+		}
+	}()
+	if config.OnConnect != nil {
+		config.OnConnect(frameConn)
+	}
+	if config.HeartbeatInterval > 0 {
+		go frameConn.keepHeartbeat(config.HeartbeatInterval, config.HeartbeatData)
+	}
+	for !frameConn.closed.Val() {
+		if config.IdleTimeout > 0 {
+			_ = conn.Conn.SetReadDeadline(time.Now().Add(config.IdleTimeout))
+		} else {
+			_ = conn.Conn.SetReadDeadline(time.Time{})
+		}
+		// It checks the shutdown after the deadline set, so that the shutdown interrupting is not overwritten.
+		if s.shutdown.Val() {
+			return
+		}
+		data, err := conn.RecvFrame(config.Codec)
+		if err != nil {
+			if !s.shutdown.Val() && !frameConn.closed.Val() && !isConnClosedError(err) {
+				intlog.Errorf(frameConn.ctx, `read frame from "%s" failed: %+v`, conn.RemoteAddr(), err)
+			}
+			return
+		}
+		if heartbeat && bytes.Equal(data, config.HeartbeatData) {
+			continue
+		}
+		s.frameHandler(frameConn, data)
+	}
+}
+
+// newFrameConn creates and returns a frame connection for `conn`.
+func (s *Server) newFrameConn(conn *Conn) *FrameConn {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &FrameConn{
+		Conn:      conn,
+		id:        s.frameConnId.Add(1),
+		server:    s,
+		codec:     s.frameConfig.Codec,
+		metadata:  gmap.NewStrAnyMap(true),
+		closed:    gtype.NewBool(),
+		closeChan: make(chan struct{}),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// Id returns the unique id of connection in server.
+func (c *FrameConn) Id() int {
+	return c.id
+}
+
+// Server returns the server that the connection belongs to.
+func (c *FrameConn) Server() *Server {
+	return c.server
+}
+
+// Context returns the context of connection, which is done when the connection is closed.
+func (c *FrameConn) Context() context.Context {
+	return c.ctx
+}
+
+// Metadata returns the custom metadata map of connection, which is concurrent-safe.
+func (c *FrameConn) Metadata() *gmap.StrAnyMap {
+	return c.metadata
+}
+
+// SendFrame sends `data` as a frame using the codec of server.
+// It is concurrent-safe, and it overwrites the Conn.SendFrame using the codec of server.
+func (c *FrameConn) SendFrame(data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if timeout := c.server.frameConfig.WriteTimeout; timeout > 0 {
+		_ = c.Conn.Conn.SetWriteDeadline(time.Now().Add(timeout))
+		defer func() {
+			_ = c.Conn.Conn.SetWriteDeadline(time.Time{})
+		}()
+	}
+	return c.codec.WriteFrame(c.Conn.Conn, data)
+}
+
+// Close closes the connection, it is safe to be called multiple times.
+func (c *FrameConn) Close() error {
+	if !c.closed.Cas(false, true) {
+		return nil
+	}
+	close(c.closeChan)
+	c.cancel()
+	return c.Conn.Close()
+}
+
+// keepHeartbeat sends heartbeat frame in `interval` until the connection is closed.
+func (c *FrameConn) keepHeartbeat(interval time.Duration, data []byte) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.closeChan:
+			return
+		case <-ticker.C:
+			if err := c.SendFrame(data); err != nil {
+				intlog.Errorf(c.ctx, `send heartbeat to "%s" failed: %+v`, c.RemoteAddr(), err)
+				_ = c.Close()
+				return
+			}
+		}
+	}
+}
+
+// isConnClosedError checks whether `err` is caused by connection closed or read timeout.
+func isConnClosedError(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, net.ErrClosed) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,256 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/net/gtcp"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Frame_Codec(t *testing.T) {
+	codecs := []gtcp.FrameCodec{
+		gtcp.NewLengthFieldCodec(1),
+		gtcp.NewLengthFieldCodec(2),
+		gtcp.NewLengthFieldCodec(4),
+		gtcp.NewLengthFieldCodec(8),
+		gtcp.NewDelimiterCodec([]byte("\r\n")),
+		gtcp.NewVarintCodec(),
+	}
+	gtest.C(t, func(t *gtest.T) {
+		frames := [][]byte{[]byte("hello"), {}, bytes.Repeat([]byte("x"), 200)}
+		for _, codec := range codecs {
+			buffer := bytes.NewBuffer(nil)
+			for _, frame := range frames {
+				t.AssertNil(codec.WriteFrame(buffer, frame))
+			}
+			reader := bufio.NewReaderSize(buffer, 16)
+			for _, frame := range frames {
+				data, err := codec.ReadFrame(reader)
+				t.AssertNil(err)
+				t.Assert(data, frame)
+			}
+		}
+	})
+	// Exceeding max length.
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNE(gtcp.NewLengthFieldCodec(1).WriteFrame(bytes.NewBuffer(nil), make([]byte, 256)), nil)
+		t.AssertNE(gtcp.NewVarintCodec(10).WriteFrame(bytes.NewBuffer(nil), make([]byte, 11)), nil)
+
+		buffer := bytes.NewBuffer(nil)
+		t.AssertNil(gtcp.NewVarintCodec().WriteFrame(buffer, make([]byte, 11)))
+		_, err := gtcp.NewVarintCodec(10).ReadFrame(bufio.NewReader(buffer))
+		t.AssertNE(err, nil)
+
+		buffer = bytes.NewBufferString("0123456789abc\n")
+		_, err = gtcp.NewDelimiterCodec(nil, 10).ReadFrame(bufio.NewReaderSize(buffer, 16))
+		t.AssertNE(err, nil)
+	})
+	// Delimiter in data.
+	gtest.C(t, func(t *gtest.T) {
+		err := gtcp.NewDelimiterCodec(nil).WriteFrame(bytes.NewBuffer(nil), []byte("a\nb"))
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Frame_Server_Echo(t *testing.T) {
+	var (
+		codec     = gtcp.NewDelimiterCodec(nil)
+		connected = gtype.NewInt()
+		closed    = gtype.NewInt()
+	)
+	s := gtcp.NewFrameServer(gtcp.FreePortAddress, gtcp.FrameConfig{
+		Codec: codec,
+		OnConnect: func(conn *gtcp.FrameConn) {
+			connected.Add(1)
+		},
+		OnClose: func(conn *gtcp.FrameConn) {
+			closed.Add(1)
+		},
+	}, func(conn *gtcp.FrameConn, data []byte) {
+		_ = conn.SendFrame(append([]byte("echo:"), data...))
+	})
+	go s.Run()
+	defer s.Close()
+	time.Sleep(simpleTimeout)
+
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := gtcp.NewConn(s.GetListenedAddress())
+		t.AssertNil(err)
+		for i := 0; i < 10; i++ {
+			t.AssertNil(conn.SendFrame([]byte("hello"), codec))
+			data, err := conn.RecvFrame(codec)
+			t.AssertNil(err)
+			t.Assert(data, "echo:hello")
+		}
+		t.Assert(connected.Val(), 1)
+		t.Assert(s.GetConnCount(), 1)
+		t.AssertNil(conn.Close())
+		time.Sleep(simpleTimeout)
+		t.Assert(closed.Val(), 1)
+		t.Assert(s.GetConnCount(), 0)
+	})
+}
+
+func Test_Frame_Server_Broadcast(t *testing.T) {
+	s := gtcp.NewFrameServer(gtcp.FreePortAddress, gtcp.FrameConfig{}, func(conn *gtcp.FrameConn, data []byte) {
+		conn.Metadata().Set("name", string(data))
+	})
+	go s.Run()
+	defer s.Close()
+	time.Sleep(simpleTimeout)
+
+	gtest.C(t, func(t *gtest.T) {
+		var conns []*gtcp.Conn
+		for _, name := range []string{"john", "smith", "alice"} {
+			conn, err := gtcp.NewConn(s.GetListenedAddress())
+			t.AssertNil(err)
+			defer conn.Close()
+			t.AssertNil(conn.SendFrame([]byte(name)))
+			conns = append(conns, conn)
+		}
+		time.Sleep(simpleTimeout)
+		t.Assert(s.GetConnCount(), 3)
+
+		serverConns := s.GetConns()
+		t.Assert(len(serverConns), 3)
+		t.Assert(s.GetConn(serverConns[0].Id()), serverConns[0])
+		t.Assert(s.GetConn(-1), nil)
+
+		// Broadcast to all.
+		t.AssertNil(s.Broadcast([]byte("all")))
+		for _, conn := range conns {
+			data, err := conn.RecvFrame()
+			t.AssertNil(err)
+			t.Assert(data, "all")
+		}
+
+		// Broadcast with filter.
+		t.AssertNil(s.Broadcast([]byte("only"), func(conn *gtcp.FrameConn) bool {
+			return conn.Metadata().Get("name") == "smith"
+		}))
+		data, err := conns[1].RecvFrameWithTimeout(time.Second)
+		t.AssertNil(err)
+		t.Assert(data, "only")
+		_, err = conns[0].RecvFrameWithTimeout(simpleTimeout)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Frame_Server_Timeout(t *testing.T) {
+	var (
+		heartbeat = []byte("ping")
+		received  = gtype.NewInt()
+	)
+	s := gtcp.NewFrameServer(gtcp.FreePortAddress, gtcp.FrameConfig{
+		IdleTimeout:       3 * simpleTimeout,
+		HeartbeatInterval: simpleTimeout,
+		HeartbeatData:     heartbeat,
+	}, func(conn *gtcp.FrameConn, data []byte) {
+		received.Add(1)
+	})
+	go s.Run()
+	defer s.Close()
+	time.Sleep(simpleTimeout)
+
+	// Heartbeats from client keep the connection alive, and are not passed to handler.
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := gtcp.NewConn(s.GetListenedAddress())
+		t.AssertNil(err)
+		defer conn.Close()
+		for i := 0; i < 5; i++ {
+			data, err := conn.RecvFrame()
+			t.AssertNil(err)
+			t.Assert(data, heartbeat)
+			t.AssertNil(conn.SendFrame(heartbeat))
+		}
+		t.Assert(s.GetConnCount(), 1)
+		t.Assert(received.Val(), 0)
+	})
+	// Idle connection is closed.
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := gtcp.NewConn(s.GetListenedAddress())
+		t.AssertNil(err)
+		defer conn.Close()
+		time.Sleep(5 * simpleTimeout)
+		t.Assert(s.GetConnCount(), 0)
+	})
+}
+
+func Test_Frame_Server_Shutdown(t *testing.T) {
+	var (
+		handled  = gtype.NewInt()
+		closed   = gtype.NewInt()
+		runError = make(chan error, 1)
+	)
+	s := gtcp.NewFrameServer(gtcp.FreePortAddress, gtcp.FrameConfig{
+		OnClose: func(conn *gtcp.FrameConn) {
+			closed.Add(1)
+		},
+	}, func(conn *gtcp.FrameConn, data []byte) {
+		time.Sleep(2 * simpleTimeout)
+		handled.Add(1)
+	})
+	go func() {
+		runError <- s.Run()
+	}()
+	time.Sleep(simpleTimeout)
+
+	gtest.C(t, func(t *gtest.T) {
+		address := s.GetListenedAddress()
+		for i := 0; i < 2; i++ {
+			conn, err := gtcp.NewConn(address)
+			t.AssertNil(err)
+			defer conn.Close()
+			t.AssertNil(conn.SendFrame([]byte("job")))
+		}
+		time.Sleep(simpleTimeout)
+
+		// It waits for the handling frames done.
+		t.AssertNil(s.Shutdown(context.Background()))
+		t.Assert(handled.Val(), 2)
+		t.Assert(closed.Val(), 2)
+		t.Assert(s.GetConnCount(), 0)
+		t.AssertNil(<-runError)
+
+		_, err := gtcp.NewConn(address)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Frame_Server_ShutdownTimeout(t *testing.T) {
+	s := gtcp.NewServer(gtcp.FreePortAddress, func(conn *gtcp.Conn) {
+		defer conn.Close()
+		for {
+			if _, err := conn.Recv(-1); err != nil {
+				break
+			}
+		}
+	})
+	go s.Run()
+	time.Sleep(simpleTimeout)
+
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := gtcp.NewConn(s.GetListenedAddress())
+		t.AssertNil(err)
+		defer conn.Close()
+		time.Sleep(simpleTimeout)
+
+		// The handler in normal mode does not return, so the connection is closed forcibly.
+		ctx, cancel := context.WithTimeout(context.Background(), simpleTimeout)
+		defer cancel()
+		t.Assert(s.Shutdown(ctx), context.DeadlineExceeded)
+		_, err = conn.RecvWithTimeout(-1, time.Second)
+		t.AssertNE(err, nil)
+	})
+}
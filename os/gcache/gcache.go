@@ -33,6 +33,19 @@ func Set(ctx context.Context, key any, value any, duration time.Duration) error
 	return defaultCache.Set(ctx, key, value, duration)
 }
 
+// SetWithTags sets cache with `key`-`value` pair bound to `tags`, which is expired after `duration`.
+//
+// It does not expire if `duration` == 0.
+// It deletes the `key` if `duration` < 0 or given `value` is nil.
+func SetWithTags(ctx context.Context, key any, value any, duration time.Duration, tags []string) error {
+	return defaultCache.SetWithTags(ctx, key, value, duration, tags)
+}
+
+// InvalidateTags deletes all keys bound to any of `tags` in the default cache.
+func InvalidateTags(ctx context.Context, tags ...string) error {
+	return defaultCache.InvalidateTags(ctx, tags...)
+}
+
 // SetMap batch sets cache with key-value pairs by `data` map, which is expired after `duration`.
 //
 // It does not expire if `duration` == 0.
@@ -140,3 +140,20 @@ type Adapter interface {
 	// Close closes the cache if necessary.
 	Close(ctx context.Context) error
 }
+
+// TagAdapter is the optional interface for Adapter supporting tag-based invalidation,
+// which is implemented by AdapterMemory and AdapterRedis.
+type TagAdapter interface {
+	Adapter
+
+	// SetWithTags sets cache with `key`-`value` pair bound to `tags`, which is expired after `duration`.
+	// The tags previously bound to `key` are replaced by `tags`. The tags are kept if `key` is set again
+	// without tags, and they are unbound as `key` is removed, expired or invalidated.
+	//
+	// It does not expire if `duration` == 0.
+	// It deletes the `key` if `duration` < 0 or given `value` is nil.
+	SetWithTags(ctx context.Context, key any, value any, duration time.Duration, tags []string) error
+
+	// InvalidateTags deletes all keys bound to any of `tags`.
+	InvalidateTags(ctx context.Context, tags ...string) error
+}
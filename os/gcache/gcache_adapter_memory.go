@@ -25,6 +25,7 @@ type AdapterMemory struct {
 	expireTimes *memoryExpireTimes // expireTimes is the expiring key to its timestamp mapping, which is used for quick indexing and deleting.
 	expireSets  *memoryExpireSets  // expireSets is the expiring timestamp to its key set mapping, which is used for quick indexing and deleting.
 	lru         *memoryLru         // lru is the LRU manager, which is enabled when attribute cap > 0.
+	tags        *memoryTags        // tags is the tag to keys index, which is used for invalidating keys by tags.
 	eventList   *glist.List        // eventList is the asynchronous event list for internal data synchronization.
 	closed      *gtype.Bool        // closed controls the cache closed or not.
 }
@@ -59,6 +60,7 @@ func doNewAdapterMemory() *AdapterMemory {
 		data:        newMemoryData(),
 		expireTimes: newMemoryExpireTimes(),
 		expireSets:  newMemoryExpireSets(),
+		tags:        newMemoryTags(),
 		eventList:   glist.New(true),
 		closed:      gtype.NewBool(),
 	}
@@ -86,6 +88,33 @@ func (c *AdapterMemory) Set(ctx context.Context, key any, value any, duration ti
 	return nil
 }
 
+// SetWithTags sets cache with `key`-`value` pair bound to `tags`, which is expired after `duration`.
+// The tags previously bound to `key` are replaced by `tags`. The tags are kept if `key` is set again
+// using Set without tags, and they are unbound as `key` is removed, expired or invalidated.
+//
+// It does not expire if `duration` == 0.
+// It deletes the `key` if `duration` < 0 or given `value` is nil.
+func (c *AdapterMemory) SetWithTags(ctx context.Context, key any, value any, duration time.Duration, tags []string) error {
+	if value == nil || duration < 0 {
+		_, err := c.Remove(ctx, key)
+		return err
+	}
+	if err := c.Set(ctx, key, value, duration); err != nil {
+		return err
+	}
+	c.tags.Set(key, tags)
+	return nil
+}
+
+// InvalidateTags deletes all keys bound to any of `tags`.
+func (c *AdapterMemory) InvalidateTags(ctx context.Context, tags ...string) error {
+	if keys := c.tags.Pop(tags...); len(keys) > 0 {
+		_, err := c.Remove(ctx, keys...)
+		return err
+	}
+	return nil
+}
+
 // SetMap batch sets cache with key-value pairs by `data` map, which is expired after `duration`.
 //
 // It does not expire if `duration` == 0.
@@ -297,6 +326,7 @@ func (c *AdapterMemory) doRemove(_ context.Context, keys ...any) (*gvar.Var, err
 	if err != nil {
 		return nil, err
 	}
+	c.tags.Remove(removedKeys...)
 	for _, key := range removedKeys {
 		c.eventList.PushBack(&adapterMemoryEvent{
 			k: key,
@@ -364,6 +394,7 @@ func (c *AdapterMemory) Values(ctx context.Context) ([]any, error) {
 func (c *AdapterMemory) Clear(ctx context.Context) error {
 	c.data.Clear()
 	c.lru.Clear()
+	c.tags.Clear()
 	return nil
 }
 
@@ -481,4 +512,6 @@ func (c *AdapterMemory) deleteExpiredKey(key any) {
 	c.data.Delete(key)
 	// Deleting its expiration time from `expireTimes`.
 	c.expireTimes.Delete(key)
+	// Deleting its tags from `tags`.
+	c.tags.Remove(key)
 }
@@ -0,0 +1,92 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+import (
+	"sync"
+)
+
+// memoryTags is the tag to keys index for AdapterMemory.
+type memoryTags struct {
+	mu      sync.Mutex                  // mu ensures the concurrent safety of the index.
+	tagKeys map[string]map[any]struct{} // tagKeys is the tag to its key set mapping.
+	keyTags map[any][]string            // keyTags is the key to its tags mapping, which is used for quick deleting.
+}
+
+func newMemoryTags() *memoryTags {
+	return &memoryTags{
+		tagKeys: make(map[string]map[any]struct{}),
+		keyTags: make(map[any][]string),
+	}
+}
+
+// Set binds `key` to `tags`, which replaces the tags previously bound to `key`.
+func (t *memoryTags) Set(key any, tags []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.doRemove(key)
+	if len(tags) == 0 {
+		return
+	}
+	for _, tag := range tags {
+		keySet, ok := t.tagKeys[tag]
+		if !ok {
+			keySet = make(map[any]struct{})
+			t.tagKeys[tag] = keySet
+		}
+		keySet[key] = struct{}{}
+	}
+	t.keyTags[key] = tags
+}
+
+// Remove unbinds `keys` from their tags.
+func (t *memoryTags) Remove(keys ...any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, key := range keys {
+		t.doRemove(key)
+	}
+}
+
+// Pop unbinds and returns all keys bound to `tags`.
+func (t *memoryTags) Pop(tags ...string) []any {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var keys []any
+	for _, tag := range tags {
+		for key := range t.tagKeys[tag] {
+			keys = append(keys, key)
+			t.doRemove(key)
+		}
+	}
+	return keys
+}
+
+// Clear deletes all tags.
+func (t *memoryTags) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tagKeys = make(map[string]map[any]struct{})
+	t.keyTags = make(map[any][]string)
+}
+
+// doRemove unbinds `key` from its tags without lock.
+func (t *memoryTags) doRemove(key any) {
+	tags, ok := t.keyTags[key]
+	if !ok {
+		return
+	}
+	for _, tag := range tags {
+		if keySet, ok := t.tagKeys[tag]; ok {
+			delete(keySet, key)
+			if len(keySet) == 0 {
+				delete(t.tagKeys, tag)
+			}
+		}
+	}
+	delete(t.keyTags, key)
+}
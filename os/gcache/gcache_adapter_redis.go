@@ -8,13 +8,68 @@ package gcache
 
 import (
 	"context"
+	"strings"
 	"time"
 
+	"github.com/gogf/gf/v2/container/gset"
 	"github.com/gogf/gf/v2/container/gvar"
 	"github.com/gogf/gf/v2/database/gredis"
 	"github.com/gogf/gf/v2/util/gconv"
 )
 
+const (
+	// redisTagKeyPrefix is the key prefix of redis sets storing the keys of tags.
+	redisTagKeyPrefix = "gcache:tag:"
+
+	// redisTagIndexKeyPrefix is the key prefix of redis sets storing the tag sets of keys,
+	// which is used for replacing the tags of key and checking whether the key is still bound to tags.
+	redisTagIndexKeyPrefix = "gcache:keytags:"
+
+	// redisTagIndexScript replaces the tag sets in index KEYS[1] with ARGV[2...], and returns the previous ones.
+	// The index expires after ARGV[1] milliseconds, which means no expiration if it is 0.
+	// It only operates on the index, so it is safe in redis cluster mode.
+	redisTagIndexScript = `
+local previous = redis.call('SMEMBERS', KEYS[1])
+redis.call('DEL', KEYS[1])
+if #ARGV > 1 then
+	redis.call('SADD', KEYS[1], unpack(ARGV, 2))
+	if tonumber(ARGV[1]) > 0 then
+		redis.call('PEXPIRE', KEYS[1], ARGV[1])
+	end
+end
+return previous
+`
+
+	// redisTagAddScript adds key ARGV[1] to tag set KEYS[1], and extends the expiration of the tag set
+	// to ARGV[2] milliseconds, which means no expiration if it is 0.
+	redisTagAddScript = `
+local ttl = tonumber(ARGV[2])
+local existed = redis.call('EXISTS', KEYS[1])
+redis.call('SADD', KEYS[1], ARGV[1])
+if ttl == 0 then
+	redis.call('PERSIST', KEYS[1])
+else
+	local pttl = redis.call('PTTL', KEYS[1])
+	if existed == 0 or (pttl >= 0 and pttl < ttl) then
+		redis.call('PEXPIRE', KEYS[1], ttl)
+	end
+end
+return 1
+`
+
+	// redisTagDeleteScript deletes key KEYS[1] and its index KEYS[2] if the index contains tag set ARGV[1],
+	// and returns the tag sets in the index. It returns empty if the key is removed, expired or re-tagged.
+	// The key and its index are in the same slot, so it is safe in redis cluster mode.
+	redisTagDeleteScript = `
+if redis.call('SISMEMBER', KEYS[2], ARGV[1]) == 0 then
+	return {}
+end
+local tagKeys = redis.call('SMEMBERS', KEYS[2])
+redis.call('DEL', KEYS[1], KEYS[2])
+return tagKeys
+`
+)
+
 // AdapterRedis is the gcache adapter implements using Redis server.
 type AdapterRedis struct {
 	redis *gredis.Redis
@@ -45,6 +100,105 @@ func (c *AdapterRedis) Set(ctx context.Context, key any, value any, duration tim
 	return err
 }
 
+// SetWithTags sets cache with `key`-`value` pair bound to `tags`, which is expired after `duration`.
+// The tags previously bound to `key` are replaced by `tags`. The tags are kept if `key` is set again
+// using Set without tags, and they are unbound as `key` is removed, expired or invalidated.
+// Note that the binding expires after `duration` of SetWithTags, even if `key` is set again
+// with longer duration without tags.
+//
+// The keys of each tag are stored in a redis set named with prefix "gcache:tag:",
+// which expires no earlier than its keys. The tags of each key are stored in a redis set
+// named with prefix "gcache:keytags:", which expires along with the key.
+// Each redis command or script operates on keys of the same slot, so it works in redis cluster mode,
+// but the binding is not atomic across the key and its tag sets.
+//
+// It does not expire if `duration` == 0.
+// It deletes the `key` if `duration` < 0 or given `value` is nil.
+func (c *AdapterRedis) SetWithTags(ctx context.Context, key any, value any, duration time.Duration, tags []string) (err error) {
+	if value == nil || duration < 0 {
+		_, err = c.Remove(ctx, key)
+		return
+	}
+	if err = c.Set(ctx, key, value, duration); err != nil {
+		return
+	}
+	var (
+		redisKey = gconv.String(key)
+		tagKeys  = make([]any, 0, len(tags))
+		args     = make([]any, 0, len(tags)+1)
+	)
+	for _, tag := range tags {
+		tagKeys = append(tagKeys, redisTagKeyPrefix+tag)
+	}
+	args = append(args, duration.Milliseconds())
+	args = append(args, tagKeys...)
+	previous, err := c.redis.Eval(ctx, redisTagIndexScript, 1, []string{redisTagIndexKey(redisKey)}, args)
+	if err != nil {
+		return
+	}
+	var newTagKeys = gset.NewStrSetFrom(gconv.Strings(tagKeys))
+	for _, tagKey := range previous.Strings() {
+		if newTagKeys.Contains(tagKey) {
+			continue
+		}
+		if _, err = c.redis.SRem(ctx, tagKey, redisKey); err != nil {
+			return err
+		}
+	}
+	for _, tagKey := range newTagKeys.Slice() {
+		_, err = c.redis.Eval(
+			ctx, redisTagAddScript, 1, []string{tagKey}, []any{redisKey, duration.Milliseconds()},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InvalidateTags deletes all keys bound to any of `tags`, and the tag sets as well.
+// The keys are deleted one by one along with their indexes, so it works in redis cluster mode,
+// but it is not atomic. The keys bound to `tags` during invalidating might be kept.
+func (c *AdapterRedis) InvalidateTags(ctx context.Context, tags ...string) (err error) {
+	var (
+		members      gvar.Vars
+		boundTagKeys *gvar.Var
+	)
+	for _, tag := range tags {
+		var tagKey = redisTagKeyPrefix + tag
+		if members, err = c.redis.SMembers(ctx, tagKey); err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			continue
+		}
+		var redisKeys = members.Strings()
+		for _, redisKey := range redisKeys {
+			boundTagKeys, err = c.redis.Eval(
+				ctx, redisTagDeleteScript, 2,
+				[]string{redisKey, redisTagIndexKey(redisKey)}, []any{tagKey},
+			)
+			if err != nil {
+				return err
+			}
+			// Unbinds the deleted key from its other tags.
+			for _, boundTagKey := range boundTagKeys.Strings() {
+				if boundTagKey == tagKey {
+					continue
+				}
+				if _, err = c.redis.SRem(ctx, boundTagKey, redisKey); err != nil {
+					return err
+				}
+			}
+		}
+		// It removes the invalidated keys only, keeping the keys bound during invalidating.
+		if _, err = c.redis.SRem(ctx, tagKey, redisKeys[0], gconv.Interfaces(redisKeys[1:])...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SetMap batch sets cache with key-value pairs by `data` map, which is expired after `duration`.
 //
 // It does not expire if `duration` == 0.
@@ -419,11 +573,27 @@ func (c *AdapterRedis) Remove(ctx context.Context, keys ...any) (lastValue *gvar
 	if lastValue, err = c.redis.Get(ctx, gconv.String(keys[len(keys)-1])); err != nil {
 		return nil, err
 	}
-	// Deletes all given keys.
-	_, err = c.redis.Del(ctx, gconv.Strings(keys)...)
+	// Deletes all given keys and their tag indexes, which unbinds the keys from their tags.
+	var redisKeys = gconv.Strings(keys)
+	for _, key := range gconv.Strings(keys) {
+		redisKeys = append(redisKeys, redisTagIndexKey(key))
+	}
+	_, err = c.redis.Del(ctx, redisKeys...)
 	return
 }
 
+// redisTagIndexKey returns the key of redis set storing the tag sets of `key`.
+// It is in the same slot with `key` in redis cluster mode, as it uses the hash tag of `key`,
+// or `key` itself as hash tag if `key` has no hash tag.
+func redisTagIndexKey(key string) string {
+	if start := strings.IndexByte(key, '{'); start != -1 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			return redisTagIndexKeyPrefix + key
+		}
+	}
+	return redisTagIndexKeyPrefix + "{" + key + "}"
+}
+
 // Clear clears all data of the cache.
 // Note that this function is sensitive and should be carefully used.
 // It uses `FLUSHDB` command in redis server, which might be disabled in server.
@@ -8,7 +8,10 @@ package gcache
 
 import (
 	"context"
+	"time"
 
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/util/gconv"
 )
 
@@ -54,6 +57,42 @@ func (c *Cache) GetAdapter() Adapter {
 	return c.localAdapter
 }
 
+// SetWithTags sets cache with `key`-`value` pair bound to `tags`, which is expired after `duration`.
+// The keys can then be deleted by tags using InvalidateTags.
+// It returns error of code gcode.CodeNotSupported if the adapter does not implement TagAdapter.
+//
+// It does not expire if `duration` == 0.
+// It deletes the `key` if `duration` < 0 or given `value` is nil.
+func (c *Cache) SetWithTags(ctx context.Context, key any, value any, duration time.Duration, tags []string) error {
+	adapter, err := c.getTagAdapter()
+	if err != nil {
+		return err
+	}
+	return adapter.SetWithTags(ctx, key, value, duration, tags)
+}
+
+// InvalidateTags deletes all keys bound to any of `tags` in the cache.
+// It returns error of code gcode.CodeNotSupported if the adapter does not implement TagAdapter.
+func (c *Cache) InvalidateTags(ctx context.Context, tags ...string) error {
+	adapter, err := c.getTagAdapter()
+	if err != nil {
+		return err
+	}
+	return adapter.InvalidateTags(ctx, tags...)
+}
+
+// getTagAdapter returns the adapter as TagAdapter if it implements TagAdapter.
+func (c *Cache) getTagAdapter() (TagAdapter, error) {
+	if adapter, ok := c.localAdapter.(TagAdapter); ok {
+		return adapter, nil
+	}
+	return nil, gerror.NewCodef(
+		gcode.CodeNotSupported,
+		`cache adapter "%T" does not support tags`,
+		c.localAdapter,
+	)
+}
+
 // Removes deletes `keys` in the cache.
 func (c *Cache) Removes(ctx context.Context, keys []any) error {
 	_, err := c.Remove(ctx, keys...)
@@ -622,3 +622,92 @@ func TestCache_NewWithAdapter(t *testing.T) {
 		t.AssertNE(cache, nil)
 	})
 }
+
+func TestCache_Tags(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cache := gcache.New()
+		t.AssertNil(cache.SetWithTags(ctx, "user:1", 1, 0, []string{"user", "user:1"}))
+		t.AssertNil(cache.SetWithTags(ctx, "user:2", 2, 0, []string{"user", "user:2"}))
+		t.AssertNil(cache.SetWithTags(ctx, "order:1", 3, 0, []string{"order"}))
+		t.AssertNil(cache.Set(ctx, "other", 4, 0))
+
+		t.AssertNil(cache.InvalidateTags(ctx, "user:1"))
+		v, _ := cache.Get(ctx, "user:1")
+		t.Assert(v, nil)
+		v, _ = cache.Get(ctx, "user:2")
+		t.Assert(v, 2)
+
+		t.AssertNil(cache.InvalidateTags(ctx, "user", "order"))
+		n, _ := cache.Size(ctx)
+		t.Assert(n, 1)
+		v, _ = cache.Get(ctx, "other")
+		t.Assert(v, 4)
+
+		// Tags are replaced when set again.
+		t.AssertNil(cache.SetWithTags(ctx, "user:1", 1, 0, []string{"user"}))
+		t.AssertNil(cache.SetWithTags(ctx, "user:1", 1, 0, []string{"vip"}))
+		t.AssertNil(cache.InvalidateTags(ctx, "user"))
+		v, _ = cache.Get(ctx, "user:1")
+		t.Assert(v, 1)
+		t.AssertNil(cache.InvalidateTags(ctx, "vip"))
+		v, _ = cache.Get(ctx, "user:1")
+		t.Assert(v, nil)
+	})
+	// Removed key is unbound from its tags.
+	gtest.C(t, func(t *gtest.T) {
+		cache := gcache.New()
+		t.AssertNil(cache.SetWithTags(ctx, "k", 1, 0, []string{"t"}))
+		_, err := cache.Remove(ctx, "k")
+		t.AssertNil(err)
+		t.AssertNil(cache.Set(ctx, "k", 2, 0))
+		t.AssertNil(cache.InvalidateTags(ctx, "t"))
+		v, _ := cache.Get(ctx, "k")
+		t.Assert(v, 2)
+	})
+	// Not supported adapter.
+	gtest.C(t, func(t *gtest.T) {
+		cache := gcache.NewWithAdapter(struct{ gcache.Adapter }{gcache.NewAdapterMemory()})
+		t.AssertNE(cache.SetWithTags(ctx, "k", 1, 0, []string{"t"}), nil)
+		t.AssertNE(cache.InvalidateTags(ctx, "t"), nil)
+	})
+}
+
+func TestCache_Tags_Retag(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cache := gcache.New()
+		// Tags are replaced when set again.
+		t.AssertNil(cache.SetWithTags(ctx, "k", 1, 0, []string{"old", "both"}))
+		t.AssertNil(cache.SetWithTags(ctx, "k", 2, 0, []string{"new", "both"}))
+		t.AssertNil(cache.InvalidateTags(ctx, "old"))
+		v, _ := cache.Get(ctx, "k")
+		t.Assert(v, 2)
+
+		// Tags are kept when set again without tags.
+		t.AssertNil(cache.Set(ctx, "k", 3, 0))
+		t.AssertNil(cache.InvalidateTags(ctx, "new"))
+		v, _ = cache.Get(ctx, "k")
+		t.Assert(v, nil)
+
+		// Invalidated key is unbound from all its tags.
+		t.AssertNil(cache.Set(ctx, "k", 4, 0))
+		t.AssertNil(cache.InvalidateTags(ctx, "both"))
+		v, _ = cache.Get(ctx, "k")
+		t.Assert(v, 4)
+
+		// Empty tags unbind the key.
+		t.AssertNil(cache.SetWithTags(ctx, "k", 5, 0, []string{"t"}))
+		t.AssertNil(cache.SetWithTags(ctx, "k", 6, 0, nil))
+		t.AssertNil(cache.InvalidateTags(ctx, "t"))
+		v, _ = cache.Get(ctx, "k")
+		t.Assert(v, 6)
+
+		// Removed key is unbound from its tags.
+		t.AssertNil(cache.SetWithTags(ctx, "k", 7, 0, []string{"t"}))
+		_, err := cache.Remove(ctx, "k")
+		t.AssertNil(err)
+		t.AssertNil(cache.Set(ctx, "k", 8, 0))
+		t.AssertNil(cache.InvalidateTags(ctx, "t"))
+		v, _ = cache.Get(ctx, "k")
+		t.Assert(v, 8)
+	})
+}
@@ -81,6 +81,38 @@ func (c *Config) GetAdapter() Adapter {
 	return c.adapter
 }
 
+// AddWatcher adds a watcher function `fn` with unique `name`, which is called when configuration changes.
+// It returns error if the adapter of current Config object does not implement WatcherAdapter.
+func (c *Config) AddWatcher(name string, fn WatcherFunc) error {
+	watcherAdapter, ok := c.adapter.(WatcherAdapter)
+	if !ok {
+		return gerror.NewCodef(
+			gcode.CodeNotSupported,
+			`adapter "%T" does not support watching configuration changes`,
+			c.adapter,
+		)
+	}
+	watcherAdapter.AddWatcher(name, fn)
+	return nil
+}
+
+// RemoveWatcher removes the watcher function by `name`.
+// It does nothing if the adapter of current Config object does not implement WatcherAdapter.
+func (c *Config) RemoveWatcher(name string) {
+	if watcherAdapter, ok := c.adapter.(WatcherAdapter); ok {
+		watcherAdapter.RemoveWatcher(name)
+	}
+}
+
+// GetWatcherNames returns all watcher names of the adapter in adding order.
+// It returns nil if the adapter of current Config object does not implement WatcherAdapter.
+func (c *Config) GetWatcherNames() []string {
+	if watcherAdapter, ok := c.adapter.(WatcherAdapter); ok {
+		return watcherAdapter.GetWatcherNames()
+	}
+	return nil
+}
+
 // Available checks and returns the configuration service is available.
 // The optional parameter `pattern` specifies certain configuration resource.
 //
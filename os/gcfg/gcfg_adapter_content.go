@@ -12,19 +12,22 @@ import (
 	"github.com/gogf/gf/v2/container/gvar"
 	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gctx"
 )
 
 // AdapterContent implements interface Adapter using content.
 // The configuration content supports the coding types as package `gjson`.
 type AdapterContent struct {
-	jsonVar *gvar.Var // The pared JSON object for configuration content, type: *gjson.Json.
+	jsonVar  *gvar.Var        // The pared JSON object for configuration content, type: *gjson.Json.
+	watchers *WatcherRegistry // Watchers for configuration changes.
 }
 
 // NewAdapterContent returns a new configuration management object using custom content.
 // The parameter `content` specifies the default configuration content for reading.
 func NewAdapterContent(content ...string) (*AdapterContent, error) {
 	a := &AdapterContent{
-		jsonVar: gvar.New(nil, true),
+		jsonVar:  gvar.New(nil, true),
+		watchers: NewWatcherRegistry(),
 	}
 	if len(content) > 0 {
 		if err := a.SetContent(content[0]); err != nil {
@@ -36,12 +39,17 @@ func NewAdapterContent(content ...string) (*AdapterContent, error) {
 
 // SetContent sets customized configuration content for specified `file`.
 // The `file` is unnecessary param, default is DefaultConfigFile.
+// It notifies all watchers if the configuration content changes.
 func (a *AdapterContent) SetContent(content string) error {
 	j, err := gjson.LoadContent([]byte(content), true)
 	if err != nil {
 		return gerror.Wrap(err, `load configuration content failed`)
 	}
-	a.jsonVar.Set(j)
+	var oldData map[string]any
+	if oldJson, ok := a.jsonVar.Set(j).(*gjson.Json); ok && oldJson != nil {
+		oldData = oldJson.Var().Map()
+	}
+	a.watchers.Notify(gctx.New(), oldData, j.Var().Map())
 	return nil
 }
 
@@ -74,3 +82,18 @@ func (a *AdapterContent) Data(ctx context.Context) (data map[string]any, err err
 	}
 	return a.jsonVar.Val().(*gjson.Json).Var().Map(), nil
 }
+
+// AddWatcher adds a watcher function `fn` with unique `name`, which is called when configuration changes.
+func (a *AdapterContent) AddWatcher(name string, fn WatcherFunc) {
+	a.watchers.AddWatcher(name, fn)
+}
+
+// RemoveWatcher removes the watcher function by `name`.
+func (a *AdapterContent) RemoveWatcher(name string) {
+	a.watchers.RemoveWatcher(name)
+}
+
+// GetWatcherNames returns all watcher names in adding order.
+func (a *AdapterContent) GetWatcherNames() []string {
+	return a.watchers.GetWatcherNames()
+}
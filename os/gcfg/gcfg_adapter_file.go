@@ -11,11 +11,13 @@ import (
 
 	"github.com/gogf/gf/v2/container/garray"
 	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gset"
 	"github.com/gogf/gf/v2/container/gvar"
 	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/internal/command"
 	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gctx"
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/gfsnotify"
 	"github.com/gogf/gf/v2/os/gres"
@@ -29,6 +31,8 @@ type AdapterFile struct {
 	searchPaths           *garray.StrArray // Searching the path array.
 	jsonMap               *gmap.StrAnyMap  // The pared JSON objects for configuration files.
 	violenceCheck         bool             // Whether it does violence check in value index searching. It affects the performance when set true(false in default).
+	watchedPaths          *gset.StrSet     // Monitored configuration files, which are only added to monitor once.
+	watchers              *WatcherRegistry // Watchers for configuration file changes.
 }
 
 const (
@@ -70,6 +74,8 @@ func NewAdapterFile(fileNameOrPath ...string) (*AdapterFile, error) {
 		defaultFileNameOrPath: usedFileNameOrPath,
 		searchPaths:           garray.NewStrArray(true),
 		jsonMap:               gmap.NewStrAnyMap(true),
+		watchedPaths:          gset.NewStrSet(true),
+		watchers:              NewWatcherRegistry(),
 	}
 	// Customized dir path from env/cmd.
 	if customPath := command.GetOptWithEnv(commandEnvKeyForPath); customPath != "" {
@@ -277,9 +283,14 @@ func (a *AdapterFile) getJson(fileNameOrPath ...string) (configJson *gjson.Json,
 		configJson.SetViolenceCheck(a.violenceCheck)
 		// Add monitor for this configuration file,
 		// any changes of this file will refresh its cache in the Config object.
+		// The monitor is added only once, as the file is reloaded after each change.
 		if filePath != "" && !gres.Contains(filePath) {
-			_, err = gfsnotify.Add(filePath, func(event *gfsnotify.Event) {
-				a.jsonMap.Remove(usedFileNameOrPath)
+			a.watchedPaths.AddIfNotExistFuncLock(usedFileNameOrPath+"@"+filePath, func() bool {
+				_, err = gfsnotify.Add(filePath, func(event *gfsnotify.Event) {
+					oldJson := a.jsonMap.Remove(usedFileNameOrPath)
+					a.notifyWatchers(usedFileNameOrPath, oldJson)
+				})
+				return err == nil
 			})
 			if err != nil {
 				return nil
@@ -292,3 +303,44 @@ func (a *AdapterFile) getJson(fileNameOrPath ...string) (configJson *gjson.Json,
 	}
 	return
 }
+
+// AddWatcher adds a watcher function `fn` with unique `name`,
+// which is called when the content of the configuration file changes.
+func (a *AdapterFile) AddWatcher(name string, fn WatcherFunc) {
+	a.watchers.AddWatcher(name, fn)
+}
+
+// RemoveWatcher removes the watcher function by `name`.
+func (a *AdapterFile) RemoveWatcher(name string) {
+	a.watchers.RemoveWatcher(name)
+}
+
+// GetWatcherNames returns all watcher names in adding order.
+func (a *AdapterFile) GetWatcherNames() []string {
+	return a.watchers.GetWatcherNames()
+}
+
+// notifyWatchers reloads the configuration file `fileNameOrPath` and notifies all watchers
+// with changes compared with previous cached content `oldJson`.
+func (a *AdapterFile) notifyWatchers(fileNameOrPath string, oldJson any) {
+	if a.watchers.IsEmpty() {
+		return
+	}
+	var (
+		ctx     = gctx.New()
+		oldData map[string]any
+		newData map[string]any
+	)
+	if j, ok := oldJson.(*gjson.Json); ok && j != nil {
+		oldData = j.Var().Map()
+	}
+	newJson, err := a.getJson(fileNameOrPath)
+	if err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+		return
+	}
+	if newJson != nil {
+		newData = newJson.Var().Map()
+	}
+	a.watchers.Notify(ctx, oldData, newData)
+}
@@ -14,8 +14,12 @@ import (
 
 // SetContent sets customized configuration content for specified `file`.
 // The `file` is unnecessary param, default is DefaultConfigFile.
+// It notifies the watchers of adapters which cached `file` if the configuration content changes.
 func (a *AdapterFile) SetContent(content string, fileNameOrPath ...string) {
-	var usedFileNameOrPath = DefaultConfigFileName
+	var (
+		usedFileNameOrPath = DefaultConfigFileName
+		oldJsonMap         = make(map[*AdapterFile]any)
+	)
 	if len(fileNameOrPath) > 0 {
 		usedFileNameOrPath = fileNameOrPath[0]
 	}
@@ -25,13 +29,22 @@ func (a *AdapterFile) SetContent(content string, fileNameOrPath ...string) {
 			for _, v := range m {
 				if configInstance, ok := v.(*Config); ok {
 					if fileConfig, ok := configInstance.GetAdapter().(*AdapterFile); ok {
-						fileConfig.jsonMap.Remove(usedFileNameOrPath)
+						oldJsonMap[fileConfig] = fileConfig.jsonMap.Remove(usedFileNameOrPath)
 					}
 				}
 			}
 		}
 		customConfigContentMap.Set(usedFileNameOrPath, content)
 	})
+	if _, ok := oldJsonMap[a]; !ok {
+		oldJsonMap[a] = a.jsonMap.Remove(usedFileNameOrPath)
+	}
+	// The watchers are notified out of the lock, as they might retrieve the configuration instances.
+	for fileConfig, oldJson := range oldJsonMap {
+		if oldJson != nil {
+			fileConfig.notifyWatchers(usedFileNameOrPath, oldJson)
+		}
+	}
 }
 
 // GetContent returns customized configuration content for specified `file`.
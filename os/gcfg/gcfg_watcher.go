@@ -0,0 +1,214 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+// WatcherAdapter is the interface for configuration adapters that support watching configuration changes.
+type WatcherAdapter interface {
+	Adapter
+
+	// AddWatcher adds a watcher function `fn` with unique `name`, which is called when configuration changes.
+	// It overwrites the previous watcher if the `name` already exists.
+	AddWatcher(name string, fn WatcherFunc)
+
+	// RemoveWatcher removes the watcher function by `name`.
+	RemoveWatcher(name string)
+
+	// GetWatcherNames returns all watcher names in adding order.
+	GetWatcherNames() []string
+}
+
+// WatcherFunc is the callback function for configuration changes.
+type WatcherFunc func(ctx context.Context, event ConfigChangeEvent)
+
+// ConfigChangeType is the type of configuration key change.
+type ConfigChangeType string
+
+const (
+	ConfigChangeTypeAdded   ConfigChangeType = "added"   // The key is newly added.
+	ConfigChangeTypeUpdated ConfigChangeType = "updated" // The value of the key is updated.
+	ConfigChangeTypeDeleted ConfigChangeType = "deleted" // The key is deleted.
+)
+
+// ConfigChange describes the change of a single configuration key.
+type ConfigChange struct {
+	Key      string           // Key path like "x.y.z" or "x.0.y".
+	Type     ConfigChangeType // Change type.
+	OldValue any              // Value before change, which is nil for added key.
+	NewValue any              // Value after change, which is nil for deleted key.
+}
+
+// ConfigChangeEvent is the event passed to WatcherFunc when configuration changes.
+type ConfigChangeEvent struct {
+	Changes []ConfigChange // Changed keys ordered by key path.
+}
+
+// Keys returns all changed key paths of the event.
+func (e ConfigChangeEvent) Keys() []string {
+	keys := make([]string, len(e.Changes))
+	for i, change := range e.Changes {
+		keys[i] = change.Key
+	}
+	return keys
+}
+
+// Get returns the change of given key path `key`.
+// The returned `ok` is false if `key` is not changed.
+func (e ConfigChangeEvent) Get(key string) (change ConfigChange, ok bool) {
+	for _, change = range e.Changes {
+		if change.Key == key {
+			return change, true
+		}
+	}
+	return ConfigChange{}, false
+}
+
+// WatcherRegistry manages watcher functions for adapters, which is concurrent safe.
+// Adapters commonly hold it in an unexported field and delegate the watcher managing methods of
+// WatcherAdapter to it.
+type WatcherRegistry struct {
+	watchers *gmap.ListMap // Watcher name to WatcherFunc, keeping adding order.
+}
+
+// NewWatcherRegistry creates and returns a new WatcherRegistry.
+func NewWatcherRegistry() *WatcherRegistry {
+	return &WatcherRegistry{
+		watchers: gmap.NewListMap(true),
+	}
+}
+
+// AddWatcher adds a watcher function `fn` with unique `name`.
+// It overwrites the previous watcher if the `name` already exists.
+func (r *WatcherRegistry) AddWatcher(name string, fn WatcherFunc) {
+	r.watchers.Set(name, fn)
+}
+
+// RemoveWatcher removes the watcher function by `name`.
+func (r *WatcherRegistry) RemoveWatcher(name string) {
+	r.watchers.Remove(name)
+}
+
+// GetWatcherNames returns all watcher names in adding order.
+func (r *WatcherRegistry) GetWatcherNames() []string {
+	return gconv.Strings(r.watchers.Keys())
+}
+
+// IsEmpty checks and returns whether there's no watcher in registry.
+func (r *WatcherRegistry) IsEmpty() bool {
+	return r.watchers.IsEmpty()
+}
+
+// Notify compares `oldData` and `newData`, and calls all watcher functions with the changes.
+// It does nothing if there's no watcher or no change.
+func (r *WatcherRegistry) Notify(ctx context.Context, oldData, newData map[string]any) {
+	if r.IsEmpty() {
+		return
+	}
+	changes := diffData(oldData, newData)
+	if len(changes) == 0 {
+		return
+	}
+	var event = ConfigChangeEvent{Changes: changes}
+	// It iterates the snapshot of names, so watchers can be added or removed in watcher functions.
+	for _, name := range r.GetWatcherNames() {
+		fn, ok := r.watchers.Get(name).(WatcherFunc)
+		if !ok {
+			continue
+		}
+		gutil.TryCatch(ctx, func(ctx context.Context) {
+			fn(ctx, event)
+		}, func(ctx context.Context, exception error) {
+			intlog.Errorf(ctx, `config watcher "%s" panics: %+v`, name, exception)
+		})
+	}
+}
+
+// diffData compares `oldData` and `newData`, and returns the changed key paths ordered by key.
+func diffData(oldData, newData map[string]any) []ConfigChange {
+	var (
+		oldFlat = make(map[string]any)
+		newFlat = make(map[string]any)
+		changes = make([]ConfigChange, 0)
+	)
+	flattenData("", oldData, oldFlat)
+	flattenData("", newData, newFlat)
+	for key, oldValue := range oldFlat {
+		newValue, ok := newFlat[key]
+		if !ok {
+			changes = append(changes, ConfigChange{
+				Key:      key,
+				Type:     ConfigChangeTypeDeleted,
+				OldValue: oldValue,
+			})
+			continue
+		}
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, ConfigChange{
+				Key:      key,
+				Type:     ConfigChangeTypeUpdated,
+				OldValue: oldValue,
+				NewValue: newValue,
+			})
+		}
+	}
+	for key, newValue := range newFlat {
+		if _, ok := oldFlat[key]; !ok {
+			changes = append(changes, ConfigChange{
+				Key:      key,
+				Type:     ConfigChangeTypeAdded,
+				NewValue: newValue,
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+// flattenData flattens hierarchical `value` into `result` using key paths like "x.y.z" or "x.0.y".
+func flattenData(prefix string, value any, result map[string]any) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 && prefix != "" {
+			result[prefix] = v
+			return
+		}
+		for key, item := range v {
+			flattenData(joinKeyPath(prefix, key), item, result)
+		}
+	case []any:
+		if len(v) == 0 && prefix != "" {
+			result[prefix] = v
+			return
+		}
+		for index, item := range v {
+			flattenData(joinKeyPath(prefix, gconv.String(index)), item, result)
+		}
+	default:
+		if prefix != "" {
+			result[prefix] = v
+		}
+	}
+}
+
+func joinKeyPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
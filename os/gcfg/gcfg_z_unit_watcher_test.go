@@ -0,0 +1,152 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/os/gcfg"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func TestAdapterContent_Watcher(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		adapter, err := gcfg.NewAdapterContent(`{"a": 1, "b": {"c": 2, "d": 3}}`)
+		t.AssertNil(err)
+
+		var (
+			c      = gcfg.NewWithAdapter(adapter)
+			events = make([]gcfg.ConfigChangeEvent, 0)
+		)
+		err = c.AddWatcher("test", func(ctx context.Context, event gcfg.ConfigChangeEvent) {
+			events = append(events, event)
+		})
+		t.AssertNil(err)
+		t.Assert(c.GetWatcherNames(), []string{"test"})
+
+		err = adapter.SetContent(`{"a": 1, "b": {"c": 20}, "e": 5}`)
+		t.AssertNil(err)
+		t.Assert(len(events), 1)
+		t.Assert(events[0].Keys(), []string{"b.c", "b.d", "e"})
+
+		change, ok := events[0].Get("b.c")
+		t.Assert(ok, true)
+		t.Assert(change.Type, gcfg.ConfigChangeTypeUpdated)
+		t.Assert(change.OldValue, 2)
+		t.Assert(change.NewValue, 20)
+
+		change, ok = events[0].Get("b.d")
+		t.Assert(ok, true)
+		t.Assert(change.Type, gcfg.ConfigChangeTypeDeleted)
+		t.Assert(change.OldValue, 3)
+		t.Assert(change.NewValue, nil)
+
+		change, ok = events[0].Get("e")
+		t.Assert(ok, true)
+		t.Assert(change.Type, gcfg.ConfigChangeTypeAdded)
+		t.Assert(change.NewValue, 5)
+
+		_, ok = events[0].Get("a")
+		t.Assert(ok, false)
+
+		// No change, no notification.
+		err = adapter.SetContent(`{"a": 1, "b": {"c": 20}, "e": 5}`)
+		t.AssertNil(err)
+		t.Assert(len(events), 1)
+
+		c.RemoveWatcher("test")
+		t.Assert(len(c.GetWatcherNames()), 0)
+		err = adapter.SetContent(`{"a": 2}`)
+		t.AssertNil(err)
+		t.Assert(len(events), 1)
+	})
+}
+
+func TestAdapterFile_Watcher(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dirPath  = gfile.Temp(gtime.TimestampNanoStr())
+			filePath = gfile.Join(dirPath, "config.yaml")
+			keys     = garray.NewStrArray(true)
+		)
+		t.AssertNil(gfile.PutContents(filePath, "level: info\nname: test"))
+		defer gfile.Remove(dirPath)
+
+		adapter, err := gcfg.NewAdapterFile(filePath)
+		t.AssertNil(err)
+		c := gcfg.NewWithAdapter(adapter)
+		t.Assert(c.MustGet(ctx, "level"), "info")
+
+		err = c.AddWatcher("test", func(ctx context.Context, event gcfg.ConfigChangeEvent) {
+			keys.Append(event.Keys()...)
+		})
+		t.AssertNil(err)
+
+		t.AssertNil(gfile.PutContents(filePath, "level: debug\nname: test"))
+		time.Sleep(time.Second)
+		t.Assert(keys.Unique().Slice(), []string{"level"})
+		t.Assert(c.MustGet(ctx, "level"), "debug")
+	})
+}
+
+func TestAdapterFile_Watcher_SetContent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			fileName = gtime.TimestampNanoStr() + ".yaml"
+			events   = make([]gcfg.ConfigChangeEvent, 0)
+		)
+		adapter, err := gcfg.NewAdapterFile(fileName)
+		t.AssertNil(err)
+		defer adapter.RemoveContent(fileName)
+
+		adapter.SetContent("level: info\nname: test", fileName)
+		c := gcfg.NewWithAdapter(adapter)
+		t.Assert(c.MustGet(ctx, "level"), "info")
+
+		err = c.AddWatcher("test", func(ctx context.Context, event gcfg.ConfigChangeEvent) {
+			events = append(events, event)
+		})
+		t.AssertNil(err)
+
+		adapter.SetContent("level: debug\nname: test", fileName)
+		t.Assert(len(events), 1)
+		t.Assert(events[0].Keys(), []string{"level"})
+		t.Assert(c.MustGet(ctx, "level"), "debug")
+
+		// No change, no notification.
+		adapter.SetContent("level: debug\nname: test", fileName)
+		t.Assert(len(events), 1)
+	})
+}
+
+func TestConfig_AddWatcher_NotSupported(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c := gcfg.NewWithAdapter(&customAdapter{})
+		err := c.AddWatcher("test", func(ctx context.Context, event gcfg.ConfigChangeEvent) {})
+		t.AssertNE(err, nil)
+		t.Assert(c.GetWatcherNames(), nil)
+	})
+}
+
+type customAdapter struct{}
+
+func (a *customAdapter) Available(ctx context.Context, resource ...string) (ok bool) {
+	return true
+}
+
+func (a *customAdapter) Get(ctx context.Context, pattern string) (value any, err error) {
+	return nil, nil
+}
+
+func (a *customAdapter) Data(ctx context.Context) (data map[string]any, err error) {
+	return nil, nil
+}
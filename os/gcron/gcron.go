@@ -37,6 +37,17 @@ func GetLogger() glog.ILogger {
 	return defaultCron.GetLogger()
 }
 
+// SetLockConfig sets the global configuration for cluster singleton entries.
+// It returns error if the configuration is invalid.
+func SetLockConfig(config LockConfig) error {
+	return defaultCron.SetLockConfig(config)
+}
+
+// SetRecorder sets the Recorder storing run history of entries for default cron object.
+func SetRecorder(recorder Recorder) {
+	defaultCron.SetRecorder(recorder)
+}
+
 // Add adds a timed task to default cron object.
 // A unique `name` can be bound with the timed task.
 // It returns and error if the `name` is already used.
@@ -52,6 +63,13 @@ func AddSingleton(ctx context.Context, pattern string, job JobFunc, name ...stri
 	return defaultCron.AddSingleton(ctx, pattern, job, name...)
 }
 
+// AddClusterSingleton adds a cluster singleton timed task with unique `name`, to default cron object.
+// A cluster singleton timed task is that can only be running one single instance at the same time
+// in the whole cluster. It requires the Locker configured by SetLockConfig.
+func AddClusterSingleton(ctx context.Context, pattern string, job JobFunc, name string) (*Entry, error) {
+	return defaultCron.AddClusterSingleton(ctx, pattern, job, name)
+}
+
 // AddOnce adds a timed task which can be run only once, to default cron object.
 // A unique `name` can be bound with the timed task.
 // It returns and error if the `name` is already used.
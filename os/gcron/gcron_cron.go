@@ -14,26 +14,35 @@ import (
 	"github.com/gogf/gf/v2/container/garray"
 	"github.com/gogf/gf/v2/container/gmap"
 	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/os/glog"
 	"github.com/gogf/gf/v2/os/gtimer"
 )
 
 // Cron stores all the cron job entries.
 type Cron struct {
-	idGen     *gtype.Int64    // Used for unique name generation.
-	status    *gtype.Int      // Timed task status(0: Not Start; 1: Running; 2: Stopped; -1: Closed)
-	entries   *gmap.StrAnyMap // All timed task entries.
-	logger    glog.ILogger    // Logger, it is nil in default.
-	jobWaiter sync.WaitGroup  // Graceful shutdown when cron jobs are stopped.
+	idGen      *gtype.Int64     // Used for unique name generation.
+	status     *gtype.Int       // Timed task status(0: Not Start; 1: Running; 2: Stopped; -1: Closed)
+	entries    *gmap.StrAnyMap  // All timed task entries.
+	logger     glog.ILogger     // Logger, it is nil in default.
+	jobWaiter  sync.WaitGroup   // Graceful shutdown when cron jobs are stopped.
+	lockConfig *gtype.Interface // Lock configuration for cluster singleton entries.
+	recorder   *gtype.Interface // Recorder storing run history of entries.
 }
 
 // New returns a new Cron object with default settings.
 func New() *Cron {
-	return &Cron{
-		idGen:   gtype.NewInt64(),
-		status:  gtype.NewInt(StatusRunning),
-		entries: gmap.NewStrAnyMap(true),
+	c := &Cron{
+		idGen:      gtype.NewInt64(),
+		status:     gtype.NewInt(StatusRunning),
+		entries:    gmap.NewStrAnyMap(true),
+		lockConfig: gtype.NewInterface(),
+		recorder:   gtype.NewInterface(),
 	}
+	c.SetRecorder(NewRecorderMemory())
+	_ = c.SetLockConfig(LockConfig{})
+	return c
 }
 
 // SetLogger sets the logger for cron.
@@ -91,6 +100,33 @@ func (c *Cron) AddSingleton(ctx context.Context, pattern string, job JobFunc, na
 	return c.AddEntry(ctx, pattern, job, -1, true, name...)
 }
 
+// AddClusterSingleton adds a cluster singleton timed task with unique `name`.
+// A cluster singleton timed task is that can only be running one single instance at the same time
+// in the whole cluster, which is implemented using the Locker of LockConfig.
+// The `name` is also used as the lock key, so it should be the same for all nodes of the cluster.
+// It returns and error if the `name` is already used or the Locker is not configured.
+func (c *Cron) AddClusterSingleton(ctx context.Context, pattern string, job JobFunc, name string) (*Entry, error) {
+	if name == "" {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `name is required for cluster singleton cron job`)
+	}
+	if c.GetLockConfig().Locker == nil {
+		return nil, gerror.NewCode(
+			gcode.CodeInvalidConfiguration,
+			`locker is not configured for cluster singleton cron job, please use SetLockConfig to configure it`,
+		)
+	}
+	return c.doAddEntry(doAddEntryInput{
+		Name:               name,
+		Job:                job,
+		Ctx:                ctx,
+		Times:              -1,
+		Pattern:            pattern,
+		IsSingleton:        true,
+		IsClusterSingleton: true,
+		Infinite:           true,
+	})
+}
+
 // AddTimes adds a timed task which can be run specified times.
 // A unique `name` can be bound with the timed task.
 // It returns and error if the `name` is already used.
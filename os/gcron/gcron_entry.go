@@ -19,6 +19,7 @@ import (
 	"github.com/gogf/gf/v2/os/glog"
 	"github.com/gogf/gf/v2/os/gtimer"
 	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/guid"
 )
 
 // JobFunc is the timing called job function in cron.
@@ -32,6 +33,10 @@ type Entry struct {
 	jobName      string        // Callback function name(address info).
 	times        *gtype.Int    // Running times limit.
 	infinite     *gtype.Bool   // No times limit.
+	cluster      *gtype.Bool   // Whether running in cluster singleton mode.
+	acquired     *gtype.Bool   // Whether this node acquired the lock for the latest run in cluster singleton mode.
+	running      *gtype.Int    // Count of running jobs.
+	lastRecord   *gtype.Any    // Record of the latest finished run.
 	Name         string        // Entry name.
 	RegisterTime time.Time     // Registered time.
 	Job          JobFunc       `json:"-"` // Callback function.
@@ -45,6 +50,9 @@ type doAddEntryInput struct {
 	Pattern     string          // Pattern is the crontab style string for scheduler.
 	IsSingleton bool            // Singleton specifies whether timed task executing in singleton mode.
 	Infinite    bool            // Infinite specifies whether this entry is running with no times limit.
+
+	// IsClusterSingleton specifies whether timed task executing in cluster singleton mode.
+	IsClusterSingleton bool
 }
 
 // doAddEntry creates and returns a new Entry object.
@@ -69,6 +77,10 @@ func (c *Cron) doAddEntry(in doAddEntryInput) (*Entry, error) {
 		jobName:      runtime.FuncForPC(reflect.ValueOf(in.Job).Pointer()).Name(),
 		times:        gtype.NewInt(in.Times),
 		infinite:     gtype.NewBool(in.Infinite),
+		cluster:      gtype.NewBool(in.IsClusterSingleton),
+		acquired:     gtype.NewBool(),
+		running:      gtype.NewInt(),
+		lastRecord:   gtype.NewAny(),
 		RegisterTime: time.Now(),
 		Job:          in.Job,
 	}
@@ -94,6 +106,11 @@ func (c *Cron) doAddEntry(in doAddEntryInput) (*Entry, error) {
 	return entry, nil
 }
 
+// Pattern returns the crontab pattern of the entry.
+func (e *Entry) Pattern() string {
+	return e.schedule.pattern
+}
+
 // IsSingleton return whether this entry is a singleton timed task.
 func (e *Entry) IsSingleton() bool {
 	return e.timerEntry.IsSingleton()
@@ -104,6 +121,17 @@ func (e *Entry) SetSingleton(enabled bool) {
 	e.timerEntry.SetSingleton(enabled)
 }
 
+// IsClusterSingleton return whether this entry is a cluster singleton timed task.
+func (e *Entry) IsClusterSingleton() bool {
+	return e.cluster.Val()
+}
+
+// IsAcquired returns whether this node acquired the lock and ran the job for the latest schedule,
+// which is only meaningful for cluster singleton entry.
+func (e *Entry) IsAcquired() bool {
+	return e.acquired.Val()
+}
+
 // SetTimes sets the times which the entry can run.
 func (e *Entry) SetTimes(times int) {
 	e.times.Set(times)
@@ -136,6 +164,43 @@ func (e *Entry) Close() {
 	e.timerEntry.Close()
 }
 
+// Run runs the job of the entry immediately, which is not limited by the schedule, status
+// and running times of the entry. The run is also recorded into the run history.
+// It returns the error converted from the panic of the job, or error if the entry is a singleton
+// that is already running, or the entry is a cluster singleton whose lock is held by other node.
+func (e *Entry) Run(ctx context.Context) error {
+	return e.doRun(ctx, true)
+}
+
+// IsRunning returns whether the job of the entry is running.
+func (e *Entry) IsRunning() bool {
+	return e.running.Val() > 0
+}
+
+// Next returns the next scheduled run time of the entry after now.
+func (e *Entry) Next() time.Time {
+	return e.schedule.Next(time.Now())
+}
+
+// LastRecord returns the record of the latest finished run of the entry.
+// It returns nil if the entry has never run.
+func (e *Entry) LastRecord() *RunRecord {
+	if v := e.lastRecord.Val(); v != nil {
+		record := v.(RunRecord)
+		return &record
+	}
+	return nil
+}
+
+// History returns the run records of the entry from the Recorder of cron, the latest first.
+func (e *Entry) History(ctx context.Context) ([]RunRecord, error) {
+	recorder := e.cron.GetRecorder()
+	if recorder == nil {
+		return nil, nil
+	}
+	return recorder.History(ctx, e.Name)
+}
+
 // checkAndRun is the core timing task check logic.
 // This function is called every second.
 func (e *Entry) checkAndRun(ctx context.Context) {
@@ -155,14 +220,13 @@ func (e *Entry) checkAndRun(ctx context.Context) {
 		e.cron.jobWaiter.Add(1)
 		defer func() {
 			e.cron.jobWaiter.Done()
+			// The panics of job are recovered in runJob,
+			// this is for the ones outside the job, like the Locker of cluster singleton.
 			if exception := recover(); exception != nil {
-				// Exception caught, it logs the error content to logger in default behavior.
 				e.logErrorf(ctx,
 					`cron job "%s(%s)" end with error: %+v`,
 					e.jobName, e.schedule.pattern, exception,
 				)
-			} else {
-				e.logDebugf(ctx, `cron job "%s" ends`, e.getJobNameWithPattern())
 			}
 			if e.timerEntry.Status() == StatusClosed {
 				e.Close()
@@ -178,9 +242,149 @@ func (e *Entry) checkAndRun(ctx context.Context) {
 				}
 			}
 		}
-		e.logDebugf(ctx, `cron job "%s" starts`, e.getJobNameWithPattern())
-		e.Job(ctx)
+		_ = e.doRun(ctx, false)
+	}
+}
+
+// doRun runs the job, in cluster singleton mode if it is configured.
+func (e *Entry) doRun(ctx context.Context, manual bool) error {
+	if e.cluster.Val() {
+		return e.runWithLock(ctx, manual)
+	}
+	return e.runJob(ctx, manual)
+}
+
+// runWithLock runs the job if this node acquires the lock of the entry.
+// It keeps renewing the lease while the job is running, and the context of the job is canceled
+// if the lock is lost during running.
+func (e *Entry) runWithLock(ctx context.Context, manual bool) (err error) {
+	var (
+		config    = e.cron.GetLockConfig()
+		lockKey   = config.Prefix + e.Name
+		lockValue = guid.S()
+		startTime = time.Now()
+	)
+	ok, err := config.Locker.TryLock(ctx, lockKey, lockValue, config.TTL)
+	if err != nil {
+		e.acquired.Set(false)
+		e.logErrorf(ctx, `cron job "%s" acquires lock failed: %+v`, e.getJobNameWithPattern(), err)
+		return err
 	}
+	e.acquired.Set(ok)
+	if !ok {
+		e.logDebugf(ctx, `cron job "%s" skips as lock is held by other node`, e.getJobNameWithPattern())
+		if manual {
+			return gerror.NewCodef(
+				gcode.CodeInvalidOperation,
+				`cron job "%s" is running on other node`,
+				e.Name,
+			)
+		}
+		return nil
+	}
+	var (
+		jobCtx, cancel = context.WithCancel(ctx)
+		renewDone      = make(chan struct{})
+	)
+	defer func() {
+		cancel()
+		<-renewDone
+		// It keeps holding the lock for the minimum duration, or else releases it.
+		var releaseErr error
+		if holdLeft := config.MinHold - time.Since(startTime); holdLeft > 0 {
+			_, releaseErr = config.Locker.Renew(ctx, lockKey, lockValue, holdLeft)
+		} else {
+			releaseErr = config.Locker.Unlock(ctx, lockKey, lockValue)
+		}
+		if releaseErr != nil {
+			e.logErrorf(ctx, `cron job "%s" releases lock failed: %+v`, e.getJobNameWithPattern(), releaseErr)
+		}
+	}()
+	go func() {
+		defer close(renewDone)
+		ticker := time.NewTicker(config.TTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-jobCtx.Done():
+				return
+			case <-ticker.C:
+				renewed, err := config.Locker.Renew(jobCtx, lockKey, lockValue, config.TTL)
+				if err != nil {
+					e.logErrorf(ctx, `cron job "%s" renews lock failed: %+v`, e.getJobNameWithPattern(), err)
+					continue
+				}
+				if !renewed {
+					e.logErrorf(ctx, `cron job "%s" lost lock, job context is canceled`, e.getJobNameWithPattern())
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return e.runJob(jobCtx, manual)
+}
+
+// runJob runs the job and records the run.
+func (e *Entry) runJob(ctx context.Context, manual bool) (err error) {
+	if e.IsSingleton() {
+		// The timer guarantees the singleton for scheduled runs,
+		// the check here is for the manual runs.
+		if !e.running.Cas(0, 1) {
+			if manual {
+				return gerror.NewCodef(
+					gcode.CodeInvalidOperation,
+					`singleton cron job "%s" is already running`,
+					e.Name,
+				)
+			}
+			e.logDebugf(ctx, `cron job "%s" skips as it is already running`, e.getJobNameWithPattern())
+			return nil
+		}
+	} else {
+		e.running.Add(1)
+	}
+	var record = RunRecord{
+		Name:      e.Name,
+		Pattern:   e.schedule.pattern,
+		Manual:    manual,
+		StartTime: time.Now(),
+	}
+	e.handleMetricsBeforeRun(ctx)
+	defer func() {
+		if exception := recover(); exception != nil {
+			if v, ok := exception.(error); ok && gerror.HasStack(v) {
+				err = v
+			} else {
+				err = gerror.NewCodef(gcode.CodeInternalPanic, "%+v", exception)
+			}
+			// Exception caught, it logs the error content to logger in default behavior.
+			e.logErrorf(ctx,
+				`cron job "%s(%s)" end with error: %+v`,
+				e.jobName, e.schedule.pattern, err,
+			)
+		} else {
+			e.logDebugf(ctx, `cron job "%s" ends`, e.getJobNameWithPattern())
+		}
+		e.running.Add(-1)
+		record.EndTime = time.Now()
+		record.Duration = record.EndTime.Sub(record.StartTime)
+		record.Error = err
+		if err != nil {
+			record.ErrorMessage = err.Error()
+		}
+		record.NextTime = e.schedule.Next(record.EndTime)
+		e.lastRecord.Set(record)
+		if recorder := e.cron.GetRecorder(); recorder != nil {
+			if recordErr := recorder.Record(ctx, record); recordErr != nil {
+				e.logErrorf(ctx, `cron job "%s" records run failed: %+v`, e.getJobNameWithPattern(), recordErr)
+			}
+		}
+		e.handleMetricsAfterRun(ctx, record)
+	}()
+	e.logDebugf(ctx, `cron job "%s" starts`, e.getJobNameWithPattern())
+	e.Job(ctx)
+	return
 }
 
 func (e *Entry) getJobNameWithPattern() string {
@@ -0,0 +1,91 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Locker is the distributed lock for cluster singleton entries,
+// which makes the entry running on only one node of the cluster at the same time.
+//
+// The `value` is the unique token of the lock holder, which should be checked
+// in Renew and Unlock to make sure the lock is operated by its holder.
+// Note that the implementer itself should guarantee the concurrent safety of these functions.
+type Locker interface {
+	// TryLock tries acquiring the lock of `key` with lease `ttl`.
+	// It returns true if the lock is acquired, or else false if it is held by others.
+	TryLock(ctx context.Context, key string, value string, ttl time.Duration) (bool, error)
+
+	// Renew resets the lease of lock `key` to `ttl` if it is held by `value`.
+	// It returns false if the lock is not held by `value` anymore.
+	Renew(ctx context.Context, key string, value string, ttl time.Duration) (bool, error)
+
+	// Unlock releases the lock of `key` if it is held by `value`.
+	Unlock(ctx context.Context, key string, value string) error
+}
+
+// LockConfig is the configuration for cluster singleton entries.
+type LockConfig struct {
+	// Locker is the distributed lock for cluster singleton entries.
+	Locker Locker
+
+	// Prefix is the key prefix of locks, which is "gcron:lock:" in default.
+	// The lock key is the prefix joined with entry name.
+	Prefix string
+
+	// TTL is the lease of locks, which is renewed every TTL/3 while the job is running.
+	// It is 30 seconds in default, and it should be no less than 1 second.
+	TTL time.Duration
+
+	// MinHold is the minimum duration holding the lock since the job starts,
+	// which avoids other nodes running the same schedule because of clock skew between nodes.
+	// It is 1 second in default.
+	MinHold time.Duration
+}
+
+const (
+	defaultLockPrefix  = "gcron:lock:"
+	defaultLockTTL     = 30 * time.Second
+	defaultLockMinHold = time.Second
+	minLockTTL         = time.Second // Minimum lock TTL, which avoids renewing the lock too frequently.
+)
+
+// SetLockConfig sets the configuration for cluster singleton entries of cron.
+// It returns error if the configuration is invalid, and the previous configuration is kept.
+func (c *Cron) SetLockConfig(config LockConfig) error {
+	if config.Prefix == "" {
+		config.Prefix = defaultLockPrefix
+	}
+	if config.TTL <= 0 {
+		config.TTL = defaultLockTTL
+	}
+	if config.TTL < minLockTTL {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`lock ttl "%s" should be no less than "%s"`,
+			config.TTL, minLockTTL,
+		)
+	}
+	if config.MinHold <= 0 {
+		config.MinHold = defaultLockMinHold
+	}
+	c.lockConfig.Set(config)
+	return nil
+}
+
+// GetLockConfig returns the configuration for cluster singleton entries of cron.
+func (c *Cron) GetLockConfig() LockConfig {
+	if v := c.lockConfig.Val(); v != nil {
+		return v.(LockConfig)
+	}
+	return LockConfig{}
+}
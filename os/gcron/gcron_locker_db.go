@@ -0,0 +1,162 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+)
+
+// LockerDB is the Locker implements using database table.
+// The table is created automatically if it does not exist.
+//
+// Note that the lease expiration is calculated using the local time of nodes,
+// so the clocks of nodes should be synchronized.
+type LockerDB struct {
+	mu          sync.Mutex // Mutex for table initialization.
+	db          gdb.DB     // Database storing locks.
+	table       string     // Table name storing locks.
+	initialized bool       // Whether the table is initialized.
+}
+
+const (
+	// DefaultLockerTable is the default table name for LockerDB.
+	DefaultLockerTable = "gf_cron_locks"
+
+	lockerFieldKey      = "lock_key"
+	lockerFieldValue    = "lock_value"
+	lockerFieldExpireAt = "expire_at"
+
+	lockerCreateTableSqlDefault = "CREATE TABLE %s (%s VARCHAR(255) NOT NULL PRIMARY KEY, %s VARCHAR(64) NOT NULL, %s BIGINT NOT NULL)"
+	lockerCreateTableSqlOracle  = "CREATE TABLE %s (%s VARCHAR2(255) NOT NULL PRIMARY KEY, %s VARCHAR2(64) NOT NULL, %s NUMBER(19) NOT NULL)"
+)
+
+// NewLockerDB creates and returns a Locker using table of `db`.
+// The optional parameter `table` specifies the table name, which is DefaultLockerTable in default.
+func NewLockerDB(db gdb.DB, table ...string) *LockerDB {
+	l := &LockerDB{
+		db:    db,
+		table: DefaultLockerTable,
+	}
+	if len(table) > 0 && table[0] != "" {
+		l.table = table[0]
+	}
+	return l
+}
+
+// TryLock tries acquiring the lock of `key` with lease `ttl`.
+// It returns true if the lock is acquired, or else false if it is held by others.
+func (l *LockerDB) TryLock(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	if err := l.ensureTable(ctx); err != nil {
+		return false, err
+	}
+	var now = time.Now()
+	// Takes over the expired lock.
+	result, err := l.db.Model(l.table).Ctx(ctx).
+		Data(gdb.Map{
+			lockerFieldValue:    value,
+			lockerFieldExpireAt: now.Add(ttl).UnixMilli(),
+		}).
+		Where(lockerFieldKey, key).
+		WhereLT(lockerFieldExpireAt, now.UnixMilli()).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	if affected, _ := result.RowsAffected(); affected > 0 {
+		return true, nil
+	}
+	// Creates the lock.
+	_, err = l.db.Model(l.table).Ctx(ctx).Data(gdb.Map{
+		lockerFieldKey:      key,
+		lockerFieldValue:    value,
+		lockerFieldExpireAt: now.Add(ttl).UnixMilli(),
+	}).Insert()
+	if err == nil {
+		return true, nil
+	}
+	// It fails inserting if the lock is held by others.
+	count, countErr := l.db.Model(l.table).Ctx(ctx).Where(lockerFieldKey, key).Count()
+	if countErr == nil && count > 0 {
+		return false, nil
+	}
+	return false, err
+}
+
+// Renew resets the lease of lock `key` to `ttl` if it is held by `value`.
+// It returns false if the lock is not held by `value` anymore.
+func (l *LockerDB) Renew(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	if err := l.ensureTable(ctx); err != nil {
+		return false, err
+	}
+	result, err := l.db.Model(l.table).Ctx(ctx).
+		Data(lockerFieldExpireAt, time.Now().Add(ttl).UnixMilli()).
+		Where(lockerFieldKey, key).
+		Where(lockerFieldValue, value).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// Unlock releases the lock of `key` if it is held by `value`.
+func (l *LockerDB) Unlock(ctx context.Context, key string, value string) error {
+	if err := l.ensureTable(ctx); err != nil {
+		return err
+	}
+	_, err := l.db.Model(l.table).Ctx(ctx).
+		Where(lockerFieldKey, key).
+		Where(lockerFieldValue, value).
+		Delete()
+	return err
+}
+
+// ensureTable creates the locks table if it does not exist.
+func (l *LockerDB) ensureTable(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.initialized {
+		return nil
+	}
+	var (
+		core      = l.db.GetCore()
+		tableName = l.db.GetPrefix() + l.table
+	)
+	tables, err := l.db.Tables(ctx)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if table == tableName {
+			l.initialized = true
+			return nil
+		}
+	}
+	var sqlFormat = lockerCreateTableSqlDefault
+	switch l.db.GetConfig().Type {
+	case "oracle", "dm":
+		sqlFormat = lockerCreateTableSqlOracle
+	}
+	_, err = l.db.Exec(ctx, fmt.Sprintf(
+		sqlFormat,
+		core.QuotePrefixTableName(l.table),
+		core.QuoteWord(lockerFieldKey),
+		core.QuoteWord(lockerFieldValue),
+		core.QuoteWord(lockerFieldExpireAt),
+	))
+	if err != nil {
+		return err
+	}
+	l.initialized = true
+	return nil
+}
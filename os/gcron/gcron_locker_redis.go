@@ -0,0 +1,75 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gredis"
+)
+
+// LockerRedis is the Locker implements using Redis server.
+type LockerRedis struct {
+	redis *gredis.Redis
+}
+
+const (
+	// lockerRedisRenewScript resets the expiration of KEYS[1] to ARGV[2] milliseconds
+	// if its value equals to ARGV[1].
+	lockerRedisRenewScript = `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`
+
+	// lockerRedisUnlockScript deletes KEYS[1] if its value equals to ARGV[1].
+	lockerRedisUnlockScript = `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`
+)
+
+// NewLockerRedis creates and returns a Locker using Redis server.
+func NewLockerRedis(redis *gredis.Redis) *LockerRedis {
+	return &LockerRedis{
+		redis: redis,
+	}
+}
+
+// TryLock tries acquiring the lock of `key` with lease `ttl`.
+// It returns true if the lock is acquired, or else false if it is held by others.
+func (l *LockerRedis) TryLock(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	var px = ttl.Milliseconds()
+	v, err := l.redis.Set(ctx, key, value, gredis.SetOption{
+		TTLOption: gredis.TTLOption{PX: &px},
+		NX:        true,
+	})
+	if err != nil {
+		return false, err
+	}
+	return v.String() == "OK", nil
+}
+
+// Renew resets the lease of lock `key` to `ttl` if it is held by `value`.
+// It returns false if the lock is not held by `value` anymore.
+func (l *LockerRedis) Renew(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	v, err := l.redis.Eval(ctx, lockerRedisRenewScript, 1, []string{key}, []any{value, ttl.Milliseconds()})
+	if err != nil {
+		return false, err
+	}
+	return v.Int() == 1, nil
+}
+
+// Unlock releases the lock of `key` if it is held by `value`.
+func (l *LockerRedis) Unlock(ctx context.Context, key string, value string) error {
+	_, err := l.redis.Eval(ctx, lockerRedisUnlockScript, 1, []string{key}, []any{value})
+	return err
+}
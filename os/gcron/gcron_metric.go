@@ -0,0 +1,124 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/os/gmetric"
+)
+
+type localMetricManager struct {
+	CronJobRunActive   gmetric.UpDownCounter
+	CronJobRunTotal    gmetric.Counter
+	CronJobRunDuration gmetric.Histogram
+}
+
+const (
+	instrumentName = "github.com/gogf/gf/v2/os/gcron.Cron"
+
+	metricAttrKeyJobName   = "cron.job.name"
+	metricAttrKeyJobStatus = "cron.job.status"
+	metricAttrKeyJobManual = "cron.job.manual"
+
+	metricJobStatusSuccess = "success"
+	metricJobStatusFailure = "failure"
+)
+
+var (
+	// metricManager for cron metrics.
+	metricManager = newMetricManager()
+)
+
+func newMetricManager() *localMetricManager {
+	meter := gmetric.GetGlobalProvider().Meter(gmetric.MeterOption{
+		Instrument:        instrumentName,
+		InstrumentVersion: gf.VERSION,
+	})
+	mm := &localMetricManager{
+		CronJobRunDuration: meter.MustHistogram(
+			"cron.job.run.duration",
+			gmetric.MetricOption{
+				Help:       "Measures the duration of cron job runs.",
+				Unit:       "ms",
+				Attributes: gmetric.Attributes{},
+				Buckets: []float64{
+					1,
+					10,
+					100,
+					1000,
+					5000,
+					10000,
+					30000,
+					60000,
+					300000,
+					600000,
+					3600000,
+				},
+			},
+		),
+		CronJobRunTotal: meter.MustCounter(
+			"cron.job.run.total",
+			gmetric.MetricOption{
+				Help:       "Total run number of cron jobs.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		CronJobRunActive: meter.MustUpDownCounter(
+			"cron.job.run.active",
+			gmetric.MetricOption{
+				Help:       "Number of running cron jobs.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+	}
+	return mm
+}
+
+func (e *Entry) handleMetricsBeforeRun(ctx context.Context) {
+	if !gmetric.IsEnabled() {
+		return
+	}
+	metricManager.CronJobRunActive.Inc(ctx, gmetric.Option{
+		Attributes: gmetric.Attributes{
+			gmetric.NewAttribute(metricAttrKeyJobName, e.Name),
+		},
+	})
+}
+
+func (e *Entry) handleMetricsAfterRun(ctx context.Context, record RunRecord) {
+	if !gmetric.IsEnabled() {
+		return
+	}
+	var status = metricJobStatusSuccess
+	if record.Error != nil {
+		status = metricJobStatusFailure
+	}
+	metricManager.CronJobRunActive.Dec(ctx, gmetric.Option{
+		Attributes: gmetric.Attributes{
+			gmetric.NewAttribute(metricAttrKeyJobName, e.Name),
+		},
+	})
+	metricManager.CronJobRunTotal.Inc(ctx, gmetric.Option{
+		Attributes: gmetric.Attributes{
+			gmetric.NewAttribute(metricAttrKeyJobName, e.Name),
+			gmetric.NewAttribute(metricAttrKeyJobStatus, status),
+			gmetric.NewAttribute(metricAttrKeyJobManual, record.Manual),
+		},
+	})
+	metricManager.CronJobRunDuration.Record(
+		float64(record.Duration.Milliseconds()),
+		gmetric.Option{
+			Attributes: gmetric.Attributes{
+				gmetric.NewAttribute(metricAttrKeyJobName, e.Name),
+			},
+		},
+	)
+}
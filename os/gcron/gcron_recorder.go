@@ -0,0 +1,151 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RunRecord is the record of one run of cron entry.
+type RunRecord struct {
+	Name      string        `json:"name"`      // Entry name.
+	Pattern   string        `json:"pattern"`   // Crontab pattern of the entry.
+	Manual    bool          `json:"manual"`    // Whether it is triggered manually using Entry.Run.
+	StartTime time.Time     `json:"startTime"` // Time the job starts.
+	EndTime   time.Time     `json:"endTime"`   // Time the job ends.
+	Duration  time.Duration `json:"duration"`  // Running duration of the job.
+	Error     error         `json:"-"`         // Error of the run, which is converted from panic of the job.
+	NextTime  time.Time     `json:"nextTime"`  // Next scheduled run time after this run.
+
+	// ErrorMessage is the message of Error, which is kept for serializing the record.
+	ErrorMessage string `json:"error,omitempty"`
+}
+
+// Recorder is the interface for storing run history of cron entries.
+// Note that the implementer itself should guarantee the concurrent safety of these functions.
+type Recorder interface {
+	// Record stores the run `record` of entry.
+	Record(ctx context.Context, record RunRecord) error
+
+	// History returns the run records of entry `name`, the latest first.
+	History(ctx context.Context, name string) ([]RunRecord, error)
+}
+
+// RecorderMemory is the Recorder keeping the latest run records in memory.
+// The records are kept after the entry is closed, unless they exceed the max age of the recorder.
+type RecorderMemory struct {
+	mu       sync.RWMutex           // Mutex for records.
+	size     int                    // Max records size for each entry.
+	maxAge   time.Duration          // Max age of records, which is not limited if it is 0.
+	prunedAt time.Time              // Time of the latest pruning of expired records.
+	records  map[string][]RunRecord // Entry name to its records, the latest first.
+}
+
+const (
+	// DefaultRecorderSize is the default max records size for each entry of RecorderMemory.
+	DefaultRecorderSize = 10
+)
+
+// NewRecorderMemory creates and returns a Recorder keeping the latest `size` run records
+// of each entry in memory. The parameter `size` is DefaultRecorderSize in default.
+func NewRecorderMemory(size ...int) *RecorderMemory {
+	r := &RecorderMemory{
+		size:    DefaultRecorderSize,
+		records: make(map[string][]RunRecord),
+	}
+	if len(size) > 0 && size[0] > 0 {
+		r.size = size[0]
+	}
+	return r
+}
+
+// Record stores the run `record` of entry.
+func (r *RecorderMemory) Record(ctx context.Context, record RunRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var records = r.records[record.Name]
+	if len(records) >= r.size {
+		records = records[:r.size-1]
+	}
+	r.records[record.Name] = append([]RunRecord{record}, records...)
+	if r.maxAge > 0 && time.Since(r.prunedAt) >= r.maxAge {
+		r.prune()
+		r.prunedAt = time.Now()
+	}
+	return nil
+}
+
+// History returns the run records of entry `name`, the latest first.
+func (r *RecorderMemory) History(ctx context.Context, name string) ([]RunRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var history []RunRecord
+	for _, record := range r.records[name] {
+		if r.isExpired(record) {
+			break
+		}
+		history = append(history, record)
+	}
+	return history, nil
+}
+
+// SetMaxAge sets the max age of run records, the records ending earlier than `age` ago are removed.
+// The records never expire in default.
+func (r *RecorderMemory) SetMaxAge(age time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maxAge = age
+}
+
+// Remove deletes the run records of entry `name`.
+func (r *RecorderMemory) Remove(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.records, name)
+	return nil
+}
+
+// prune removes the expired records of all entries, and the entries having no records.
+func (r *RecorderMemory) prune() {
+	for name, records := range r.records {
+		var size = len(records)
+		for size > 0 && r.isExpired(records[size-1]) {
+			size--
+		}
+		if size == 0 {
+			delete(r.records, name)
+		} else {
+			r.records[name] = records[:size]
+		}
+	}
+}
+
+// isExpired checks whether the `record` exceeds the max age of recorder.
+func (r *RecorderMemory) isExpired(record RunRecord) bool {
+	return r.maxAge > 0 && time.Since(record.EndTime) > r.maxAge
+}
+
+// SetRecorder sets the Recorder storing run history of entries,
+// which is a RecorderMemory in default. It disables recording if `recorder` is nil.
+func (c *Cron) SetRecorder(recorder Recorder) {
+	c.recorder.Set(recorderHolder{recorder})
+}
+
+// GetRecorder returns the Recorder of cron.
+func (c *Cron) GetRecorder() Recorder {
+	if v := c.recorder.Val(); v != nil {
+		return v.(recorderHolder).Recorder
+	}
+	return nil
+}
+
+// recorderHolder holds the Recorder for atomic storing, which allows nil Recorder.
+type recorderHolder struct {
+	Recorder
+}
@@ -0,0 +1,221 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gcron"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// testLocker is the in-memory Locker for testing.
+type testLocker struct {
+	mu    sync.Mutex
+	locks map[string]testLock
+}
+
+type testLock struct {
+	value    string
+	expireAt time.Time
+}
+
+func newTestLocker() *testLocker {
+	return &testLocker{locks: make(map[string]testLock)}
+}
+
+func (l *testLocker) TryLock(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lock, ok := l.locks[key]; ok && lock.expireAt.After(time.Now()) {
+		return false, nil
+	}
+	l.locks[key] = testLock{value: value, expireAt: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (l *testLocker) Renew(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lock, ok := l.locks[key]; !ok || lock.value != value {
+		return false, nil
+	}
+	l.locks[key] = testLock{value: value, expireAt: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (l *testLocker) Unlock(ctx context.Context, key string, value string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lock, ok := l.locks[key]; ok && lock.value == value {
+		delete(l.locks, key)
+	}
+	return nil
+}
+
+func TestCron_AddClusterSingleton(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cron := gcron.New()
+		_, err := cron.AddClusterSingleton(ctx, "* * * * * *", func(ctx context.Context) {}, "test")
+		t.AssertNE(err, nil)
+		t.AssertNil(cron.SetLockConfig(gcron.LockConfig{Locker: newTestLocker()}))
+		_, err = cron.AddClusterSingleton(ctx, "* * * * * *", func(ctx context.Context) {}, "")
+		t.AssertNE(err, nil)
+		t.Assert(cron.GetLockConfig().Prefix, "gcron:lock:")
+		t.Assert(cron.GetLockConfig().TTL, 30*time.Second)
+		t.Assert(cron.GetLockConfig().MinHold, time.Second)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			locker = newTestLocker()
+			array  = garray.New(true)
+			crons  = []*gcron.Cron{gcron.New(), gcron.New(), gcron.New()}
+			// Each node runs at the same schedule, but only one node acquires the lock.
+			entries = make([]*gcron.Entry, 0)
+		)
+		for _, cron := range crons {
+			t.AssertNil(cron.SetLockConfig(gcron.LockConfig{Locker: locker, MinHold: 500 * time.Millisecond}))
+			entry, err := cron.AddClusterSingleton(ctx, "* * * * * *", func(ctx context.Context) {
+				array.Append(1)
+				time.Sleep(100 * time.Millisecond)
+			}, "cluster")
+			t.AssertNil(err)
+			t.Assert(entry.IsClusterSingleton(), true)
+			t.Assert(entry.IsSingleton(), true)
+			entries = append(entries, entry)
+		}
+		time.Sleep(1300 * time.Millisecond)
+		for _, cron := range crons {
+			cron.Close()
+		}
+		t.Assert(array.Len(), 1)
+		var acquired = 0
+		for _, entry := range entries {
+			if entry.IsAcquired() {
+				acquired++
+			}
+		}
+		t.Assert(acquired, 1)
+	})
+}
+
+func TestCron_AddClusterSingleton_LockLost(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			locker   = newTestLocker()
+			cron     = gcron.New()
+			canceled = make(chan struct{})
+		)
+		t.AssertNil(cron.SetLockConfig(gcron.LockConfig{Locker: locker, TTL: time.Second}))
+		_, err := cron.AddClusterSingleton(ctx, "* * * * * *", func(ctx context.Context) {
+			// The lock is taken by others while running.
+			locker.mu.Lock()
+			for key := range locker.locks {
+				locker.locks[key] = testLock{value: "other", expireAt: time.Now().Add(time.Minute)}
+			}
+			locker.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				close(canceled)
+			case <-time.After(2 * time.Second):
+			}
+		}, "lost")
+		t.AssertNil(err)
+		defer cron.Close()
+		select {
+		case <-canceled:
+		case <-time.After(3 * time.Second):
+			t.Error("job context is not canceled after lock lost")
+		}
+	})
+}
+
+// panicLocker is the Locker panics on locking for testing.
+type panicLocker struct {
+	*testLocker
+	count *gtype.Int
+}
+
+func (l *panicLocker) TryLock(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	l.count.Add(1)
+	panic("locker panic")
+}
+
+func TestCron_AddClusterSingleton_LockerPanic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			locker = &panicLocker{testLocker: newTestLocker(), count: gtype.NewInt()}
+			cron   = gcron.New()
+			array  = garray.New(true)
+		)
+		logger := glog.New()
+		logger.SetStdoutPrint(false)
+		cron.SetLogger(logger)
+		t.AssertNil(cron.SetLockConfig(gcron.LockConfig{Locker: locker}))
+		_, err := cron.AddClusterSingleton(ctx, "* * * * * *", func(ctx context.Context) {
+			array.Append(1)
+		}, "panic")
+		t.AssertNil(err)
+		defer cron.Close()
+		time.Sleep(2500 * time.Millisecond)
+		t.AssertGE(locker.count.Val(), 2)
+		t.Assert(array.Len(), 0)
+	})
+}
+
+func TestCron_SetLockConfig_InvalidTTL(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			locker = newTestLocker()
+			cron   = gcron.New()
+		)
+		t.AssertNil(cron.SetLockConfig(gcron.LockConfig{Locker: locker, TTL: time.Minute}))
+		err := cron.SetLockConfig(gcron.LockConfig{Locker: locker, TTL: 300 * time.Millisecond})
+		t.Assert(gerror.Code(err), gcode.CodeInvalidParameter)
+		t.Assert(cron.GetLockConfig().TTL, time.Minute)
+	})
+}
+
+func TestCron_SetLockConfig_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			locker = newTestLocker()
+			cron   = gcron.New()
+			array  = garray.New(true)
+		)
+		t.AssertNil(cron.SetLockConfig(gcron.LockConfig{Locker: locker}))
+		entry, err := cron.AddClusterSingleton(ctx, "@every 1h", func(ctx context.Context) {
+			array.Append(1)
+		}, "concurrent")
+		t.AssertNil(err)
+		defer cron.Close()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_ = cron.SetLockConfig(gcron.LockConfig{Locker: locker, TTL: time.Second})
+			}()
+			go func() {
+				defer wg.Done()
+				_ = entry.Run(ctx)
+			}()
+		}
+		wg.Wait()
+		t.AssertGT(array.Len(), 0)
+		t.Assert(cron.GetLockConfig().TTL, time.Second)
+	})
+}
@@ -0,0 +1,170 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gcron"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func TestCron_Entry_History(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron  = gcron.New()
+			array = garray.New(true)
+		)
+		cron.SetRecorder(gcron.NewRecorderMemory(2))
+		entry, err := cron.Add(ctx, "* * * * * *", func(ctx context.Context) {
+			array.Append(1)
+			if array.Len() > 1 {
+				panic("error")
+			}
+		}, "history")
+		t.AssertNil(err)
+		t.AssertNil(entry.LastRecord())
+		t.AssertGT(entry.Next().Unix(), time.Now().Unix()-1)
+
+		time.Sleep(3500 * time.Millisecond)
+		cron.Close()
+		t.Assert(array.Len(), 3)
+
+		record := entry.LastRecord()
+		t.AssertNE(record, nil)
+		t.Assert(record.Name, "history")
+		t.Assert(record.Pattern, "* * * * * *")
+		t.Assert(record.Manual, false)
+		t.AssertNE(record.Error, nil)
+		t.Assert(record.EndTime.Sub(record.StartTime), record.Duration)
+		t.Assert(record.NextTime.After(record.EndTime), true)
+
+		// Only the latest records are kept.
+		history, err := entry.History(ctx)
+		t.AssertNil(err)
+		t.Assert(len(history), 2)
+		t.Assert(history[0].StartTime, record.StartTime)
+		t.Assert(history[1].StartTime.Before(history[0].StartTime), true)
+	})
+	// Recorder disabled.
+	gtest.C(t, func(t *gtest.T) {
+		cron := gcron.New()
+		cron.SetRecorder(nil)
+		entry, err := cron.Add(ctx, "@every 1h", func(ctx context.Context) {})
+		t.AssertNil(err)
+		defer cron.Close()
+		t.AssertNil(entry.Run(ctx))
+		history, err := entry.History(ctx)
+		t.AssertNil(err)
+		t.Assert(len(history), 0)
+		t.AssertNE(entry.LastRecord(), nil)
+	})
+}
+
+func TestCron_Entry_History_Close(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron     = gcron.New()
+			recorder = gcron.NewRecorderMemory()
+		)
+		cron.SetRecorder(recorder)
+		defer cron.Close()
+
+		entry, err := cron.Add(ctx, "@every 1h", func(ctx context.Context) {}, "removed")
+		t.AssertNil(err)
+		t.AssertNil(entry.Run(ctx))
+		history, err := recorder.History(ctx, "removed")
+		t.AssertNil(err)
+		t.Assert(len(history), 1)
+
+		// The history is kept after the entry is closed.
+		cron.Remove("removed")
+		history, err = recorder.History(ctx, "removed")
+		t.AssertNil(err)
+		t.Assert(len(history), 1)
+
+		t.AssertNil(recorder.Remove(ctx, "removed"))
+		history, err = recorder.History(ctx, "removed")
+		t.AssertNil(err)
+		t.Assert(len(history), 0)
+	})
+}
+
+func TestCron_RecorderMemory_MaxAge(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			recorder = gcron.NewRecorderMemory()
+			now      = time.Now()
+		)
+		recorder.SetMaxAge(time.Minute)
+		t.AssertNil(recorder.Record(ctx, gcron.RunRecord{Name: "expired", EndTime: now.Add(-time.Hour)}))
+		t.AssertNil(recorder.Record(ctx, gcron.RunRecord{Name: "mixed", EndTime: now.Add(-time.Hour)}))
+		t.AssertNil(recorder.Record(ctx, gcron.RunRecord{Name: "mixed", EndTime: now}))
+
+		history, err := recorder.History(ctx, "expired")
+		t.AssertNil(err)
+		t.Assert(len(history), 0)
+		history, err = recorder.History(ctx, "mixed")
+		t.AssertNil(err)
+		t.Assert(len(history), 1)
+		t.Assert(history[0].EndTime, now)
+	})
+}
+
+func TestCron_Entry_Run(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron  = gcron.New()
+			array = garray.New(true)
+		)
+		entry, err := cron.Add(ctx, "@every 1h", func(ctx context.Context) {
+			array.Append(1)
+			if array.Len() == 2 {
+				panic(gerror.New("error"))
+			}
+		})
+		t.AssertNil(err)
+		defer cron.Close()
+
+		t.AssertNil(entry.Run(ctx))
+		t.Assert(array.Len(), 1)
+		t.Assert(entry.LastRecord().Manual, true)
+
+		err = entry.Run(ctx)
+		t.Assert(err, "error")
+		t.Assert(array.Len(), 2)
+		t.Assert(entry.LastRecord().Error, "error")
+		t.Assert(entry.LastRecord().ErrorMessage, "error")
+		t.Assert(entry.IsRunning(), false)
+
+		history, err := entry.History(ctx)
+		t.AssertNil(err)
+		t.Assert(len(history), 2)
+	})
+	// Singleton.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron    = gcron.New()
+			started = make(chan struct{})
+		)
+		entry, err := cron.AddSingleton(ctx, "@every 1h", func(ctx context.Context) {
+			close(started)
+			time.Sleep(500 * time.Millisecond)
+		})
+		t.AssertNil(err)
+		defer cron.Close()
+
+		go entry.Run(ctx)
+		<-started
+		t.Assert(entry.IsRunning(), true)
+		t.AssertNE(entry.Run(ctx), nil)
+	})
+}
@@ -74,6 +74,19 @@ func SetAsync(enabled bool) {
 	defaultLogger.SetAsync(enabled)
 }
 
+// Flush writes all the async buffered logging content of default defaultLogger synchronously.
+func Flush() {
+	defaultLogger.Flush()
+}
+
+// FlushAll writes all the async buffered logging content of all loggers synchronously,
+// which is commonly called before the process exits.
+func FlushAll() {
+	for _, v := range asyncWriters.Slice() {
+		v.(*asyncWriter).Flush()
+	}
+}
+
 // SetStdoutPrint sets whether ouptput the logging contents to stdout, which is true in default.
 func SetStdoutPrint(enabled bool) {
 	defaultLogger.SetStdoutPrint(enabled)
@@ -99,9 +99,12 @@ func (l *Logger) print(ctx context.Context, level int, stack string, values ...a
 	// It uses atomic reading operation to enhance the performance checking.
 	// It here uses CAP for performance and concurrent safety.
 	// It just initializes once for each logger.
-	if l.config.RotateSize > 0 || l.config.RotateExpire > 0 {
+	if l.config.RotateSize > 0 || l.config.RotateExpire > 0 || l.config.RotatePeriod != "" {
 		if !l.config.rotatedHandlerInitialized.Val() && l.config.rotatedHandlerInitialized.Cas(false, true) {
 			l.rotateChecksTimely(ctx)
+			if l.config.RotatePeriod != "" {
+				l.rotatePeriodTimely(ctx)
+			}
 			intlog.Printf(ctx, "logger rotation initialized: every %s", l.config.RotateCheckInterval.String())
 		}
 	}
@@ -207,7 +210,14 @@ func (l *Logger) print(ctx context.Context, level int, stack string, values ...a
 			}
 		}
 	}
-	if l.config.Flags&F_ASYNC > 0 {
+	if l.config.Flags&F_ASYNC > 0 && l.config.AsyncBufferSize > 0 {
+		input.IsAsync = true
+		if writer := l.getAsyncWriter(true); writer != nil {
+			writer.Push(ctx, input)
+		} else {
+			input.Next(ctx)
+		}
+	} else if l.config.Flags&F_ASYNC > 0 {
 		input.IsAsync = true
 		err := asyncPool.Add(ctx, func(ctx context.Context) {
 			input.Next(ctx)
@@ -251,9 +261,11 @@ func (l *Logger) doFinalPrint(ctx context.Context, input *HandlerInput) *bytes.B
 func (l *Logger) printToWriter(ctx context.Context, input *HandlerInput) *bytes.Buffer {
 	if l.config.Writer != nil {
 		var buffer = input.getRealBuffer(l.config.WriterColorEnable)
-		if _, err := l.config.Writer.Write(buffer.Bytes()); err != nil {
-			intlog.Errorf(ctx, `%+v`, err)
+		if input.asyncBatch != nil {
+			input.asyncBatch.add(l, asyncTargetWriter, input.Time, "", buffer.Bytes())
+			return buffer
 		}
+		writeToWriter(ctx, l.config.Writer, buffer.Bytes())
 		return buffer
 	}
 	return nil
@@ -266,6 +278,10 @@ func (l *Logger) printToStdout(ctx context.Context, input *HandlerInput) *bytes.
 			err    error
 			buffer = input.getRealBuffer(!l.config.StdoutColorDisabled)
 		)
+		if input.asyncBatch != nil {
+			input.asyncBatch.add(l, asyncTargetStdout, input.Time, "", buffer.Bytes())
+			return buffer
+		}
 		// This will lose color in Windows os system. DO NOT USE.
 		// if _, err := os.Stdout.Write(input.getRealBuffer(true).Bytes()); err != nil {
 
@@ -281,23 +297,37 @@ func (l *Logger) printToStdout(ctx context.Context, input *HandlerInput) *bytes.
 // printToFile outputs logging content to disk file.
 func (l *Logger) printToFile(ctx context.Context, t time.Time, in *HandlerInput) *bytes.Buffer {
 	var (
-		buffer        = in.getRealBuffer(l.config.WriterColorEnable)
-		logFilePath   = l.getFilePath(t)
-		memoryLockKey = memoryLockPrefixForPrintingToFile + logFilePath
+		buffer      = in.getRealBuffer(l.config.WriterColorEnable)
+		logFilePath = l.getFilePath(t)
 	)
+	if in.asyncBatch != nil {
+		in.asyncBatch.add(l, asyncTargetFile, t, logFilePath, buffer.Bytes())
+		return buffer
+	}
+	l.writeToFile(ctx, logFilePath, t, buffer.Bytes())
+	return buffer
+}
+
+// writeToFile writes `content` to disk file `logFilePath` with rotation checks.
+func (l *Logger) writeToFile(ctx context.Context, logFilePath string, t time.Time, content []byte) {
+	var memoryLockKey = memoryLockPrefixForPrintingToFile + logFilePath
 	gmlock.Lock(memoryLockKey)
 	defer gmlock.Unlock(memoryLockKey)
 
+	// Rotation calendar period checks.
+	if l.config.RotatePeriod != "" {
+		l.rotateFileByPeriod(ctx, logFilePath, t)
+	}
 	// Rotation file size checks.
 	if l.config.RotateSize > 0 && gfile.Size(logFilePath) > l.config.RotateSize {
 		if runtime.GOOS == "windows" {
 			file := l.createFpInPool(ctx, logFilePath)
 			if file == nil {
 				intlog.Errorf(ctx, `got nil file pointer for: %s`, logFilePath)
-				return buffer
+				return
 			}
 
-			if _, err := file.Write(buffer.Bytes()); err != nil {
+			if _, err := file.Write(content); err != nil {
 				intlog.Errorf(ctx, `%+v`, err)
 			}
 
@@ -306,7 +336,7 @@ func (l *Logger) printToFile(ctx context.Context, t time.Time, in *HandlerInput)
 			}
 			l.rotateFileBySize(ctx, t)
 
-			return buffer
+			return
 		}
 
 		l.rotateFileBySize(ctx, t)
@@ -315,14 +345,13 @@ func (l *Logger) printToFile(ctx context.Context, t time.Time, in *HandlerInput)
 	if file := l.createFpInPool(ctx, logFilePath); file == nil {
 		intlog.Errorf(ctx, `got nil file pointer for: %s`, logFilePath)
 	} else {
-		if _, err := file.Write(buffer.Bytes()); err != nil {
+		if _, err := file.Write(content); err != nil {
 			intlog.Errorf(ctx, `%+v`, err)
 		}
 		if err := file.Close(); err != nil {
 			intlog.Errorf(ctx, `%+v`, err)
 		}
 	}
-	return buffer
 }
 
 // createFpInPool retrieves and returns a file pointer from file pool.
@@ -25,26 +25,32 @@ func (l *Logger) Printf(ctx context.Context, format string, v ...any) {
 }
 
 // Fatal prints the logging content with [FATA] header and newline, then exit the current process.
+// The async buffered logging content is flushed before exiting.
 func (l *Logger) Fatal(ctx context.Context, v ...any) {
 	l.printErr(ctx, LEVEL_FATA, v...)
+	l.Flush()
 	os.Exit(1)
 }
 
 // Fatalf prints the logging content with [FATA] header, custom format and newline, then exit the current process.
+// The async buffered logging content is flushed before exiting.
 func (l *Logger) Fatalf(ctx context.Context, format string, v ...any) {
 	l.printErr(ctx, LEVEL_FATA, l.format(format, v...))
+	l.Flush()
 	os.Exit(1)
 }
 
 // Panic prints the logging content with [PANI] header and newline, then panics.
 func (l *Logger) Panic(ctx context.Context, v ...any) {
 	l.printErr(ctx, LEVEL_PANI, v...)
+	l.Flush()
 	panic(fmt.Sprint(v...))
 }
 
 // Panicf prints the logging content with [PANI] header, custom format and newline, then panics.
 func (l *Logger) Panicf(ctx context.Context, format string, v ...any) {
 	l.printErr(ctx, LEVEL_PANI, l.format(format, v...))
+	l.Flush()
 	panic(l.format(format, v...))
 }
 
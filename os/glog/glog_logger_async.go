@@ -0,0 +1,351 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+// Policies for async logging when the async buffer is full.
+const (
+	AsyncOverflowBlock      = "block"       // Blocks the logging until the buffer has space.
+	AsyncOverflowDropOldest = "drop-oldest" // Drops the oldest buffered logging content.
+	AsyncOverflowDropNewest = "drop-newest" // Drops the current logging content.
+	AsyncOverflowSample     = "sample"      // Keeps one of every AsyncSampleRate logging contents by dropping the oldest, drops the others.
+)
+
+const (
+	defaultAsyncFlushInterval = time.Second
+	defaultAsyncSampleRate    = 10
+	asyncBatchMaxSize         = 256 * 1024 // Batched content is written if its size exceeds this.
+)
+
+var (
+	// asyncWriters is the registry of the active async writers of all loggers, which are flushed by FlushAll.
+	asyncWriters = gset.New(true)
+)
+
+// AsyncStats is the statistics of the async writer of logger.
+type AsyncStats struct {
+	Buffered int   // Count of logging contents buffered and waiting for handling.
+	Written  int64 // Count of logging contents handled.
+	Dropped  int64 // Count of logging contents dropped as the buffer is full.
+}
+
+// asyncWriterHolder holds the async writer of logger, which is shared by the cloned loggers.
+type asyncWriterHolder struct {
+	mu     sync.Mutex
+	writer *asyncWriter
+}
+
+// asyncWriter handles the logging inputs asynchronously using a bounded buffer,
+// and writes the output contents in batches.
+type asyncWriter struct {
+	queue         chan asyncItem     // Bounded buffer for logging inputs.
+	flushChan     chan chan struct{} // Flush requests, the request channel is closed after flushed.
+	closeChan     chan struct{}      // Closed when the writer is closing.
+	doneChan      chan struct{}      // Closed after the worker exits.
+	closeOnce     sync.Once          // Ensures closing once.
+	policy        string             // Overflow policy.
+	sampleRate    int                // Sample rate for AsyncOverflowSample.
+	sampleCount   *gtype.Int64       // Overflow counter for sampling.
+	flushInterval time.Duration      // Interval flushing batched content.
+	written       *gtype.Int64       // Count of handled logging inputs.
+	dropped       *gtype.Int64       // Count of dropped logging inputs.
+	batch         *asyncBatch        // Batched output content, which is only accessed by the worker.
+}
+
+// asyncItem is a logging input in buffer.
+type asyncItem struct {
+	ctx   context.Context
+	input *HandlerInput
+}
+
+// asyncBatch is the batched output content, which keeps the outputting sequence.
+type asyncBatch struct {
+	size    int
+	entries []*asyncBatchEntry
+}
+
+// asyncBatchEntry is the continuous output content of the same target.
+type asyncBatchEntry struct {
+	logger *Logger       // Logger outputting the content.
+	target int           // Output target kind.
+	time   time.Time     // Logging time for file path.
+	path   string        // File path for file target.
+	buffer *bytes.Buffer // Output content.
+}
+
+const (
+	asyncTargetStdout = iota
+	asyncTargetFile
+	asyncTargetWriter
+)
+
+// Flush writes all the async buffered logging content of logger synchronously.
+// It does nothing if the logger is not in async mode with AsyncBufferSize configured.
+func (l *Logger) Flush() {
+	if writer := l.getAsyncWriter(false); writer != nil {
+		writer.Flush()
+	}
+}
+
+// Close flushes the async buffered logging content and stops the async writer of logger.
+// The async writer is recreated if the logger is used in async mode again.
+func (l *Logger) Close() {
+	if l == nil || l.config.asyncWriterHolder == nil {
+		return
+	}
+	holder := l.config.asyncWriterHolder
+	holder.mu.Lock()
+	writer := holder.writer
+	holder.writer = nil
+	holder.mu.Unlock()
+	if writer != nil {
+		writer.Close()
+	}
+}
+
+// GetAsyncStats returns the statistics of the async writer of logger.
+func (l *Logger) GetAsyncStats() AsyncStats {
+	if writer := l.getAsyncWriter(false); writer != nil {
+		return AsyncStats{
+			Buffered: len(writer.queue),
+			Written:  writer.written.Val(),
+			Dropped:  writer.dropped.Val(),
+		}
+	}
+	return AsyncStats{}
+}
+
+// getAsyncWriter returns the async writer of logger, it creates one if `create` is true and it does not exist.
+func (l *Logger) getAsyncWriter(create bool) *asyncWriter {
+	if l == nil {
+		return nil
+	}
+	holder := l.config.asyncWriterHolder
+	if holder == nil {
+		return nil
+	}
+	holder.mu.Lock()
+	defer holder.mu.Unlock()
+	if holder.writer == nil && create {
+		holder.writer = newAsyncWriter(l.config)
+	}
+	return holder.writer
+}
+
+// newAsyncWriter creates and returns an async writer and starts its worker.
+func newAsyncWriter(config Config) *asyncWriter {
+	w := &asyncWriter{
+		queue:         make(chan asyncItem, config.AsyncBufferSize),
+		flushChan:     make(chan chan struct{}),
+		closeChan:     make(chan struct{}),
+		doneChan:      make(chan struct{}),
+		policy:        config.AsyncOverflow,
+		sampleRate:    config.AsyncSampleRate,
+		sampleCount:   gtype.NewInt64(),
+		flushInterval: config.AsyncFlushInterval,
+		written:       gtype.NewInt64(),
+		dropped:       gtype.NewInt64(),
+		batch:         &asyncBatch{},
+	}
+	if w.policy == "" {
+		w.policy = AsyncOverflowBlock
+	}
+	if w.sampleRate <= 0 {
+		w.sampleRate = defaultAsyncSampleRate
+	}
+	if w.flushInterval <= 0 {
+		w.flushInterval = defaultAsyncFlushInterval
+	}
+	go w.work()
+	asyncWriters.Add(w)
+	return w
+}
+
+// Push puts logging `input` to buffer, it handles the input according to the overflow policy if buffer is full.
+// The input is handled synchronously if the writer is closed.
+func (w *asyncWriter) Push(ctx context.Context, input *HandlerInput) {
+	item := asyncItem{ctx: ctx, input: input}
+	select {
+	case <-w.closeChan:
+		input.Next(ctx)
+		return
+	case w.queue <- item:
+		return
+	default:
+	}
+	switch w.policy {
+	case AsyncOverflowDropNewest:
+		w.dropped.Add(1)
+
+	case AsyncOverflowDropOldest:
+		w.pushDroppingOldest(ctx, item)
+
+	case AsyncOverflowSample:
+		if w.sampleCount.Add(1)%int64(w.sampleRate) == 0 {
+			w.pushDroppingOldest(ctx, item)
+		} else {
+			w.dropped.Add(1)
+		}
+
+	default:
+		select {
+		case <-w.closeChan:
+			input.Next(ctx)
+		case w.queue <- item:
+		}
+	}
+}
+
+// pushDroppingOldest puts `item` to buffer by dropping the oldest ones if buffer is full.
+func (w *asyncWriter) pushDroppingOldest(ctx context.Context, item asyncItem) {
+	for {
+		select {
+		case <-w.closeChan:
+			item.input.Next(ctx)
+			return
+		case w.queue <- item:
+			return
+		default:
+		}
+		select {
+		case <-w.queue:
+			w.dropped.Add(1)
+		default:
+		}
+	}
+}
+
+// Flush blocks until all the logging inputs pushed before are written.
+func (w *asyncWriter) Flush() {
+	done := make(chan struct{})
+	select {
+	case w.flushChan <- done:
+		<-done
+	case <-w.doneChan:
+	}
+}
+
+// Close flushes and stops the worker, it blocks until the worker exits.
+func (w *asyncWriter) Close() {
+	w.closeOnce.Do(func() {
+		close(w.closeChan)
+	})
+	<-w.doneChan
+	asyncWriters.Remove(w)
+}
+
+// work handles logging inputs from buffer and flushes the batched content periodically.
+func (w *asyncWriter) work() {
+	var ticker = time.NewTicker(w.flushInterval)
+	defer func() {
+		ticker.Stop()
+		close(w.doneChan)
+	}()
+	for {
+		select {
+		case item := <-w.queue:
+			w.handle(item)
+			if w.batch.size >= asyncBatchMaxSize {
+				w.batch.flush()
+			}
+
+		case done := <-w.flushChan:
+			w.drain()
+			w.batch.flush()
+			close(done)
+
+		case <-ticker.C:
+			w.batch.flush()
+
+		case <-w.closeChan:
+			w.drain()
+			w.batch.flush()
+			return
+		}
+	}
+}
+
+// drain handles all the logging inputs currently in buffer.
+func (w *asyncWriter) drain() {
+	for i := len(w.queue); i > 0; i-- {
+		select {
+		case item := <-w.queue:
+			w.handle(item)
+		default:
+			return
+		}
+	}
+}
+
+// handle calls the handlers of logging input, of which the final output is appended to batch.
+func (w *asyncWriter) handle(item asyncItem) {
+	item.input.asyncBatch = w.batch
+	item.input.Next(item.ctx)
+	w.written.Add(1)
+}
+
+// add appends output `content` to batch, it merges the content to the last entry if they have the same target.
+func (b *asyncBatch) add(logger *Logger, target int, t time.Time, path string, content []byte) {
+	if len(content) == 0 {
+		return
+	}
+	b.size += len(content)
+	if n := len(b.entries); n > 0 {
+		last := b.entries[n-1]
+		if last.logger == logger && last.target == target && last.path == path {
+			last.buffer.Write(content)
+			return
+		}
+	}
+	b.entries = append(b.entries, &asyncBatchEntry{
+		logger: logger,
+		target: target,
+		time:   t,
+		path:   path,
+		buffer: bytes.NewBuffer(content),
+	})
+}
+
+// flush writes all the batched content in sequence and resets the batch.
+func (b *asyncBatch) flush() {
+	var ctx = context.Background()
+	for _, entry := range b.entries {
+		switch entry.target {
+		case asyncTargetStdout:
+			if _, err := fmt.Fprint(color.Output, entry.buffer.String()); err != nil {
+				intlog.Errorf(ctx, `%+v`, err)
+			}
+		case asyncTargetFile:
+			entry.logger.writeToFile(ctx, entry.path, entry.time, entry.buffer.Bytes())
+		case asyncTargetWriter:
+			writeToWriter(ctx, entry.logger.config.Writer, entry.buffer.Bytes())
+		}
+	}
+	b.size = 0
+	b.entries = b.entries[:0]
+}
+
+// writeToWriter writes `content` to `writer`.
+func writeToWriter(ctx context.Context, writer io.Writer, content []byte) {
+	if _, err := writer.Write(content); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+}
@@ -41,17 +41,25 @@ type Config struct {
 	LevelPrefixes        map[int]string `json:"levelPrefixes"`        // Logging level to its prefix string mapping.
 	RotateSize           int64          `json:"rotateSize"`           // Rotate the logging file if its size > 0 in bytes.
 	RotateExpire         time.Duration  `json:"rotateExpire"`         // Rotate the logging file if its mtime exceeds this duration.
-	RotateBackupLimit    int            `json:"rotateBackupLimit"`    // Max backup for rotated files, default is 0, means no backups.
+	RotateBackupLimit    int            `json:"rotateBackupLimit"`    // Max backup for rotated files, default is 0, means no backups, or no limit if RotatePeriod is set.
 	RotateBackupExpire   time.Duration  `json:"rotateBackupExpire"`   // Max expires for rotated files, which is 0 in default, means no expiration.
 	RotateBackupCompress int            `json:"rotateBackupCompress"` // Compress level for rotated files using gzip algorithm. It's 0 in default, means no compression.
 	RotateCheckInterval  time.Duration  `json:"rotateCheckInterval"`  // Asynchronously checks the backups and expiration at intervals. It's 1 hour in default.
+	RotatePeriod         string         `json:"rotatePeriod"`         // Rotate the logging file at calendar boundaries: hourly, daily. It's empty in default, means no calendar rotation.
+	RotateCompressFormat string         `json:"rotateCompressFormat"` // Compression format for rotated files: gzip(default), zlib, zip.
+	RotateHandler        RotateHandler  `json:"-"`                    // Handler called with the rotated file path, which is called after compression if compression enabled.
 	StdoutColorDisabled  bool           `json:"stdoutColorDisabled"`  // Logging level prefix with color to writer or not (false in default).
 	WriterColorEnable    bool           `json:"writerColorEnable"`    // Logging level prefix with color to writer or not (false in default).
+	AsyncBufferSize      int            `json:"asyncBufferSize"`      // Bounded buffer size for async logging, the unbounded async pool is used if it is 0.
+	AsyncOverflow        string         `json:"asyncOverflow"`        // Policy if the async buffer is full: block(default), drop-oldest, drop-newest, sample.
+	AsyncSampleRate      int            `json:"asyncSampleRate"`      // Keeps one of every this count logging if the async buffer is full for sample policy, 10 in default.
+	AsyncFlushInterval   time.Duration  `json:"asyncFlushInterval"`   // Interval flushing the batched async logging content, 1 second in default.
 	internalConfig
 }
 
 type internalConfig struct {
-	rotatedHandlerInitialized *gtype.Bool // Whether the rotation feature initialized.
+	rotatedHandlerInitialized *gtype.Bool        // Whether the rotation feature initialized.
+	asyncWriterHolder         *asyncWriterHolder // Holder of the async writer, which is shared by the cloned loggers.
 }
 
 // DefaultConfig returns the default configuration for logger.
@@ -70,6 +78,7 @@ func DefaultConfig() Config {
 		RotateCheckInterval: time.Hour,
 		internalConfig: internalConfig{
 			rotatedHandlerInitialized: gtype.NewBool(),
+			asyncWriterHolder:         &asyncWriterHolder{},
 		},
 	}
 	for k, v := range defaultLevelPrefixes {
@@ -88,6 +97,13 @@ func (l *Logger) GetConfig() Config {
 
 // SetConfig set configurations for the logger.
 func (l *Logger) SetConfig(config Config) error {
+	if err := checkRotateConfig(config); err != nil {
+		return err
+	}
+	// It keeps the async writer for the custom configuration without internal configuration.
+	if config.asyncWriterHolder == nil {
+		config.asyncWriterHolder = l.config.asyncWriterHolder
+	}
 	l.config = config
 	// Necessary validation.
 	if config.Path != "" {
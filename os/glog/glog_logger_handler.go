@@ -84,8 +84,9 @@ type HandlerInput struct {
 }
 
 type internalHandlerInfo struct {
-	index    int       // Middleware handling index for internal usage.
-	handlers []Handler // Handler array calling bu index.
+	index      int         // Middleware handling index for internal usage.
+	handlers   []Handler   // Handler array calling bu index.
+	asyncBatch *asyncBatch // Batch for final output content, only available in async mode with AsyncBufferSize.
 }
 
 // defaultHandler is the default handler for package.
@@ -0,0 +1,211 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// SlogHandler implements slog.Handler, which outputs the slog records using Logger,
+// so that the records share the configuration of Logger like rotation, CtxKeys, handlers, etc.
+//
+// The record message and attributes are passed to Logger as values in sequence:
+// message, key1, value1, key2, value2..., which is the same as the structured logging of Logger.
+// The keys of attributes in groups are joined with '.', like "group.key".
+type SlogHandler struct {
+	logger *Logger // Logger for outputting.
+	prefix string  // Key prefix of current group, like "group.".
+	values []any   // Pre-formatted attribute key-value pairs by WithAttrs.
+}
+
+const (
+	slogLevelNotice   = slog.LevelInfo + 2  // Slog level for LEVEL_NOTI.
+	slogLevelCritical = slog.LevelError + 4 // Slog level for LEVEL_CRIT, LEVEL_PANI and LEVEL_FATA.
+)
+
+// NewSlogHandler creates and returns a slog.Handler outputting records using `logger`.
+// It uses the default logger if given `logger` is nil.
+//
+// Example:
+// slog.SetDefault(slog.New(glog.NewSlogHandler(g.Log())))
+func NewSlogHandler(logger *Logger) *SlogHandler {
+	if logger == nil {
+		logger = DefaultLogger()
+	}
+	return &SlogHandler{
+		logger: logger,
+	}
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.logger.checkLevel(slogLevelToLevel(level))
+}
+
+// Handle outputs the record using Logger.
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	var (
+		level  = slogLevelToLevel(record.Level)
+		values = make([]any, 0, 1+len(h.values)+record.NumAttrs()*2)
+	)
+	values = append(values, record.Message)
+	values = append(values, h.values...)
+	record.Attrs(func(attr slog.Attr) bool {
+		values = appendSlogAttr(values, h.prefix, attr)
+		return true
+	})
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if level >= LEVEL_ERRO {
+		h.logger.printErr(ctx, level, values...)
+	} else {
+		h.logger.printStd(ctx, level, values...)
+	}
+	return nil
+}
+
+// WithAttrs returns a new handler whose attributes consist of both the receiver's attributes and `attrs`.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	handler := h.clone()
+	for _, attr := range attrs {
+		handler.values = appendSlogAttr(handler.values, h.prefix, attr)
+	}
+	return handler
+}
+
+// WithGroup returns a new handler with the given group `name` appended to the receiver's groups.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	handler := h.clone()
+	handler.prefix = h.prefix + name + "."
+	return handler
+}
+
+// clone returns a copy of the handler.
+func (h *SlogHandler) clone() *SlogHandler {
+	return &SlogHandler{
+		logger: h.logger,
+		prefix: h.prefix,
+		values: append(make([]any, 0, len(h.values)), h.values...),
+	}
+}
+
+// HandlerSlog returns a logging Handler that forwards the logging content to slog `handler`,
+// so that the content of Logger can be output by any slog.Handler.
+//
+// The Content and the first value of odd Values are used as the message of record,
+// the value pairs of Values are used as the attributes of record,
+// and the non-empty TraceId, CtxStr, Prefix, CallerFunc, CallerPath and Stack are added as attributes too.
+//
+// Note that it does not call the next handler, so the content is not output by Logger itself.
+// It should not be used with SlogHandler of the same Logger, or else it causes recursive calls.
+func HandlerSlog(handler slog.Handler) Handler {
+	return func(ctx context.Context, in *HandlerInput) {
+		var level = levelToSlogLevel(in.Level)
+		if !handler.Enabled(ctx, level) {
+			return
+		}
+		var (
+			message = in.Content
+			values  = in.Values
+		)
+		if len(values)%2 != 0 {
+			if message != "" {
+				message += " "
+			}
+			message += gconv.String(values[0])
+			values = values[1:]
+		}
+		record := slog.NewRecord(in.Time, level, message, 0)
+		for _, attr := range []slog.Attr{
+			slog.String(structureKeyTraceId, in.TraceId),
+			slog.String(structureKeyCtxStr, in.CtxStr),
+			slog.String(structureKeyPrefix, in.Prefix),
+			slog.String(structureKeyCallerFunc, in.CallerFunc),
+			slog.String(structureKeyCallerPath, in.CallerPath),
+		} {
+			if attr.Value.String() != "" {
+				record.AddAttrs(attr)
+			}
+		}
+		for i := 0; i < len(values); i += 2 {
+			record.AddAttrs(slog.Any(gconv.String(values[i]), values[i+1]))
+		}
+		if in.Stack != "" {
+			record.AddAttrs(slog.String(structureKeyStack, in.Stack))
+		}
+		if err := handler.Handle(ctx, record); err != nil {
+			intlog.Errorf(ctx, `slog handler failed: %+v`, err)
+		}
+	}
+}
+
+// appendSlogAttr appends the flattened key-value pairs of `attr` to `values` with key `prefix`.
+func appendSlogAttr(values []any, prefix string, attr slog.Attr) []any {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return values
+	}
+	if attr.Value.Kind() != slog.KindGroup {
+		return append(values, prefix+attr.Key, attr.Value.Any())
+	}
+	// The group of empty key is inlined.
+	if attr.Key != "" {
+		prefix += attr.Key + "."
+	}
+	for _, groupAttr := range attr.Value.Group() {
+		values = appendSlogAttr(values, prefix, groupAttr)
+	}
+	return values
+}
+
+// slogLevelToLevel converts slog level to logging level.
+func slogLevelToLevel(level slog.Level) int {
+	switch {
+	case level < slog.LevelInfo:
+		return LEVEL_DEBU
+	case level < slogLevelNotice:
+		return LEVEL_INFO
+	case level < slog.LevelWarn:
+		return LEVEL_NOTI
+	case level < slog.LevelError:
+		return LEVEL_WARN
+	case level < slogLevelCritical:
+		return LEVEL_ERRO
+	default:
+		return LEVEL_CRIT
+	}
+}
+
+// levelToSlogLevel converts logging level to slog level.
+// The LEVEL_NONE used by Print functions is converted to slog.LevelInfo.
+func levelToSlogLevel(level int) slog.Level {
+	switch level {
+	case LEVEL_DEBU:
+		return slog.LevelDebug
+	case LEVEL_NONE, LEVEL_INFO:
+		return slog.LevelInfo
+	case LEVEL_NOTI:
+		return slogLevelNotice
+	case LEVEL_WARN:
+		return slog.LevelWarn
+	case LEVEL_ERRO:
+		return slog.LevelError
+	default:
+		return slogLevelCritical
+	}
+}
@@ -14,6 +14,8 @@ import (
 
 	"github.com/gogf/gf/v2/container/garray"
 	"github.com/gogf/gf/v2/encoding/gcompress"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/internal/intlog"
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/gmlock"
@@ -22,24 +24,59 @@ import (
 	"github.com/gogf/gf/v2/text/gregex"
 )
 
+// RotateHandler is the handler called with the rotated logging file path,
+// which can be used for shipping or archiving the rotated file.
+type RotateHandler func(ctx context.Context, path string)
+
+// Compression formats for rotated files.
+const (
+	RotateCompressGzip = "gzip"
+	RotateCompressZlib = "zlib"
+	RotateCompressZip  = "zip"
+)
+
 const (
-	memoryLockPrefixForRotating = "glog.rotateChecksTimely:"
+	memoryLockPrefixForRotating    = "glog.rotateChecksTimely:"
+	memoryLockPrefixForCompressing = "glog.compressBackupFile:"
 )
 
+// rotateCompressExtNames maps the compression formats to their file extension names.
+var rotateCompressExtNames = map[string]string{
+	RotateCompressGzip: "gz",
+	RotateCompressZlib: "zlib",
+	RotateCompressZip:  "zip",
+}
+
+// checkRotateConfig checks the rotation configuration of `config`.
+func checkRotateConfig(config Config) error {
+	switch config.RotatePeriod {
+	case "", RotatePeriodHourly, RotatePeriodDaily:
+	default:
+		return gerror.NewCodef(gcode.CodeInvalidConfiguration, `invalid rotate period: %s`, config.RotatePeriod)
+	}
+	if _, ok := rotateCompressExtNames[config.RotateCompressFormat]; !ok && config.RotateCompressFormat != "" {
+		return gerror.NewCodef(
+			gcode.CodeInvalidConfiguration, `invalid rotate compress format: %s`, config.RotateCompressFormat,
+		)
+	}
+	return nil
+}
+
 // rotateFileBySize rotates the current logging file according to the
 // configured rotation size.
 func (l *Logger) rotateFileBySize(ctx context.Context, now time.Time) {
 	if l.config.RotateSize <= 0 {
 		return
 	}
-	if err := l.doRotateFile(ctx, l.getFilePath(now)); err != nil {
+	if err := l.doRotateFile(ctx, l.getFilePath(now), ""); err != nil {
 		// panic(err)
 		intlog.Errorf(ctx, `%+v`, err)
 	}
 }
 
-// doRotateFile rotates the given logging file.
-func (l *Logger) doRotateFile(ctx context.Context, filePath string) error {
+// doRotateFile rotates the given logging file by renaming it to `backupFilePath`.
+// The backup file path is generated using current time if `backupFilePath` is empty.
+func (l *Logger) doRotateFile(ctx context.Context, filePath, backupFilePath string) error {
 	memoryLockKey := "glog.doRotateFile:" + filePath
 	if !gmlock.TryLock(memoryLockKey) {
 		return nil
@@ -47,21 +84,22 @@ func (l *Logger) doRotateFile(ctx context.Context, filePath string) error {
 	defer gmlock.Unlock(memoryLockKey)
 
 	intlog.PrintFunc(ctx, func() string {
-		return fmt.Sprintf(`start rotating file by size: %s, file: %s`, gfile.SizeFormat(filePath), filePath)
+		return fmt.Sprintf(`start rotating file: %s, size: %s`, filePath, gfile.SizeFormat(filePath))
 	})
 	defer intlog.PrintFunc(ctx, func() string {
-		return fmt.Sprintf(`done rotating file by size: %s, size: %s`, gfile.SizeFormat(filePath), filePath)
+		return fmt.Sprintf(`done rotating file: %s`, filePath)
 	})
 
 	// No backups, it then just removes the current logging file.
-	if l.config.RotateBackupLimit == 0 {
+	// The backups are always kept for calendar rotation, as it rotates the logging file in use.
+	if l.config.RotateBackupLimit == 0 && l.config.RotatePeriod == "" {
 		if err := gfile.RemoveFile(filePath); err != nil {
 			return err
 		}
 		intlog.Printf(
 			ctx,
-			`%d size exceeds, no backups set, remove original logging file: %s`,
-			l.config.RotateSize, filePath,
+			`no backups set, remove original logging file: %s`,
+			filePath,
 		)
 		return nil
 	}
@@ -70,12 +108,12 @@ func (l *Logger) doRotateFile(ctx context.Context, filePath string) error {
 		dirPath     = gfile.Dir(filePath)
 		fileName    = gfile.Name(filePath)
 		fileExtName = gfile.ExtName(filePath)
-		newFilePath = ""
+		newFilePath = backupFilePath
 	)
 	// Rename the logging file by adding extra datetime information to microseconds, like:
 	// access.log          -> access.20200326101301899002.log
 	// access.20200326.log -> access.20200326.20200326101301899002.log
-	for {
+	for newFilePath == "" {
 		var (
 			now   = gtime.Now()
 			micro = now.Microsecond() % 1000
@@ -94,29 +132,101 @@ func (l *Logger) doRotateFile(ctx context.Context, filePath string) error {
 				fileName, now.Format("YmdHisu"), micro, fileExtName,
 			),
 		)
-		if !gfile.Exists(newFilePath) {
-			break
-		} else {
+		if gfile.Exists(newFilePath) {
 			intlog.Printf(ctx, `rotation file exists, continue: %s`, newFilePath)
+			newFilePath = ""
 		}
 	}
-	intlog.Printf(ctx, "rotating file by size from %s to %s", filePath, newFilePath)
+	intlog.Printf(ctx, "rotating file from %s to %s", filePath, newFilePath)
 	if err := gfile.Rename(filePath, newFilePath); err != nil {
 		return err
 	}
+	if l.config.RotateHandler != nil {
+		go l.handleRotatedFile(context.WithoutCancel(ctx), newFilePath)
+	}
 	return nil
 }
 
+// handleRotatedFile compresses the rotated file if compression enabled,
+// and then calls the RotateHandler with the final rotated file path.
+func (l *Logger) handleRotatedFile(ctx context.Context, path string) {
+	if l.config.RotateBackupCompress > 0 {
+		compressedPath, err := l.compressBackupFile(ctx, path)
+		if err != nil {
+			intlog.Errorf(ctx, `%+v`, err)
+		} else {
+			path = compressedPath
+		}
+	}
+	l.config.RotateHandler(ctx, path)
+}
+
+// compressBackupFile compresses the backup file `path` using configured compression format,
+// removes the original file and returns the compressed file path.
+// It is concurrent safe, and it returns the compressed file path directly if it is already compressed.
+func (l *Logger) compressBackupFile(ctx context.Context, path string) (string, error) {
+	var (
+		format         = l.getRotateCompressFormat()
+		compressedPath = path + "." + rotateCompressExtNames[format]
+		memoryLockKey  = memoryLockPrefixForCompressing + path
+	)
+	gmlock.Lock(memoryLockKey)
+	defer gmlock.Unlock(memoryLockKey)
+
+	if !gfile.Exists(path) && gfile.Exists(compressedPath) {
+		return compressedPath, nil
+	}
+	var err error
+	switch format {
+	case RotateCompressZlib:
+		var data []byte
+		if data, err = gcompress.Zlib(gfile.GetBytes(path)); err == nil {
+			err = gfile.PutBytes(compressedPath, data)
+		}
+	case RotateCompressZip:
+		err = gcompress.ZipPath(path, compressedPath)
+	default:
+		err = gcompress.GzipFile(path, compressedPath, l.config.RotateBackupCompress)
+	}
+	if err != nil {
+		return "", err
+	}
+	intlog.Printf(ctx, `compressed done, remove original logging file: %s`, path)
+	if err = gfile.RemoveFile(path); err != nil {
+		return "", err
+	}
+	return compressedPath, nil
+}
+
+// getRotateCompressFormat returns the configured compression format, which is gzip in default.
+func (l *Logger) getRotateCompressFormat() string {
+	if _, ok := rotateCompressExtNames[l.config.RotateCompressFormat]; ok {
+		return l.config.RotateCompressFormat
+	}
+	return RotateCompressGzip
+}
+
+// isCompressedBackupFile checks and returns whether `path` is a compressed backup file.
+func isCompressedBackupFile(path string) bool {
+	var extName = gfile.ExtName(path)
+	for _, compressExtName := range rotateCompressExtNames {
+		if extName == compressExtName {
+			return true
+		}
+	}
+	return false
+}
+
 // rotateChecksTimely timely checks the backups expiration and the compression.
 func (l *Logger) rotateChecksTimely(ctx context.Context) {
 	defer gtimer.AddOnce(ctx, l.config.RotateCheckInterval, l.rotateChecksTimely)
 
 	// Checks whether file rotation not enabled.
-	if l.config.RotateSize <= 0 && l.config.RotateExpire == 0 {
+	if l.config.RotateSize <= 0 && l.config.RotateExpire == 0 && l.config.RotatePeriod == "" {
 		intlog.Printf(
 			ctx,
-			"logging rotation ignore checks: RotateSize: %d, RotateExpire: %s",
-			l.config.RotateSize, l.config.RotateExpire.String(),
+			"logging rotation ignore checks: RotateSize: %d, RotateExpire: %s, RotatePeriod: %s",
+			l.config.RotateSize, l.config.RotateExpire.String(), l.config.RotatePeriod,
 		)
 		return
 	}
@@ -130,7 +240,7 @@ func (l *Logger) rotateChecksTimely(ctx context.Context) {
 
 	var (
 		now        = time.Now()
-		pattern    = "*.log, *.gz"
+		pattern    = "*.log, *.gz, *.zlib, *.zip"
 		files, err = gfile.ScanDirFile(l.config.Path, pattern, true)
 	)
 	if err != nil {
@@ -153,7 +263,7 @@ func (l *Logger) rotateChecksTimely(ctx context.Context) {
 		)
 		for _, file := range files {
 			// ignore backup file
-			if gregex.IsMatchString(`.+\.\d{20}\.log`, gfile.Basename(file)) || gfile.ExtName(file) == "gz" {
+			if gregex.IsMatchString(`.+\.\d{20}\.log`, gfile.Basename(file)) || isCompressedBackupFile(file) {
 				continue
 			}
 			// ignore not matching file
@@ -175,7 +285,7 @@ func (l *Logger) rotateChecksTimely(ctx context.Context) {
 						`%v - %v = %v > %v, rotation expire logging file: %s`,
 						now, mtime, subDuration, l.config.RotateExpire, file,
 					)
-					if err = l.doRotateFile(ctx, file); err != nil {
+					if err = l.doRotateFile(ctx, file, ""); err != nil {
 						intlog.Errorf(ctx, `%+v`, err)
 					}
 				}()
@@ -197,7 +307,7 @@ func (l *Logger) rotateChecksTimely(ctx context.Context) {
 	if l.config.RotateBackupCompress > 0 {
 		for _, file := range files {
 			// Eg: access.20200326101301899002.log.gz
-			if gfile.ExtName(file) == "gz" {
+			if isCompressedBackupFile(file) {
 				continue
 			}
 			// ignore not matching file
@@ -213,13 +323,7 @@ func (l *Logger) rotateChecksTimely(ctx context.Context) {
 		}
 		if needCompressFileArray.Len() > 0 {
 			needCompressFileArray.Iterator(func(_ int, path string) bool {
-				err := gcompress.GzipFile(path, path+".gz")
-				if err == nil {
-					intlog.Printf(ctx, `compressed done, remove original logging file: %s`, path)
-					if err = gfile.RemoveFile(path); err != nil {
-						intlog.Print(ctx, err)
-					}
-				} else {
+				if _, err := l.compressBackupFile(ctx, path); err != nil {
 					intlog.Print(ctx, err)
 				}
 				return true
@@ -261,6 +365,10 @@ func (l *Logger) rotateChecksTimely(ctx context.Context) {
 		}
 		intlog.Printf(ctx, `calculated backup files array: %+v`, backupFiles)
 		diff := backupFiles.Len() - l.config.RotateBackupLimit
+		// There's no backup limit for calendar rotation if it is not configured.
+		if l.config.RotateBackupLimit == 0 && l.config.RotatePeriod != "" {
+			diff = 0
+		}
 		for i := 0; i < diff; i++ {
 			path, _ := backupFiles.PopLeft()
 			intlog.Printf(ctx, `remove exceeded backup limit file: %s`, path)
@@ -0,0 +1,110 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gmlock"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/os/gtimer"
+)
+
+// Calendar periods for logging file rotation.
+const (
+	RotatePeriodHourly = "hourly" // Rotates the logging file at the beginning of every hour.
+	RotatePeriodDaily  = "daily"  // Rotates the logging file at the beginning of every day.
+)
+
+// rotateFileByPeriod rotates the logging file `filePath` if it was last written before
+// the current calendar period of `now`.
+//
+// The rotated file name is derived from the period it was written in, like:
+// access.log -> access.20200326100000000000.log (hourly, period starting at 2020-03-26 10:00:00)
+// access.log -> access.20200326000000000000.log (daily, period starting at 2020-03-26 00:00:00)
+func (l *Logger) rotateFileByPeriod(ctx context.Context, filePath string, now time.Time) {
+	periodStart := l.getRotatePeriodStart(now)
+	if periodStart.IsZero() || !gfile.Exists(filePath) {
+		return
+	}
+	mtime := gfile.MTime(filePath)
+	if !mtime.Before(periodStart) {
+		return
+	}
+	intlog.Printf(
+		ctx,
+		`%v < %v, rotation period logging file: %s`,
+		mtime, periodStart, filePath,
+	)
+	var backupFilePath = gfile.Join(
+		gfile.Dir(filePath),
+		fmt.Sprintf(
+			`%s.%s000000.%s`,
+			gfile.Name(filePath), gtime.New(l.getRotatePeriodStart(mtime)).Format("YmdHis"), gfile.ExtName(filePath),
+		),
+	)
+	// It falls back to the backup file name of current time if the period one exists,
+	// which might be rotated by size in the same period.
+	if gfile.Exists(backupFilePath) {
+		backupFilePath = ""
+	}
+	if err := l.doRotateFile(ctx, filePath, backupFilePath); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+}
+
+// rotatePeriodTimely rotates the logging file of the last period at calendar boundaries,
+// so that the logging file is rotated in time even if there's no logging in the new period.
+func (l *Logger) rotatePeriodTimely(ctx context.Context) {
+	var (
+		now         = time.Now()
+		periodStart = l.getRotatePeriodStart(now)
+	)
+	if periodStart.IsZero() {
+		return
+	}
+	defer gtimer.AddOnce(ctx, l.getRotatePeriodEnd(now).Sub(now), l.rotatePeriodTimely)
+
+	var (
+		filePath      = l.getFilePath(periodStart.Add(-time.Nanosecond))
+		memoryLockKey = memoryLockPrefixForPrintingToFile + filePath
+	)
+	gmlock.Lock(memoryLockKey)
+	defer gmlock.Unlock(memoryLockKey)
+	l.rotateFileByPeriod(ctx, filePath, now)
+}
+
+// getRotatePeriodStart returns the start time of the calendar period that `t` is in.
+// It returns zero time if calendar rotation is not enabled.
+func (l *Logger) getRotatePeriodStart(t time.Time) time.Time {
+	switch l.config.RotatePeriod {
+	case RotatePeriodHourly:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	case RotatePeriodDaily:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	default:
+		return time.Time{}
+	}
+}
+
+// getRotatePeriodEnd returns the end time of the calendar period that `t` is in,
+// which is also the start time of the next period.
+func (l *Logger) getRotatePeriodEnd(t time.Time) time.Time {
+	start := l.getRotatePeriodStart(t)
+	switch l.config.RotatePeriod {
+	case RotatePeriodHourly:
+		return start.Add(time.Hour)
+	case RotatePeriodDaily:
+		return start.AddDate(0, 0, 1)
+	default:
+		return time.Time{}
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// newAsyncTestLogger creates a logger in async mode, of which the handling blocks until `release` is closed.
+// It sends to `handling` when the handler is called.
+func newAsyncTestLogger(
+	w *bytes.Buffer, bufferSize int, overflow string, handling chan struct{}, release chan struct{},
+) *glog.Logger {
+	l := glog.NewWithWriter(w)
+	config := l.GetConfig()
+	config.StdoutPrint = false
+	config.HeaderPrint = false
+	config.AsyncBufferSize = bufferSize
+	config.AsyncOverflow = overflow
+	config.AsyncSampleRate = 2
+	config.AsyncFlushInterval = time.Hour
+	_ = l.SetConfig(config)
+	l.SetAsync(true)
+	if release != nil {
+		l.SetHandlers(func(ctx context.Context, in *glog.HandlerInput) {
+			select {
+			case handling <- struct{}{}:
+			default:
+			}
+			<-release
+			in.Next(ctx)
+		})
+	}
+	return l
+}
+
+func Test_Async_Buffered(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx = context.TODO()
+			w   = bytes.NewBuffer(nil)
+			l   = newAsyncTestLogger(w, 100, "", nil, nil)
+		)
+		defer l.Close()
+		for i := 1; i <= 10; i++ {
+			l.Print(ctx, i)
+		}
+		l.Flush()
+		t.Assert(w.String(), "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n")
+		t.Assert(l.GetAsyncStats().Written, 10)
+		t.Assert(l.GetAsyncStats().Dropped, 0)
+		t.Assert(l.GetAsyncStats().Buffered, 0)
+
+		// Cloned logger shares the async writer.
+		l.Cat("cat").Print(ctx, 11)
+		l.Close()
+		t.Assert(gstr.Count(w.String(), "11\n"), 1)
+	})
+	// Batch is flushed periodically.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx    = context.TODO()
+			w      = bytes.NewBuffer(nil)
+			l      = glog.NewWithWriter(w)
+			config = l.GetConfig()
+		)
+		config.StdoutPrint = false
+		config.HeaderPrint = false
+		config.AsyncBufferSize = 100
+		config.AsyncFlushInterval = 50 * time.Millisecond
+		t.AssertNil(l.SetConfig(config))
+		l.SetAsync(true)
+		defer l.Close()
+		l.Print(ctx, 1)
+		time.Sleep(200 * time.Millisecond)
+		l.Flush()
+		t.Assert(w.String(), "1\n")
+	})
+}
+
+func Test_Async_FlushAll(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx = context.TODO()
+			w1  = bytes.NewBuffer(nil)
+			w2  = bytes.NewBuffer(nil)
+			l1  = newAsyncTestLogger(w1, 100, "", nil, nil)
+			l2  = newAsyncTestLogger(w2, 100, "", nil, nil)
+		)
+		defer l1.Close()
+		l1.Print(ctx, 1)
+		l2.Print(ctx, 2)
+		glog.FlushAll()
+		t.Assert(w1.String(), "1\n")
+		t.Assert(w2.String(), "2\n")
+
+		// Closed logger is not flushed any more.
+		l2.Close()
+		l1.Print(ctx, 3)
+		glog.FlushAll()
+		t.Assert(w1.String(), "1\n3\n")
+	})
+}
+
+func Test_Async_Panic_Flush(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx = context.TODO()
+			w   = bytes.NewBuffer(nil)
+			l   = newAsyncTestLogger(w, 100, "", nil, nil)
+		)
+		defer l.Close()
+		l.Print(ctx, "before")
+		func() {
+			defer func() {
+				t.Assert(recover(), "panic")
+			}()
+			l.Panic(ctx, "panic")
+		}()
+		t.Assert(gstr.Count(w.String(), "before\n"), 1)
+		t.Assert(gstr.Count(w.String(), "panic"), 1)
+	})
+}
+
+func Test_Async_Overflow(t *testing.T) {
+	var (
+		ctx    = context.TODO()
+		expect = map[string]string{
+			glog.AsyncOverflowDropNewest: "1\n2\n3\n",
+			glog.AsyncOverflowDropOldest: "1\n9\n10\n",
+			glog.AsyncOverflowSample:     "1\n7\n9\n",
+		}
+	)
+	for overflow, content := range expect {
+		gtest.C(t, func(t *gtest.T) {
+			var (
+				w        = bytes.NewBuffer(nil)
+				handling = make(chan struct{}, 1)
+				release  = make(chan struct{})
+				l        = newAsyncTestLogger(w, 2, overflow, handling, release)
+			)
+			defer l.Close()
+			l.Print(ctx, 1)
+			<-handling
+			for i := 2; i <= 10; i++ {
+				l.Print(ctx, i)
+			}
+			t.Assert(l.GetAsyncStats().Buffered, 2)
+			t.Assert(l.GetAsyncStats().Dropped, 7)
+			close(release)
+			l.Flush()
+			t.Assert(w.String(), content)
+			t.Assert(l.GetAsyncStats().Written, 3)
+		})
+	}
+	// Block.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			w        = bytes.NewBuffer(nil)
+			handling = make(chan struct{}, 1)
+			release  = make(chan struct{})
+			done     = make(chan struct{})
+			l        = newAsyncTestLogger(w, 1, glog.AsyncOverflowBlock, handling, release)
+		)
+		defer l.Close()
+		l.Print(ctx, 1)
+		<-handling
+		l.Print(ctx, 2)
+		go func() {
+			l.Print(ctx, 3)
+			close(done)
+		}()
+		select {
+		case <-done:
+			t.Error("logging should be blocked")
+		case <-time.After(100 * time.Millisecond):
+		}
+		close(release)
+		<-done
+		l.Flush()
+		t.Assert(w.String(), "1\n2\n3\n")
+		t.Assert(l.GetAsyncStats().Dropped, 0)
+	})
+}
+
+func Test_Async_File(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx  = context.TODO()
+			path = gfile.Temp(gtime.TimestampNanoStr())
+			l    = glog.New()
+		)
+		defer gfile.Remove(path)
+		t.AssertNil(l.SetConfigWithMap(map[string]any{
+			"path":            path,
+			"file":            "access.log",
+			"stdout":          false,
+			"asyncBufferSize": 10,
+		}))
+		l.SetAsync(true)
+		for i := 0; i < 100; i++ {
+			l.Info(ctx, "async file")
+		}
+		l.Close()
+		t.Assert(gstr.Count(gfile.GetContents(gfile.Join(path, "access.log")), "[INFO] async file"), 100)
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+func TestSlogHandler(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		w := bytes.NewBuffer(nil)
+		l := glog.NewWithWriter(w)
+		l.SetStdoutPrint(false)
+		logger := slog.New(glog.NewSlogHandler(l))
+
+		logger.Info("hello", "user", "john", "age", 18)
+		t.Assert(gstr.Count(w.String(), "[INFO]"), 1)
+		t.Assert(gstr.Count(w.String(), "hello user john age 18"), 1)
+
+		w.Reset()
+		logger.With("app", "demo").WithGroup("req").Warn(
+			"slow", "cost", 100, slog.Group("user", "id", 1),
+		)
+		t.Assert(gstr.Count(w.String(), "[WARN]"), 1)
+		t.Assert(gstr.Count(w.String(), "slow app demo req.cost 100 req.user.id 1"), 1)
+
+		w.Reset()
+		logger.Error("failed", "error", errors.New("boom"))
+		t.Assert(gstr.Count(w.String(), "[ERRO]"), 1)
+		t.Assert(gstr.Count(w.String(), "failed error boom"), 1)
+
+		w.Reset()
+		logger.Log(context.Background(), slog.LevelInfo+2, "notice")
+		t.Assert(gstr.Count(w.String(), "[NOTI] notice"), 1)
+	})
+	// Level.
+	gtest.C(t, func(t *gtest.T) {
+		w := bytes.NewBuffer(nil)
+		l := glog.NewWithWriter(w)
+		l.SetStdoutPrint(false)
+		l.SetLevel(glog.LEVEL_WARN | glog.LEVEL_ERRO)
+		logger := slog.New(glog.NewSlogHandler(l))
+
+		t.Assert(logger.Enabled(context.Background(), slog.LevelInfo), false)
+		t.Assert(logger.Enabled(context.Background(), slog.LevelWarn), true)
+		logger.Debug("debug")
+		logger.Info("info")
+		t.Assert(w.String(), "")
+		logger.Warn("warn")
+		t.Assert(gstr.Count(w.String(), "warn"), 1)
+	})
+	// Context keys and structured output.
+	gtest.C(t, func(t *gtest.T) {
+		w := bytes.NewBuffer(nil)
+		l := glog.NewWithWriter(w)
+		l.SetStdoutPrint(false)
+		l.SetHandlers(glog.HandlerStructure)
+		l.SetCtxKeys("Trace-Id")
+		ctx := context.WithValue(context.Background(), "Trace-Id", "1234567890")
+		slog.New(glog.NewSlogHandler(l)).InfoContext(ctx, "hello world", "user", "john")
+		t.Assert(gstr.Count(w.String(), `CtxStr=1234567890`), 1)
+		t.Assert(gstr.Count(w.String(), `Content="hello world" user=john`), 1)
+	})
+	// Trace id.
+	gtest.C(t, func(t *gtest.T) {
+		w := bytes.NewBuffer(nil)
+		l := glog.NewWithWriter(w)
+		l.SetStdoutPrint(false)
+		ctx := gctx.New()
+		slog.New(glog.NewSlogHandler(l)).InfoContext(ctx, "traced")
+		t.Assert(gstr.Count(w.String(), gctx.CtxId(ctx)), 1)
+	})
+}
+
+func TestHandlerSlog(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			w = bytes.NewBuffer(nil)
+			s = bytes.NewBuffer(nil)
+			l = glog.NewWithWriter(w)
+		)
+		l.SetStdoutPrint(false)
+		l.SetCtxKeys("Trace-Id")
+		l.SetHandlers(glog.HandlerSlog(slog.NewJSONHandler(s, &slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		})))
+		ctx := context.WithValue(context.Background(), "Trace-Id", "1234567890")
+
+		l.Info(ctx, "hello", "user", "john")
+		t.Assert(w.String(), "")
+		t.Assert(gstr.Count(s.String(), `"level":"INFO"`), 1)
+		t.Assert(gstr.Count(s.String(), `"msg":"hello"`), 1)
+		t.Assert(gstr.Count(s.String(), `"user":"john"`), 1)
+		t.Assert(gstr.Count(s.String(), `"CtxStr":"1234567890"`), 1)
+
+		s.Reset()
+		l.Debug(ctx, "debug")
+		t.Assert(s.String(), "")
+
+		l.Warningf(ctx, "warn %d", 1)
+		t.Assert(gstr.Count(s.String(), `"level":"WARN"`), 1)
+		t.Assert(gstr.Count(s.String(), `"msg":"warn 1"`), 1)
+
+		s.Reset()
+		l.Print(ctx, "print")
+		t.Assert(gstr.Count(s.String(), `"level":"INFO"`), 1)
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gcompress"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+func newRotatePeriodTestLogger(t *gtest.T, config g.Map) (*glog.Logger, string, chan string) {
+	var (
+		l       = glog.New()
+		p       = gfile.Temp(gtime.TimestampNanoStr())
+		rotated = make(chan string, 10)
+	)
+	config["Path"] = p
+	config["File"] = "access.log"
+	config["StdoutPrint"] = false
+	if _, ok := config["RotateBackupLimit"]; !ok {
+		config["RotateBackupLimit"] = 10
+	}
+	t.AssertNil(l.SetConfigWithMap(config))
+	c := l.GetConfig()
+	c.RotateHandler = func(ctx context.Context, path string) {
+		rotated <- path
+	}
+	t.AssertNil(l.SetConfig(c))
+	return l, p, rotated
+}
+
+func Test_Rotate_Period(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		l, p, rotated := newRotatePeriodTestLogger(t, g.Map{
+			"RotatePeriod": "hourly",
+		})
+		defer gfile.Remove(p)
+
+		var (
+			filePath = gfile.Join(p, "access.log")
+			mtime    = time.Now().Add(-2 * time.Hour)
+		)
+		l.Print(ctx, "last period")
+		t.AssertNil(os.Chtimes(filePath, mtime, mtime))
+		l.Print(ctx, "current period")
+
+		select {
+		case path := <-rotated:
+			t.Assert(gfile.Basename(path), "access."+gtime.New(mtime).Format("YmdH")+"0000000000.log")
+			t.Assert(gstr.Count(gfile.GetContents(path), "last period"), 1)
+			t.Assert(gstr.Count(gfile.GetContents(path), "current period"), 0)
+		case <-time.After(time.Second):
+			t.Error("rotate handler is not called")
+		}
+		t.Assert(gstr.Count(gfile.GetContents(filePath), "last period"), 0)
+		t.Assert(gstr.Count(gfile.GetContents(filePath), "current period"), 1)
+
+		// No rotation in the same period.
+		l.Print(ctx, "current period")
+		t.Assert(gstr.Count(gfile.GetContents(filePath), "current period"), 2)
+		select {
+		case path := <-rotated:
+			t.Error("unexpected rotation:", path)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
+
+func Test_Rotate_Period_DefaultBackupLimit(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		l, p, rotated := newRotatePeriodTestLogger(t, g.Map{
+			"RotatePeriod":      "daily",
+			"RotateBackupLimit": 0,
+		})
+		defer gfile.Remove(p)
+
+		var (
+			filePath = gfile.Join(p, "access.log")
+			mtime    = time.Now().AddDate(0, 0, -2)
+		)
+		l.Print(ctx, "last period")
+		t.AssertNil(os.Chtimes(filePath, mtime, mtime))
+		l.Print(ctx, "current period")
+
+		select {
+		case path := <-rotated:
+			t.Assert(gfile.Basename(path), "access."+gtime.New(mtime).Format("Ymd")+"000000000000.log")
+			t.Assert(gstr.Count(gfile.GetContents(path), "last period"), 1)
+		case <-time.After(time.Second):
+			t.Error("rotate handler is not called")
+		}
+		t.Assert(gstr.Count(gfile.GetContents(filePath), "current period"), 1)
+	})
+}
+
+func Test_Rotate_CompressFormat(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		l, p, rotated := newRotatePeriodTestLogger(t, g.Map{
+			"RotatePeriod":         "daily",
+			"RotateBackupCompress": 9,
+			"RotateCompressFormat": "zlib",
+		})
+		defer gfile.Remove(p)
+
+		var (
+			filePath = gfile.Join(p, "access.log")
+			mtime    = time.Now().AddDate(0, 0, -1)
+		)
+		l.Print(ctx, "last period")
+		t.AssertNil(os.Chtimes(filePath, mtime, mtime))
+		l.Print(ctx, "current period")
+
+		select {
+		case path := <-rotated:
+			t.Assert(gfile.Basename(path), "access."+gtime.New(mtime).Format("Ymd")+"000000000000.log.zlib")
+			data, err := gcompress.UnZlib(gfile.GetBytes(path))
+			t.AssertNil(err)
+			t.Assert(gstr.Count(string(data), "last period"), 1)
+			t.Assert(gfile.Exists(gstr.TrimRightStr(path, ".zlib")), false)
+		case <-time.After(time.Second):
+			t.Error("rotate handler is not called")
+		}
+	})
+}
+
+func Test_Rotate_InvalidConfig(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		l := glog.New()
+		t.AssertNE(l.SetConfigWithMap(g.Map{"RotatePeriod": "weekly"}), nil)
+		t.AssertNE(l.SetConfigWithMap(g.Map{"RotatePeriod": "", "RotateCompressFormat": "rar"}), nil)
+		t.AssertNil(l.SetConfigWithMap(g.Map{"RotatePeriod": "daily", "RotateCompressFormat": "zip"}))
+	})
+}
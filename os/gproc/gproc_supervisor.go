@@ -0,0 +1,425 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gproc
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/glog"
+)
+
+// RestartPolicy is the policy for restarting the supervised process after it exits.
+type RestartPolicy string
+
+// SupervisorState is the state of the supervised process.
+type SupervisorState string
+
+const (
+	RestartNever     RestartPolicy = "never"      // Never restart the process.
+	RestartAlways    RestartPolicy = "always"     // Always restart the process after it exits.
+	RestartOnFailure RestartPolicy = "on-failure" // Restart the process only if it exits with non-zero code or is unhealthy.
+)
+
+const (
+	SupervisorStateStopped  SupervisorState = "stopped"  // Supervisor is not started or is stopped.
+	SupervisorStateRunning  SupervisorState = "running"  // Process is running.
+	SupervisorStateBackoff  SupervisorState = "backoff"  // Process exited and is waiting for restarting.
+	SupervisorStateStopping SupervisorState = "stopping" // Process is being stopped.
+	SupervisorStateExited   SupervisorState = "exited"   // Process exited and is not restarted according to the policy.
+	SupervisorStateFatal    SupervisorState = "fatal"    // Process is not restarted as it reaches the max restarts.
+)
+
+const (
+	defaultSupervisorBackoffMin          = time.Second
+	defaultSupervisorBackoffMax          = 30 * time.Second
+	defaultSupervisorStableTime          = 10 * time.Second
+	defaultSupervisorStopTimeout         = 10 * time.Second
+	defaultSupervisorHealthCheckInterval = 10 * time.Second
+	defaultSupervisorHealthCheckFailures = 3
+)
+
+// SupervisorConfig is the configuration for Supervisor.
+type SupervisorConfig struct {
+	Name          string        // Name of the process, which is used as logging prefix. It is the Path in default.
+	Path          string        // Path of the executable binary.
+	Args          []string      // Arguments for the process, excluding the binary path.
+	Env           []string      // Additional environment variables, the ones of current process are inherited.
+	Dir           string        // Working directory, which is the working directory of current process in default.
+	RestartPolicy RestartPolicy // Restart policy, RestartOnFailure in default.
+	MaxRestarts   int           // Max consecutive restarts before it gives up, no limit if it is 0.
+	BackoffMin    time.Duration // Initial delay before restarting, which doubles for each consecutive restart, 1 second in default.
+	BackoffMax    time.Duration // Max delay before restarting, 30 seconds in default.
+	StableTime    time.Duration // Process running longer than this resets the backoff and consecutive restarts, 10 seconds in default.
+	StopSignal    os.Signal     // Signal sent for graceful stopping, SIGTERM in default.
+	StopTimeout   time.Duration // The process is killed if it does not exit in this duration after StopSignal sent, 10 seconds in default.
+	Logger        *glog.Logger  // Logger for stdout(INFO) and stderr(WARN) lines of process, the default logger in default.
+
+	// HealthCheck checks the health of running process periodically.
+	// The process is restarted if it fails HealthCheckFailures times consecutively,
+	// unless the RestartPolicy is RestartNever.
+	HealthCheck func(ctx context.Context, s *Supervisor) error
+
+	// HealthCheckInterval is the interval of HealthCheck, 10 seconds in default.
+	HealthCheckInterval time.Duration
+
+	// HealthCheckFailures is the consecutive failures of HealthCheck for restarting, 3 in default.
+	HealthCheckFailures int
+
+	// OnStateChange is called with the latest status after the state changes.
+	OnStateChange func(status SupervisorStatus)
+}
+
+// SupervisorStatus is the status report of Supervisor.
+type SupervisorStatus struct {
+	Name      string          // Name of the process.
+	State     SupervisorState // Current state.
+	Pid       int             // Pid of the running process, which is 0 if it is not running.
+	Restarts  int             // Total restart count.
+	StartTime time.Time       // Start time of the latest process.
+	ExitCode  int             // Exit code of the latest exited process, which is -1 if it is killed by signal.
+	Healthy   bool            // Whether the latest health check passes, it is true if no health check.
+	LastError error           // Latest error of starting, exiting or health checking.
+}
+
+// Supervisor keeps a child process alive by restarting it according to the restart policy.
+type Supervisor struct {
+	config  SupervisorConfig
+	mu      sync.RWMutex     // Mutex for status and process.
+	status  SupervisorStatus // Current status.
+	process *Process         // Current running process.
+	started bool             // Whether the supervisor is started.
+	stop    func()           // Closes the stopping channel of current starting only once.
+	done    chan struct{}    // Closed when the supervising goroutine exits.
+}
+
+// NewSupervisor creates and returns a supervisor with given `config`.
+// The process is not started until Start is called.
+func NewSupervisor(config SupervisorConfig) *Supervisor {
+	if config.Name == "" {
+		config.Name = config.Path
+	}
+	if config.RestartPolicy == "" {
+		config.RestartPolicy = RestartOnFailure
+	}
+	if config.BackoffMin <= 0 {
+		config.BackoffMin = defaultSupervisorBackoffMin
+	}
+	if config.BackoffMax < config.BackoffMin {
+		config.BackoffMax = max(defaultSupervisorBackoffMax, config.BackoffMin)
+	}
+	if config.StableTime <= 0 {
+		config.StableTime = defaultSupervisorStableTime
+	}
+	if config.StopSignal == nil {
+		config.StopSignal = syscall.SIGTERM
+	}
+	if config.StopTimeout <= 0 {
+		config.StopTimeout = defaultSupervisorStopTimeout
+	}
+	if config.Logger == nil {
+		config.Logger = glog.DefaultLogger()
+	}
+	if config.HealthCheckInterval <= 0 {
+		config.HealthCheckInterval = defaultSupervisorHealthCheckInterval
+	}
+	if config.HealthCheckFailures <= 0 {
+		config.HealthCheckFailures = defaultSupervisorHealthCheckFailures
+	}
+	return &Supervisor{
+		config: config,
+		status: SupervisorStatus{
+			Name:    config.Name,
+			State:   SupervisorStateStopped,
+			Healthy: true,
+		},
+	}
+}
+
+// Start starts the process and keeps it alive in background.
+// It returns error if the process fails starting for the first time, in which case it is not restarted.
+func (s *Supervisor) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.started {
+		s.mu.Unlock()
+		return gerror.NewCodef(gcode.CodeInvalidOperation, `supervisor "%s" is already started`, s.config.Name)
+	}
+	s.started = true
+	stopChan := make(chan struct{})
+	s.stop = sync.OnceFunc(func() {
+		close(stopChan)
+	})
+	done := make(chan struct{})
+	s.done = done
+	s.status.Restarts = 0
+	s.mu.Unlock()
+
+	process, err := s.startProcess(ctx)
+	if err != nil {
+		s.mu.Lock()
+		s.started = false
+		s.mu.Unlock()
+		s.setState(SupervisorStateFatal, func(status *SupervisorStatus) {
+			status.LastError = err
+		})
+		close(done)
+		return err
+	}
+	go s.supervise(ctx, process, stopChan, done)
+	return nil
+}
+
+// Stop gracefully stops the process and the supervising.
+// It sends StopSignal to the process and kills it if it does not exit in StopTimeout.
+// It returns the error of `ctx` if `ctx` is done before the process exits.
+func (s *Supervisor) Stop(ctx context.Context) error {
+	s.mu.RLock()
+	var (
+		started = s.started
+		stop    = s.stop
+		done    = s.done
+	)
+	s.mu.RUnlock()
+	if !started {
+		return nil
+	}
+	stop()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Wait blocks until the supervising exits, which is caused by Stop or the process is not restarted any more.
+func (s *Supervisor) Wait() {
+	s.mu.RLock()
+	done := s.done
+	s.mu.RUnlock()
+	if done != nil {
+		<-done
+	}
+}
+
+// Status returns the current status of the supervisor.
+func (s *Supervisor) Status() SupervisorStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status
+}
+
+// Process returns the current running process, or nil if it is not running.
+func (s *Supervisor) Process() *Process {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.process
+}
+
+// startProcess creates and starts a new process, and updates the status as running.
+func (s *Supervisor) startProcess(ctx context.Context) (*Process, error) {
+	process := NewProcess(s.config.Path, s.config.Args, s.config.Env)
+	if s.config.Dir != "" {
+		process.Dir = s.config.Dir
+	}
+	process.Stdin = nil
+	// It avoids blocking on the output pipes which are held by orphaned grandchild processes.
+	process.WaitDelay = s.config.StopTimeout
+	process.Stdout = newSupervisorLogWriter(ctx, s.config.Logger, s.config.Name, false)
+	process.Stderr = newSupervisorLogWriter(ctx, s.config.Logger, s.config.Name, true)
+	pid, err := process.Start(ctx)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `start process "%s" failed`, s.config.Name)
+	}
+	s.mu.Lock()
+	s.process = process
+	s.mu.Unlock()
+	s.setState(SupervisorStateRunning, func(status *SupervisorStatus) {
+		status.Pid = pid
+		status.StartTime = time.Now()
+		status.Healthy = true
+	})
+	return process, nil
+}
+
+// supervise waits for the process exiting and restarts it according to the policy,
+// until the supervisor is stopped or the process is not restarted any more.
+// The `stopChan` and `done` are the ones of current starting, as they are replaced by the next Start.
+func (s *Supervisor) supervise(ctx context.Context, process *Process, stopChan <-chan struct{}, done chan struct{}) {
+	defer func() {
+		s.mu.Lock()
+		s.started = false
+		s.process = nil
+		s.mu.Unlock()
+		close(done)
+	}()
+	var (
+		backoff     = s.config.BackoffMin
+		consecutive = 0
+	)
+	for {
+		var (
+			exitErr, stopped, unhealthy = s.watch(ctx, process, stopChan)
+			exitCode                    = -1
+		)
+		if process != nil {
+			flushSupervisorLogWriters(process)
+			if process.ProcessState != nil {
+				exitCode = process.ProcessState.ExitCode()
+			}
+		}
+		if stopped {
+			s.setState(SupervisorStateStopped, func(status *SupervisorStatus) {
+				status.Pid = 0
+				status.ExitCode = exitCode
+			})
+			return
+		}
+		var (
+			failed    = exitErr != nil || unhealthy
+			startTime = s.Status().StartTime
+		)
+		s.setState(SupervisorStateExited, func(status *SupervisorStatus) {
+			status.Pid = 0
+			status.ExitCode = exitCode
+			if exitErr != nil {
+				status.LastError = exitErr
+			}
+		})
+		switch s.config.RestartPolicy {
+		case RestartNever:
+			return
+		case RestartOnFailure:
+			if !failed {
+				return
+			}
+		}
+		if process != nil && time.Since(startTime) >= s.config.StableTime {
+			backoff, consecutive = s.config.BackoffMin, 0
+		}
+		if s.config.MaxRestarts > 0 && consecutive >= s.config.MaxRestarts {
+			s.setState(SupervisorStateFatal, nil)
+			return
+		}
+		s.setState(SupervisorStateBackoff, nil)
+		select {
+		case <-stopChan:
+			s.setState(SupervisorStateStopped, nil)
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, s.config.BackoffMax)
+		consecutive++
+		s.mu.Lock()
+		s.status.Restarts++
+		s.mu.Unlock()
+		var err error
+		if process, err = s.startProcess(ctx); err != nil {
+			s.mu.Lock()
+			s.status.LastError = err
+			s.mu.Unlock()
+		}
+	}
+}
+
+// watch waits for the running `process` exiting while checking its health and the stopping.
+// It returns the exiting error of process, whether it is stopped and whether it is unhealthy.
+// The `process` can be nil if it fails starting, in which case it returns immediately as a failure.
+func (s *Supervisor) watch(
+	ctx context.Context, process *Process, stopChan <-chan struct{},
+) (exitErr error, stopped, unhealthy bool) {
+	if process == nil {
+		return gerror.NewCodef(gcode.CodeInternalError, `process "%s" not started`, s.config.Name), false, false
+	}
+	var (
+		waitChan = make(chan error, 1)
+		failures = 0
+		ticker   *time.Ticker
+		tickChan <-chan time.Time
+	)
+	go func() {
+		waitChan <- process.Wait()
+	}()
+	if s.config.HealthCheck != nil {
+		ticker = time.NewTicker(s.config.HealthCheckInterval)
+		defer ticker.Stop()
+		tickChan = ticker.C
+	}
+	for {
+		select {
+		case exitErr = <-waitChan:
+			return exitErr, false, unhealthy
+
+		case <-stopChan:
+			s.setState(SupervisorStateStopping, nil)
+			return s.terminate(process, waitChan), true, unhealthy
+
+		case <-tickChan:
+			checkCtx, cancel := context.WithTimeout(ctx, s.config.HealthCheckInterval)
+			err := s.config.HealthCheck(checkCtx, s)
+			cancel()
+			if err == nil {
+				failures = 0
+				s.mu.Lock()
+				s.status.Healthy = true
+				s.mu.Unlock()
+				continue
+			}
+			failures++
+			s.mu.Lock()
+			s.status.Healthy = false
+			s.status.LastError = err
+			s.mu.Unlock()
+			if failures >= s.config.HealthCheckFailures && s.config.RestartPolicy != RestartNever {
+				s.config.Logger.Warningf(
+					ctx, `[%s] process is unhealthy after %d checks, restarting: %v`,
+					s.config.Name, failures, err,
+				)
+				unhealthy = true
+				s.setState(SupervisorStateStopping, nil)
+				return s.terminate(process, waitChan), false, unhealthy
+			}
+		}
+	}
+}
+
+// terminate sends StopSignal to `process` and kills it if it does not exit in StopTimeout.
+// It returns the exiting error of the process.
+func (s *Supervisor) terminate(process *Process, waitChan <-chan error) error {
+	if err := process.Signal(s.config.StopSignal); err != nil {
+		// Signal is not supported on some platforms like windows, it kills the process directly.
+		_ = process.Process.Kill()
+		return <-waitChan
+	}
+	select {
+	case err := <-waitChan:
+		return err
+	case <-time.After(s.config.StopTimeout):
+		_ = process.Process.Kill()
+		return <-waitChan
+	}
+}
+
+// setState sets the state and updates status using optional `update` function,
+// then it calls OnStateChange with the latest status.
+func (s *Supervisor) setState(state SupervisorState, update func(status *SupervisorStatus)) {
+	s.mu.Lock()
+	s.status.State = state
+	if update != nil {
+		update(&s.status)
+	}
+	status := s.status
+	s.mu.Unlock()
+	if s.config.OnStateChange != nil {
+		s.config.OnStateChange(status)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gproc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/gogf/gf/v2/os/glog"
+)
+
+const (
+	// supervisorLogMaxLineSize is the max size of a line buffered, the longer line is split.
+	supervisorLogMaxLineSize = 64 * 1024
+)
+
+// supervisorLogWriter writes the output of supervised process to logger line by line.
+type supervisorLogWriter struct {
+	mu     sync.Mutex
+	ctx    context.Context
+	logger *glog.Logger
+	name   string // Process name as logging prefix.
+	stderr bool   // Whether it is the stderr of process, which is logged in WARN level.
+	buffer []byte // Incomplete line buffered.
+}
+
+// newSupervisorLogWriter creates and returns a writer logging lines of process `name` to `logger`.
+func newSupervisorLogWriter(ctx context.Context, logger *glog.Logger, name string, stderr bool) *supervisorLogWriter {
+	return &supervisorLogWriter{
+		ctx:    ctx,
+		logger: logger,
+		name:   name,
+		stderr: stderr,
+	}
+}
+
+// Write implements the io.Writer interface, which logs each complete line in `p`.
+func (w *supervisorLogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buffer = append(w.buffer, p...)
+	for {
+		index := bytes.IndexByte(w.buffer, '\n')
+		if index < 0 {
+			break
+		}
+		w.log(w.buffer[:index])
+		w.buffer = w.buffer[index+1:]
+	}
+	if len(w.buffer) >= supervisorLogMaxLineSize {
+		w.log(w.buffer)
+		w.buffer = nil
+	}
+	return len(p), nil
+}
+
+// Flush logs the incomplete line buffered.
+func (w *supervisorLogWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buffer) > 0 {
+		w.log(w.buffer)
+		w.buffer = nil
+	}
+}
+
+// log logs a single `line`.
+func (w *supervisorLogWriter) log(line []byte) {
+	line = bytes.TrimSuffix(line, []byte{'\r'})
+	if w.stderr {
+		w.logger.Warningf(w.ctx, `[%s] %s`, w.name, line)
+	} else {
+		w.logger.Infof(w.ctx, `[%s] %s`, w.name, line)
+	}
+}
+
+// flushSupervisorLogWriters flushes the log writers of stdout and stderr of `process`.
+func flushSupervisorLogWriters(process *Process) {
+	for _, writer := range []io.Writer{process.Stdout, process.Stderr} {
+		if w, ok := writer.(*supervisorLogWriter); ok {
+			w.Flush()
+		}
+	}
+}
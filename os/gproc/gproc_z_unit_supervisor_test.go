@@ -0,0 +1,239 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+//go:build !windows
+
+package gproc_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/os/gproc"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// syncBuffer is a concurrent-safe buffer for capturing logging content.
+type syncBuffer struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.String()
+}
+
+func newSupervisorTestLogger() (*glog.Logger, *syncBuffer) {
+	var (
+		buffer = &syncBuffer{}
+		logger = glog.New()
+	)
+	logger.SetWriter(buffer)
+	logger.SetStdoutPrint(false)
+	return logger, buffer
+}
+
+func Test_Supervisor_RestartOnFailure(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx            = gctx.New()
+			logger, buffer = newSupervisorTestLogger()
+			states         []gproc.SupervisorState
+			mu             sync.Mutex
+		)
+		s := gproc.NewSupervisor(gproc.SupervisorConfig{
+			Name:        "worker",
+			Path:        "/bin/sh",
+			Args:        []string{"-c", "echo hello; echo oops >&2; exit 3"},
+			MaxRestarts: 2,
+			BackoffMin:  10 * time.Millisecond,
+			Logger:      logger,
+			OnStateChange: func(status gproc.SupervisorStatus) {
+				mu.Lock()
+				states = append(states, status.State)
+				mu.Unlock()
+			},
+		})
+		t.AssertNil(s.Start(ctx))
+		s.Wait()
+
+		status := s.Status()
+		t.Assert(status.Name, "worker")
+		t.Assert(status.State, gproc.SupervisorStateFatal)
+		t.Assert(status.Restarts, 2)
+		t.Assert(status.ExitCode, 3)
+		t.Assert(status.Pid, 0)
+		t.AssertNE(status.LastError, nil)
+		t.Assert(s.Process(), nil)
+
+		mu.Lock()
+		t.Assert(states[0], gproc.SupervisorStateRunning)
+		t.Assert(states[len(states)-1], gproc.SupervisorStateFatal)
+		t.AssertIN(gproc.SupervisorStateBackoff, states)
+		mu.Unlock()
+
+		content := buffer.String()
+		t.Assert(gstr.Count(content, "[worker] hello"), 3)
+		t.Assert(gstr.Count(content, "[worker] oops"), 3)
+		t.Assert(gstr.Count(content, "[INFO]"), 3)
+		t.Assert(gstr.Count(content, "[WARN]"), 3)
+	})
+	// Successful exit is not restarted.
+	gtest.C(t, func(t *gtest.T) {
+		logger, _ := newSupervisorTestLogger()
+		s := gproc.NewSupervisor(gproc.SupervisorConfig{
+			Path:       "/bin/sh",
+			Args:       []string{"-c", "exit 0"},
+			BackoffMin: 10 * time.Millisecond,
+			Logger:     logger,
+		})
+		t.AssertNil(s.Start(gctx.New()))
+		s.Wait()
+		t.Assert(s.Status().State, gproc.SupervisorStateExited)
+		t.Assert(s.Status().Restarts, 0)
+		t.Assert(s.Status().ExitCode, 0)
+	})
+	// Start failure.
+	gtest.C(t, func(t *gtest.T) {
+		s := gproc.NewSupervisor(gproc.SupervisorConfig{
+			Path: "/none-exist-binary",
+		})
+		t.AssertNE(s.Start(gctx.New()), nil)
+		t.Assert(s.Status().State, gproc.SupervisorStateFatal)
+		s.Wait()
+	})
+}
+
+func Test_Supervisor_RestartAlways(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		logger, _ := newSupervisorTestLogger()
+		s := gproc.NewSupervisor(gproc.SupervisorConfig{
+			Path:          "/bin/sh",
+			Args:          []string{"-c", "exit 0"},
+			RestartPolicy: gproc.RestartAlways,
+			MaxRestarts:   3,
+			BackoffMin:    10 * time.Millisecond,
+			Logger:        logger,
+		})
+		t.AssertNil(s.Start(gctx.New()))
+		t.AssertNE(s.Start(gctx.New()), nil)
+		s.Wait()
+		t.Assert(s.Status().State, gproc.SupervisorStateFatal)
+		t.Assert(s.Status().Restarts, 3)
+	})
+}
+
+func Test_Supervisor_Stop(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		logger, _ := newSupervisorTestLogger()
+		s := gproc.NewSupervisor(gproc.SupervisorConfig{
+			Path:          "/bin/sh",
+			Args:          []string{"-c", "exec sleep 10"},
+			RestartPolicy: gproc.RestartAlways,
+			Logger:        logger,
+		})
+		t.AssertNil(s.Start(gctx.New()))
+		status := s.Status()
+		t.Assert(status.State, gproc.SupervisorStateRunning)
+		t.AssertGT(status.Pid, 0)
+		t.Assert(s.Process().Pid(), status.Pid)
+
+		start := time.Now()
+		t.AssertNil(s.Stop(context.Background()))
+		t.AssertLT(time.Since(start), 5*time.Second)
+		t.Assert(s.Status().State, gproc.SupervisorStateStopped)
+		t.Assert(s.Status().Pid, 0)
+		// Stopping a stopped supervisor does nothing.
+		t.AssertNil(s.Stop(context.Background()))
+	})
+	// Signal escalation.
+	gtest.C(t, func(t *gtest.T) {
+		logger, _ := newSupervisorTestLogger()
+		s := gproc.NewSupervisor(gproc.SupervisorConfig{
+			Path:        "/bin/sh",
+			Args:        []string{"-c", `trap "" TERM; echo ready; while true; do sleep 0.05; done`},
+			StopTimeout: 300 * time.Millisecond,
+			Logger:      logger,
+		})
+		t.AssertNil(s.Start(gctx.New()))
+		time.Sleep(100 * time.Millisecond)
+
+		start := time.Now()
+		t.AssertNil(s.Stop(context.Background()))
+		t.AssertGE(time.Since(start), 300*time.Millisecond)
+		t.Assert(s.Status().State, gproc.SupervisorStateStopped)
+		t.Assert(s.Status().ExitCode, -1)
+	})
+}
+
+func Test_Supervisor_HealthCheck(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			logger, _ = newSupervisorTestLogger()
+			unhealthy = errors.New("unhealthy")
+		)
+		s := gproc.NewSupervisor(gproc.SupervisorConfig{
+			Path:        "/bin/sh",
+			Args:        []string{"-c", "exec sleep 10"},
+			MaxRestarts: 1,
+			BackoffMin:  10 * time.Millisecond,
+			Logger:      logger,
+			HealthCheck: func(ctx context.Context, s *gproc.Supervisor) error {
+				return unhealthy
+			},
+			HealthCheckInterval: 50 * time.Millisecond,
+			HealthCheckFailures: 2,
+		})
+		t.AssertNil(s.Start(gctx.New()))
+		s.Wait()
+		status := s.Status()
+		t.Assert(status.State, gproc.SupervisorStateFatal)
+		t.Assert(status.Restarts, 1)
+		t.Assert(status.Healthy, false)
+	})
+}
+
+func Test_Supervisor_Restart_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		logger, _ := newSupervisorTestLogger()
+		s := gproc.NewSupervisor(gproc.SupervisorConfig{
+			Path:       "/bin/sh",
+			Args:       []string{"-c", "exit 0"},
+			BackoffMin: 10 * time.Millisecond,
+			Logger:     logger,
+		})
+		// Starts again as soon as the supervising exits.
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 5; j++ {
+					_ = s.Start(gctx.New())
+					s.Wait()
+				}
+			}()
+		}
+		wg.Wait()
+		t.AssertNil(s.Stop(context.Background()))
+		t.AssertNE(s.Status().State, gproc.SupervisorStateRunning)
+	})
+}
@@ -8,19 +8,44 @@ package gsession
 
 import (
 	"context"
+	"net"
+	"strings"
 	"time"
+
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/crypto/gmd5"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
 )
 
 // Manager for sessions.
 type Manager struct {
-	ttl     time.Duration // TTL for sessions.
-	storage Storage       // Storage interface for session storage.
+	ttl         time.Duration     // TTL for sessions, which is the idle timeout refreshed on each access.
+	absoluteTTL time.Duration     // Absolute lifetime for sessions since creation, no limit if 0.
+	fingerprint FingerprintConfig // Client fingerprint binding configuration.
+	rotateKeys  *gset.StrSet      // Privilege keys that rotate session id when they are changed.
+	storage     Storage           // Storage interface for session storage.
+}
+
+// FingerprintConfig is the configuration binding sessions to client fingerprint.
+// The session is invalidated if its client fingerprint does not match the one bound in creation.
+type FingerprintConfig struct {
+	UserAgent  bool // UserAgent specifies whether binding the user agent of client.
+	IPv4Prefix int  // IPv4Prefix is the prefix bits of client IPv4 address binding, eg: 24. It is disabled if 0.
+	IPv6Prefix int  // IPv6Prefix is the prefix bits of client IPv6 address binding, eg: 64. It is disabled if 0.
+}
+
+// IsEnabled checks and returns whether the fingerprint binding is enabled.
+func (c FingerprintConfig) IsEnabled() bool {
+	return c.UserAgent || c.IPv4Prefix > 0 || c.IPv6Prefix > 0
 }
 
 // New creates and returns a new session manager.
 func New(ttl time.Duration, storage ...Storage) *Manager {
 	m := &Manager{
-		ttl: ttl,
+		ttl:        ttl,
+		rotateKeys: gset.NewStrSet(true),
 	}
 	if len(storage) > 0 && storage[0] != nil {
 		m.storage = storage[0]
@@ -65,3 +90,140 @@ func (m *Manager) SetTTL(ttl time.Duration) {
 func (m *Manager) GetTTL() time.Duration {
 	return m.ttl
 }
+
+// SetAbsoluteTTL sets the absolute lifetime for sessions since they are created.
+// Different from the TTL which is refreshed on each access, the session is invalidated
+// after absolute lifetime even it is active. It is disabled if `ttl` is 0.
+func (m *Manager) SetAbsoluteTTL(ttl time.Duration) {
+	m.absoluteTTL = ttl
+}
+
+// GetAbsoluteTTL returns the absolute lifetime of sessions.
+func (m *Manager) GetAbsoluteTTL() time.Duration {
+	return m.absoluteTTL
+}
+
+// SetFingerprint sets the client fingerprint binding configuration for sessions.
+func (m *Manager) SetFingerprint(config FingerprintConfig) {
+	m.fingerprint = config
+}
+
+// GetFingerprint returns the client fingerprint binding configuration for sessions.
+func (m *Manager) GetFingerprint() FingerprintConfig {
+	return m.fingerprint
+}
+
+// Fingerprint calculates and returns the client fingerprint using configuration of manager.
+// It returns empty string if fingerprint binding is not enabled.
+func (m *Manager) Fingerprint(userAgent string, clientIp string) string {
+	if !m.fingerprint.IsEnabled() {
+		return ""
+	}
+	var parts = make([]string, 0, 2)
+	if m.fingerprint.UserAgent {
+		parts = append(parts, userAgent)
+	}
+	if ip := net.ParseIP(clientIp); ip == nil {
+		if m.fingerprint.IPv4Prefix > 0 || m.fingerprint.IPv6Prefix > 0 {
+			parts = append(parts, clientIp)
+		}
+	} else if ipv4 := ip.To4(); ipv4 != nil {
+		if m.fingerprint.IPv4Prefix > 0 {
+			parts = append(parts, ipv4.Mask(net.CIDRMask(min(m.fingerprint.IPv4Prefix, 32), 32)).String())
+		}
+	} else if m.fingerprint.IPv6Prefix > 0 {
+		parts = append(parts, ip.Mask(net.CIDRMask(min(m.fingerprint.IPv6Prefix, 128), 128)).String())
+	}
+	return gmd5.MustEncryptString(strings.Join(parts, "\n"))
+}
+
+// SetRotateKeys sets the privilege keys of sessions, eg: user id, role.
+// The session id is automatically regenerated if any of these keys is changed,
+// which prevents session fixation attacks.
+func (m *Manager) SetRotateKeys(keys ...string) {
+	m.rotateKeys.Clear()
+	m.rotateKeys.Add(keys...)
+}
+
+// GetRotateKeys returns the privilege keys of sessions.
+func (m *Manager) GetRotateKeys() []string {
+	return m.rotateKeys.Slice()
+}
+
+// UserSessions returns the alive session ids bound to user `userId` by Session.SetUser.
+// It returns error if the storage does not implement StorageUserIndex.
+func (m *Manager) UserSessions(ctx context.Context, userId string) ([]string, error) {
+	index, err := m.getUserIndex()
+	if err != nil {
+		return nil, err
+	}
+	sessionIds, err := index.GetUserSessions(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	var aliveIds = make([]string, 0, len(sessionIds))
+	for _, sessionId := range sessionIds {
+		if m.isSessionAlive(ctx, sessionId) {
+			aliveIds = append(aliveIds, sessionId)
+			continue
+		}
+		// Expired session is removed from index.
+		if err = index.RemoveUserSession(ctx, userId, sessionId); err != nil {
+			intlog.Errorf(ctx, `%+v`, err)
+		}
+	}
+	return aliveIds, nil
+}
+
+// RevokeUserSessions deletes all sessions bound to user `userId` by Session.SetUser.
+// It returns error if the storage does not implement StorageUserIndex.
+func (m *Manager) RevokeUserSessions(ctx context.Context, userId string) error {
+	index, err := m.getUserIndex()
+	if err != nil {
+		return err
+	}
+	sessionIds, err := index.GetUserSessions(ctx, userId)
+	if err != nil {
+		return err
+	}
+	for _, sessionId := range sessionIds {
+		if err = m.storage.RemoveAll(ctx, sessionId); err != nil && !gerror.Is(err, ErrorDisabled) {
+			return err
+		}
+		if err = index.RemoveUserSession(ctx, userId, sessionId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isSecurityEnabled checks whether any security feature requiring session metadata is enabled.
+func (m *Manager) isSecurityEnabled() bool {
+	return m.absoluteTTL > 0 || m.fingerprint.IsEnabled()
+}
+
+// getUserIndex returns the user index of storage.
+func (m *Manager) getUserIndex() (StorageUserIndex, error) {
+	if index, ok := m.storage.(StorageUserIndex); ok {
+		return index, nil
+	}
+	return nil, gerror.NewCodef(
+		gcode.CodeNotSupported,
+		`session storage "%T" does not support user sessions index`,
+		m.storage,
+	)
+}
+
+// isSessionAlive checks whether session `sessionId` exists in storage.
+func (m *Manager) isSessionAlive(ctx context.Context, sessionId string) bool {
+	data, err := m.storage.GetSession(ctx, sessionId, m.ttl)
+	if err != nil || data == nil {
+		return false
+	}
+	if data.Size() > 0 {
+		return true
+	}
+	// Some storage does not store session data in memory, eg: StorageRedisHashTable.
+	size, _ := m.storage.GetSize(ctx, sessionId)
+	return size > 0
+}
@@ -28,6 +28,12 @@ type Session struct {
 	start   bool            // Used to mark session is started.
 	manager *Manager        // Parent session Manager.
 
+	// Security features.
+	created     int64          // Created timestamp in milliseconds, which is used for absolute TTL.
+	fingerprint string         // Client fingerprint of current request.
+	generated   bool           // Whether the session id is generated in current request.
+	metadata    map[string]any // Pending metadata of new session, which is written along with the first writing.
+
 	// idFunc is a callback function used for creating custom session id.
 	// This is called if session id is empty ever when session starts.
 	idFunc func(ttl time.Duration) (id string)
@@ -50,6 +56,12 @@ func (s *Session) init() error {
 				return err
 			}
 		}
+		// Security checks for retrieved session.
+		if s.manager.isSecurityEnabled() {
+			if err = s.checkSecurity(); err != nil {
+				return err
+			}
+		}
 	}
 	// Session id creation.
 	if s.id == "" {
@@ -69,10 +81,14 @@ func (s *Session) init() error {
 				s.id = NewSessionId()
 			}
 		}
+		s.generated = true
 	}
 	if s.data == nil {
 		s.data = gmap.NewStrAnyMap(true)
 	}
+	if s.created == 0 && s.manager.isSecurityEnabled() {
+		s.initMetadata()
+	}
 	s.start = true
 	return nil
 }
@@ -88,12 +104,12 @@ func (s *Session) Close() error {
 	if s.start && s.id != "" {
 		size := s.data.Size()
 		if s.dirty {
-			err := s.manager.storage.SetSession(s.ctx, s.id, s.data, s.manager.ttl)
+			err := s.manager.storage.SetSession(s.ctx, s.id, s.data, s.getTTL())
 			if err != nil && !gerror.Is(err, ErrorDisabled) {
 				return err
 			}
 		} else if size > 0 {
-			err := s.manager.storage.UpdateTTL(s.ctx, s.id, s.manager.ttl)
+			err := s.manager.storage.UpdateTTL(s.ctx, s.id, s.getTTL())
 			if err != nil && !gerror.Is(err, ErrorDisabled) {
 				return err
 			}
@@ -103,31 +119,57 @@ func (s *Session) Close() error {
 }
 
 // Set sets key-value pair to this session.
+// It regenerates the session id if `key` is one of the rotate keys of manager.
 func (s *Session) Set(key string, value any) (err error) {
 	if err = s.init(); err != nil {
 		return err
 	}
-	if err = s.manager.storage.Set(s.ctx, s.id, key, value, s.manager.ttl); err != nil {
-		if !gerror.Is(err, ErrorDisabled) {
-			return err
-		}
-		s.data.Set(key, value)
+	if err = s.checkRotate(key); err != nil {
+		return err
 	}
-	s.dirty = true
-	return nil
+	return s.doSetMap(map[string]any{key: value})
 }
 
 // SetMap batch sets the session using map.
+// It regenerates the session id if any key of `data` is one of the rotate keys of manager.
 func (s *Session) SetMap(data map[string]any) (err error) {
 	if err = s.init(); err != nil {
 		return err
 	}
-	if err = s.manager.storage.SetMap(s.ctx, s.id, data, s.manager.ttl); err != nil {
+	for key := range data {
+		if err = s.checkRotate(key); err != nil {
+			return err
+		}
+	}
+	return s.doSetMap(data)
+}
+
+// doSetMap writes `data` to session along with the pending metadata.
+func (s *Session) doSetMap(data map[string]any) (err error) {
+	if len(s.metadata) > 0 {
+		var merged = make(map[string]any, len(s.metadata)+len(data))
+		for k, v := range s.metadata {
+			merged[k] = v
+		}
+		for k, v := range data {
+			merged[k] = v
+		}
+		data = merged
+	}
+	if len(data) == 1 {
+		for key, value := range data {
+			err = s.manager.storage.Set(s.ctx, s.id, key, value, s.getTTL())
+		}
+	} else {
+		err = s.manager.storage.SetMap(s.ctx, s.id, data, s.getTTL())
+	}
+	if err != nil {
 		if !gerror.Is(err, ErrorDisabled) {
 			return err
 		}
 		s.data.Sets(data)
 	}
+	s.metadata = nil
 	s.dirty = true
 	return nil
 }
@@ -140,6 +182,11 @@ func (s *Session) Remove(keys ...string) (err error) {
 	if err = s.init(); err != nil {
 		return err
 	}
+	for _, key := range keys {
+		if err = s.checkRotate(key); err != nil {
+			return err
+		}
+	}
 	for _, key := range keys {
 		if err = s.manager.storage.Remove(s.ctx, s.id, key); err != nil {
 			if !gerror.Is(err, ErrorDisabled) {
@@ -160,6 +207,9 @@ func (s *Session) RemoveAll() (err error) {
 	if err = s.init(); err != nil {
 		return err
 	}
+	if err = s.unbindUser(s.id); err != nil {
+		return err
+	}
 	if err = s.manager.storage.RemoveAll(s.ctx, s.id); err != nil {
 		if !gerror.Is(err, ErrorDisabled) {
 			return err
@@ -169,6 +219,10 @@ func (s *Session) RemoveAll() (err error) {
 	if s.data != nil {
 		s.data.Clear()
 	}
+	// The metadata is kept for the following writing.
+	if s.manager.isSecurityEnabled() {
+		s.pendMetadata()
+	}
 	s.dirty = true
 	return nil
 }
@@ -215,10 +269,14 @@ func (s *Session) Data() (sessionData map[string]any, err error) {
 	if err != nil && !gerror.Is(err, ErrorDisabled) {
 		intlog.Errorf(s.ctx, `%+v`, err)
 	}
-	if sessionData != nil {
-		return sessionData, nil
+	if sessionData == nil {
+		sessionData = s.data.Map()
 	}
-	return s.data.Map(), nil
+	// Reserved metadata keys are invisible for user.
+	for _, key := range reservedKeys {
+		delete(sessionData, key)
+	}
+	return sessionData, nil
 }
 
 // Size returns the size of the session.
@@ -229,6 +287,11 @@ func (s *Session) Size() (size int, err error) {
 	if err = s.init(); err != nil {
 		return 0, err
 	}
+	// Reserved metadata keys are not counted.
+	if s.hasMetadata() {
+		data, err := s.Data()
+		return len(data), err
+	}
 	size, err = s.manager.storage.GetSize(s.ctx, s.id)
 	if err != nil && !gerror.Is(err, ErrorDisabled) {
 		intlog.Errorf(s.ctx, `%+v`, err)
@@ -367,6 +430,13 @@ func (s *Session) RegenerateId(deleteOld bool) (newId string, err error) {
 		return "", err
 	}
 
+	// The pending metadata should be copied along with the data.
+	if len(s.metadata) > 0 {
+		if err = s.doSetMap(nil); err != nil {
+			return "", err
+		}
+	}
+
 	// Generate new session id
 	if s.idFunc != nil {
 		newId = s.idFunc(s.manager.ttl)
@@ -382,14 +452,27 @@ func (s *Session) RegenerateId(deleteOld bool) (newId string, err error) {
 
 	// If using storage, need to copy data to new id
 	if s.manager.storage != nil {
-		if err = s.manager.storage.SetSession(s.ctx, newId, s.data, s.manager.ttl); err != nil {
-			if !gerror.Is(err, ErrorDisabled) {
-				return "", err
-			}
+		var oldId = s.id
+		// Some storage does not store session data in memory, eg: StorageRedisHashTable,
+		// so it copies the data from storage.
+		data, err := s.manager.storage.Data(s.ctx, oldId)
+		if err != nil && !gerror.Is(err, ErrorDisabled) {
+			return "", err
+		}
+		if len(data) > 0 {
+			err = s.manager.storage.SetMap(s.ctx, newId, data, s.getTTL())
+		} else {
+			err = s.manager.storage.SetSession(s.ctx, newId, s.data, s.getTTL())
+		}
+		if err != nil && !gerror.Is(err, ErrorDisabled) {
+			return "", err
+		}
+		if err = s.moveUser(oldId, newId, deleteOld); err != nil {
+			return "", err
 		}
 		// Delete old session data if requested
 		if deleteOld {
-			if err = s.manager.storage.RemoveAll(s.ctx, s.id); err != nil {
+			if err = s.manager.storage.RemoveAll(s.ctx, oldId); err != nil {
 				if !gerror.Is(err, ErrorDisabled) {
 					return "", err
 				}
@@ -399,6 +482,7 @@ func (s *Session) RegenerateId(deleteOld bool) (newId string, err error) {
 
 	// Update session id
 	s.id = newId
+	s.generated = true
 	s.dirty = true
 	return newId, nil
 }
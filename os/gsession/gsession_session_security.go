@@ -0,0 +1,231 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+const (
+	sessionKeyCreated     = "__gf_session_created"     // Created timestamp in milliseconds of session.
+	sessionKeyFingerprint = "__gf_session_fingerprint" // Client fingerprint bound to session.
+	sessionKeyUser        = "__gf_session_user"        // User id bound to session.
+)
+
+// reservedKeys are the metadata keys of session, which are invisible in Data.
+var reservedKeys = []string{sessionKeyCreated, sessionKeyFingerprint, sessionKeyUser}
+
+// SetFingerprint sets the client fingerprint before session starts, which is used for
+// checking the session if fingerprint binding is enabled in manager.
+// It returns error if it is called after session starts.
+func (s *Session) SetFingerprint(userAgent string, clientIp string) error {
+	if s.start {
+		return gerror.NewCode(gcode.CodeInvalidOperation, "session already started")
+	}
+	s.fingerprint = s.manager.Fingerprint(userAgent, clientIp)
+	return nil
+}
+
+// SetUser binds current session to user `userId`, which is commonly called after user logins.
+// It regenerates the session id for privilege change, and adds the session to the user index
+// of storage if the storage implements StorageUserIndex.
+// It unbinds the user from session if `userId` is empty, which is commonly called after user logouts.
+func (s *Session) SetUser(userId string) (err error) {
+	if err = s.init(); err != nil {
+		return err
+	}
+	if !s.generated {
+		if _, err = s.RegenerateId(true); err != nil {
+			return err
+		}
+	}
+	if err = s.unbindUser(s.id); err != nil {
+		return err
+	}
+	if userId == "" {
+		if err = s.manager.storage.Remove(s.ctx, s.id, sessionKeyUser); err != nil {
+			if !gerror.Is(err, ErrorDisabled) {
+				return err
+			}
+			s.data.Remove(sessionKeyUser)
+		}
+		s.dirty = true
+		return nil
+	}
+	if err = s.doSetMap(map[string]any{sessionKeyUser: userId}); err != nil {
+		return err
+	}
+	if index, ok := s.manager.storage.(StorageUserIndex); ok {
+		return index.AddUserSession(s.ctx, userId, s.id, s.getTTL())
+	}
+	return nil
+}
+
+// GetUser returns the user id bound to current session by SetUser.
+// It returns empty string if no user bound.
+func (s *Session) GetUser() (userId string, err error) {
+	if s.id == "" {
+		return "", nil
+	}
+	if err = s.init(); err != nil {
+		return "", err
+	}
+	return gconv.String(s.getMetadata(sessionKeyUser)), nil
+}
+
+// checkSecurity checks the retrieved session with absolute TTL and client fingerprint.
+// The session is invalidated if it fails checking, and a new session id is created later.
+func (s *Session) checkSecurity() (err error) {
+	if !s.isExisting() {
+		return nil
+	}
+	var (
+		reason  string
+		created = gconv.Int64(s.getMetadata(sessionKeyCreated))
+	)
+	if s.manager.absoluteTTL > 0 && created > 0 &&
+		created+s.manager.absoluteTTL.Milliseconds() < gtime.TimestampMilli() {
+		reason = "absolute lifetime exceeded"
+	} else if s.fingerprint != "" && gconv.String(s.getMetadata(sessionKeyFingerprint)) != s.fingerprint {
+		reason = "client fingerprint mismatched"
+	}
+	if reason == "" {
+		s.created = created
+		return nil
+	}
+	intlog.Printf(s.ctx, `session "%s" is invalidated: %s`, s.id, reason)
+	if err = s.unbindUser(s.id); err != nil {
+		return err
+	}
+	if err = s.manager.storage.RemoveAll(s.ctx, s.id); err != nil && !gerror.Is(err, ErrorDisabled) {
+		return err
+	}
+	s.id = ""
+	s.data = nil
+	s.dirty = true
+	return nil
+}
+
+// checkRotate regenerates the session id if `key` is one of the rotate keys of manager,
+// and the session id is not generated in current request.
+func (s *Session) checkRotate(key string) (err error) {
+	if s.generated || !s.manager.rotateKeys.Contains(key) {
+		return nil
+	}
+	_, err = s.RegenerateId(true)
+	return err
+}
+
+// isExisting checks whether the retrieved session exists in storage.
+func (s *Session) isExisting() bool {
+	if s.data == nil {
+		return false
+	}
+	if s.data.Size() > 0 {
+		return true
+	}
+	size, _ := s.manager.storage.GetSize(s.ctx, s.id)
+	return size > 0
+}
+
+// initMetadata initializes the metadata for new session.
+func (s *Session) initMetadata() {
+	s.created = gtime.TimestampMilli()
+	s.pendMetadata()
+}
+
+// pendMetadata makes the metadata pending, which is written along with the first writing of session.
+// It does not write the metadata immediately, which avoids storing empty sessions for anonymous clients.
+func (s *Session) pendMetadata() {
+	s.metadata = map[string]any{
+		sessionKeyCreated: s.created,
+	}
+	if s.fingerprint != "" {
+		s.metadata[sessionKeyFingerprint] = s.fingerprint
+	}
+}
+
+// hasMetadata checks whether current session might contain metadata,
+// which is true if any security feature is enabled or the session is bound to a user.
+func (s *Session) hasMetadata() bool {
+	if s.manager.isSecurityEnabled() {
+		return true
+	}
+	return s.getMetadata(sessionKeyUser) != nil
+}
+
+// getMetadata retrieves the metadata value of `key` from storage or memory.
+func (s *Session) getMetadata(key string) any {
+	v, err := s.manager.storage.Get(s.ctx, s.id, key)
+	if err != nil && !gerror.Is(err, ErrorDisabled) {
+		intlog.Errorf(s.ctx, `%+v`, err)
+	}
+	if v != nil {
+		return v
+	}
+	if s.data != nil {
+		return s.data.Get(key)
+	}
+	return nil
+}
+
+// unbindUser removes session `sessionId` from the index of its bound user.
+func (s *Session) unbindUser(sessionId string) error {
+	index, ok := s.manager.storage.(StorageUserIndex)
+	if !ok {
+		return nil
+	}
+	userId := gconv.String(s.getMetadata(sessionKeyUser))
+	if userId == "" {
+		return nil
+	}
+	return index.RemoveUserSession(s.ctx, userId, sessionId)
+}
+
+// moveUser moves the session of bound user in index from `oldId` to `newId`.
+func (s *Session) moveUser(oldId, newId string, deleteOld bool) (err error) {
+	index, ok := s.manager.storage.(StorageUserIndex)
+	if !ok {
+		return nil
+	}
+	userId := gconv.String(s.getMetadata(sessionKeyUser))
+	if userId == "" {
+		return nil
+	}
+	if err = index.AddUserSession(s.ctx, userId, newId, s.getTTL()); err != nil {
+		return err
+	}
+	if deleteOld {
+		return index.RemoveUserSession(s.ctx, userId, oldId)
+	}
+	return nil
+}
+
+// getTTL returns the TTL for current session, which is the less one of the idle TTL
+// and the remaining absolute lifetime.
+func (s *Session) getTTL() time.Duration {
+	var ttl = s.manager.ttl
+	if s.manager.absoluteTTL > 0 && s.created > 0 {
+		remaining := time.Duration(
+			s.created+s.manager.absoluteTTL.Milliseconds()-gtime.TimestampMilli(),
+		) * time.Millisecond
+		if remaining < ttl {
+			ttl = remaining
+		}
+		// Some storages use TTL in seconds.
+		if ttl < time.Second {
+			ttl = time.Second
+		}
+	}
+	return ttl
+}
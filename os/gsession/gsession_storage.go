@@ -62,3 +62,18 @@ type Storage interface {
 	// This function is called ever after session, which is not dirty, is closed.
 	UpdateTTL(ctx context.Context, sessionId string, ttl time.Duration) error
 }
+
+// StorageUserIndex is the optional interface for Storage, which maintains the index of sessions for users.
+// It is used for listing and revoking all sessions of a user.
+type StorageUserIndex interface {
+	// AddUserSession adds session `sessionId` to the index of user `userId`.
+	// The parameter `ttl` specifies the TTL for the session id.
+	AddUserSession(ctx context.Context, userId string, sessionId string, ttl time.Duration) error
+
+	// RemoveUserSession removes session `sessionId` from the index of user `userId`.
+	RemoveUserSession(ctx context.Context, userId string, sessionId string) error
+
+	// GetUserSessions returns the session ids in the index of user `userId`,
+	// which might contain expired session ids.
+	GetUserSessions(ctx context.Context, userId string) ([]string, error)
+}
@@ -0,0 +1,330 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/os/gtimer"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// StorageDB implements the Session Storage interface with database table.
+// The session data is stored as json in a row along with its expiration timestamp and bound user id,
+// and the expired rows are deleted timely.
+// It also implements StorageUserIndex using the user id column.
+type StorageDB struct {
+	StorageBase
+	db            gdb.DB          // Database for session storage.
+	table         string          // Table name storing sessions.
+	autoCreate    bool            // Whether creating the table automatically if it does not exist.
+	initialized   bool            // Whether the table is initialized.
+	mu            sync.Mutex      // Mutex for table initialization.
+	updatingIdMap *gmap.StrIntMap // Updating TTL set for session id, the value is TTL in milliseconds.
+}
+
+const (
+	// DefaultStorageDBTable is the default table name for StorageDB.
+	DefaultStorageDBTable                = "gf_sessions"
+	DefaultStorageDBAutoCreate           = true
+	DefaultStorageDBUpdateTTLInterval    = 10 * time.Second
+	DefaultStorageDBClearExpiredInterval = time.Hour
+
+	storageDBFieldId       = "session_id"
+	storageDBFieldData     = "session_data"
+	storageDBFieldExpireAt = "expire_at"
+	storageDBFieldUserId   = "user_id"
+
+	storageDBCreateTableSqlDefault = "CREATE TABLE %s (%s VARCHAR(64) NOT NULL PRIMARY KEY, %s TEXT NOT NULL, %s BIGINT NOT NULL, %s VARCHAR(64) NULL)"
+	storageDBCreateTableSqlMysql   = "CREATE TABLE %s (%s VARCHAR(64) NOT NULL PRIMARY KEY, %s LONGTEXT NOT NULL, %s BIGINT NOT NULL, %s VARCHAR(64) NULL)"
+	storageDBCreateIndexSql        = "CREATE INDEX %s ON %s (%s)"
+)
+
+// NewStorageDB creates and returns a database storage object for session.
+// The optional parameter `table` specifies the table name, which is DefaultStorageDBTable in default.
+//
+// The table has columns `session_id`, `session_data`, `expire_at` and `user_id`, which is created automatically
+// if it does not exist. The auto creating can be disabled using SetAutoCreate if the table is
+// maintained by database migration.
+func NewStorageDB(db gdb.DB, table ...string) *StorageDB {
+	if db == nil {
+		panic("database instance for storage cannot be empty")
+	}
+	s := &StorageDB{
+		db:            db,
+		table:         DefaultStorageDBTable,
+		autoCreate:    DefaultStorageDBAutoCreate,
+		updatingIdMap: gmap.NewStrIntMap(true),
+	}
+	if len(table) > 0 && table[0] != "" {
+		s.table = table[0]
+	}
+	gtimer.AddSingleton(context.Background(), DefaultStorageDBUpdateTTLInterval, s.timelyUpdateSessionTTL)
+	gtimer.AddSingleton(context.Background(), DefaultStorageDBClearExpiredInterval, s.timelyClearExpiredSessions)
+	return s
+}
+
+// SetAutoCreate enables/disables creating the table automatically if it does not exist.
+func (s *StorageDB) SetAutoCreate(enabled bool) {
+	s.autoCreate = enabled
+}
+
+// timelyUpdateSessionTTL batch updates the TTL for sessions timely.
+func (s *StorageDB) timelyUpdateSessionTTL(ctx context.Context) {
+	var (
+		err       error
+		sessionId string
+		ttlMilli  int
+	)
+	for {
+		if sessionId, ttlMilli = s.updatingIdMap.Pop(); sessionId == "" {
+			break
+		}
+		if err = s.doUpdateExpireForSession(context.TODO(), sessionId, ttlMilli); err != nil {
+			intlog.Errorf(context.TODO(), `%+v`, err)
+		}
+	}
+}
+
+// timelyClearExpiredSessions deletes all expired sessions timely.
+func (s *StorageDB) timelyClearExpiredSessions(ctx context.Context) {
+	if err := s.ClearExpired(ctx); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+}
+
+// ClearExpired deletes all expired sessions from table, along with their user index.
+// It is called timely in DefaultStorageDBClearExpiredInterval.
+func (s *StorageDB) ClearExpired(ctx context.Context) error {
+	if err := s.ensureTable(ctx); err != nil {
+		return err
+	}
+	_, err := s.model(ctx).WhereLT(storageDBFieldExpireAt, gtime.TimestampMilli()).Delete()
+	return err
+}
+
+// RemoveAll deletes session from storage.
+func (s *StorageDB) RemoveAll(ctx context.Context, sessionId string) error {
+	if err := s.ensureTable(ctx); err != nil {
+		return err
+	}
+	s.updatingIdMap.Remove(sessionId)
+	_, err := s.model(ctx).Where(storageDBFieldId, sessionId).Delete()
+	return err
+}
+
+// GetSession returns the session data as *gmap.StrAnyMap for given session id from storage.
+//
+// The parameter `ttl` specifies the TTL for this session, and it returns nil if the TTL is exceeded.
+// The parameter `data` is the current old session data stored in memory,
+// and for some storage it might be nil if memory storage is disabled.
+//
+// This function is called ever when session starts.
+func (s *StorageDB) GetSession(ctx context.Context, sessionId string, ttl time.Duration) (*gmap.StrAnyMap, error) {
+	intlog.Printf(ctx, "StorageDB.GetSession: %s, %v", sessionId, ttl)
+	if err := s.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	v, err := s.model(ctx).
+		Fields(storageDBFieldData).
+		Where(storageDBFieldId, sessionId).
+		WhereGTE(storageDBFieldExpireAt, gtime.TimestampMilli()).
+		Value()
+	if err != nil {
+		return nil, err
+	}
+	content := v.Bytes()
+	if len(content) == 0 {
+		return nil, nil
+	}
+	var m map[string]any
+	if err = json.UnmarshalUseNumber(content, &m); err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, nil
+	}
+	return gmap.NewStrAnyMapFrom(m, true), nil
+}
+
+// SetSession updates the data map for specified session id.
+// This function is called ever after session, which is changed dirty, is closed.
+// This copy all session data map from memory to storage.
+func (s *StorageDB) SetSession(ctx context.Context, sessionId string, sessionData *gmap.StrAnyMap, ttl time.Duration) error {
+	intlog.Printf(ctx, "StorageDB.SetSession: %s, %v, %v", sessionId, sessionData, ttl)
+	if err := s.ensureTable(ctx); err != nil {
+		return err
+	}
+	content, err := json.Marshal(sessionData)
+	if err != nil {
+		return err
+	}
+	s.updatingIdMap.Remove(sessionId)
+	_, err = s.model(ctx).
+		Data(gdb.Map{
+			storageDBFieldId:       sessionId,
+			storageDBFieldData:     string(content),
+			storageDBFieldExpireAt: gtime.TimestampMilli() + ttl.Milliseconds(),
+		}).
+		OnConflict(storageDBFieldId).
+		Save()
+	return err
+}
+
+// UpdateTTL updates the TTL for specified session id.
+// This function is called ever after session, which is not dirty, is closed.
+// It just adds the session id to the async handling queue.
+func (s *StorageDB) UpdateTTL(ctx context.Context, sessionId string, ttl time.Duration) error {
+	intlog.Printf(ctx, "StorageDB.UpdateTTL: %s, %v", sessionId, ttl)
+	if ttl >= DefaultStorageDBUpdateTTLInterval {
+		s.updatingIdMap.Set(sessionId, int(ttl.Milliseconds()))
+	}
+	return nil
+}
+
+// doUpdateExpireForSession updates the TTL for session id.
+func (s *StorageDB) doUpdateExpireForSession(ctx context.Context, sessionId string, ttlMilli int) error {
+	intlog.Printf(ctx, "StorageDB.doUpdateTTL: %s, %d", sessionId, ttlMilli)
+	if err := s.ensureTable(ctx); err != nil {
+		return err
+	}
+	_, err := s.model(ctx).
+		Data(storageDBFieldExpireAt, gtime.TimestampMilli()+int64(ttlMilli)).
+		Where(storageDBFieldId, sessionId).
+		Update()
+	return err
+}
+
+// AddUserSession adds session `sessionId` to the index of user `userId` by setting the user id column.
+// As the session might not be stored yet, it creates an empty session row expiring in `ttl`,
+// which is filled with session data later.
+func (s *StorageDB) AddUserSession(ctx context.Context, userId string, sessionId string, ttl time.Duration) error {
+	if err := s.ensureTable(ctx); err != nil {
+		return err
+	}
+	_, err := s.model(ctx).
+		Data(gdb.Map{
+			storageDBFieldId:       sessionId,
+			storageDBFieldData:     "{}",
+			storageDBFieldExpireAt: gtime.TimestampMilli() + ttl.Milliseconds(),
+		}).
+		InsertIgnore()
+	if err != nil {
+		return err
+	}
+	_, err = s.model(ctx).
+		Data(storageDBFieldUserId, userId).
+		Where(storageDBFieldId, sessionId).
+		Update()
+	return err
+}
+
+// RemoveUserSession removes session `sessionId` from the index of user `userId`.
+func (s *StorageDB) RemoveUserSession(ctx context.Context, userId string, sessionId string) error {
+	if err := s.ensureTable(ctx); err != nil {
+		return err
+	}
+	_, err := s.model(ctx).
+		Data(storageDBFieldUserId, nil).
+		Where(storageDBFieldId, sessionId).
+		Where(storageDBFieldUserId, userId).
+		Update()
+	return err
+}
+
+// GetUserSessions returns the unexpired session ids in the index of user `userId`.
+func (s *StorageDB) GetUserSessions(ctx context.Context, userId string) ([]string, error) {
+	if err := s.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	array, err := s.model(ctx).
+		Fields(storageDBFieldId).
+		Where(storageDBFieldUserId, userId).
+		WhereGTE(storageDBFieldExpireAt, gtime.TimestampMilli()).
+		Array()
+	if err != nil {
+		return nil, err
+	}
+	return gconv.Strings(array), nil
+}
+
+// model creates and returns the Model for session table.
+func (s *StorageDB) model(ctx context.Context) *gdb.Model {
+	return s.db.Model(s.table).Ctx(ctx)
+}
+
+// ensureTable creates the session table if it does not exist and auto creating is enabled.
+func (s *StorageDB) ensureTable(ctx context.Context) error {
+	if !s.autoCreate {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.initialized {
+		return nil
+	}
+	var (
+		core      = s.db.GetCore()
+		tableName = s.db.GetPrefix() + s.table
+	)
+	tables, err := s.db.Tables(ctx)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if table == tableName {
+			s.initialized = true
+			return nil
+		}
+	}
+	var sqlFormat = storageDBCreateTableSqlDefault
+	switch s.db.GetConfig().Type {
+	case "mysql", "mariadb", "tidb":
+		sqlFormat = storageDBCreateTableSqlMysql
+	}
+	_, err = s.db.Exec(ctx, fmt.Sprintf(
+		sqlFormat,
+		core.QuotePrefixTableName(s.table),
+		core.QuoteWord(storageDBFieldId),
+		core.QuoteWord(storageDBFieldData),
+		core.QuoteWord(storageDBFieldExpireAt),
+		core.QuoteWord(storageDBFieldUserId),
+	))
+	if err != nil {
+		return err
+	}
+	// Index for clearing expired sessions.
+	_, err = s.db.Exec(ctx, fmt.Sprintf(
+		storageDBCreateIndexSql,
+		core.QuoteWord(tableName+"_"+storageDBFieldExpireAt),
+		core.QuotePrefixTableName(s.table),
+		core.QuoteWord(storageDBFieldExpireAt),
+	))
+	if err != nil {
+		return err
+	}
+	// Index for user sessions.
+	_, err = s.db.Exec(ctx, fmt.Sprintf(
+		storageDBCreateIndexSql,
+		core.QuoteWord(tableName+"_"+storageDBFieldUserId),
+		core.QuotePrefixTableName(s.table),
+		core.QuoteWord(storageDBFieldUserId),
+	))
+	if err != nil {
+		return err
+	}
+	s.initialized = true
+	return nil
+}
@@ -10,11 +10,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gogf/gf/v2/container/gmap"
 	"github.com/gogf/gf/v2/container/gset"
 	"github.com/gogf/gf/v2/crypto/gaes"
+	"github.com/gogf/gf/v2/crypto/gmd5"
 	"github.com/gogf/gf/v2/encoding/gbinary"
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -33,6 +36,7 @@ type StorageFile struct {
 	cryptoKey     []byte        // Used when enable crypto feature.
 	cryptoEnabled bool          // Used when enable crypto feature.
 	updatingIdSet *gset.StrSet  // To be batched updated session id set.
+	usersMu       sync.Mutex    // Mutex for user index files.
 }
 
 const (
@@ -126,6 +130,11 @@ func (s *StorageFile) sessionFilePath(sessionId string) string {
 	return gfile.Join(s.path, sessionId) + ".session"
 }
 
+// userIndexFilePath returns the index file path for given user id.
+func (s *StorageFile) userIndexFilePath(userId string) string {
+	return gfile.Join(s.path, "users", gmd5.MustEncryptString(userId)) + ".user"
+}
+
 // RemoveAll deletes all key-value pairs from storage.
 func (s *StorageFile) RemoveAll(ctx context.Context, sessionId string) error {
 	return gfile.RemoveAll(s.sessionFilePath(sessionId))
@@ -266,3 +275,64 @@ func (s *StorageFile) checkAndClearSessionFile(ctx context.Context, path string)
 	}
 	return nil
 }
+
+// AddUserSession adds session `sessionId` to the index of user `userId`.
+// The index is stored in file, which contains session ids line by line.
+func (s *StorageFile) AddUserSession(ctx context.Context, userId string, sessionId string, ttl time.Duration) error {
+	s.usersMu.Lock()
+	defer s.usersMu.Unlock()
+	var sessionIds = s.readUserIndex(userId)
+	for _, id := range sessionIds {
+		if id == sessionId {
+			return nil
+		}
+	}
+	return s.writeUserIndex(userId, append(sessionIds, sessionId))
+}
+
+// RemoveUserSession removes session `sessionId` from the index of user `userId`.
+func (s *StorageFile) RemoveUserSession(ctx context.Context, userId string, sessionId string) error {
+	s.usersMu.Lock()
+	defer s.usersMu.Unlock()
+	var (
+		sessionIds = s.readUserIndex(userId)
+		newIds     = make([]string, 0, len(sessionIds))
+	)
+	for _, id := range sessionIds {
+		if id != sessionId {
+			newIds = append(newIds, id)
+		}
+	}
+	if len(newIds) == len(sessionIds) {
+		return nil
+	}
+	return s.writeUserIndex(userId, newIds)
+}
+
+// GetUserSessions returns the session ids in the index of user `userId`.
+func (s *StorageFile) GetUserSessions(ctx context.Context, userId string) ([]string, error) {
+	s.usersMu.Lock()
+	defer s.usersMu.Unlock()
+	return s.readUserIndex(userId), nil
+}
+
+// readUserIndex reads and returns the session ids from index file of user `userId`.
+func (s *StorageFile) readUserIndex(userId string) []string {
+	var sessionIds = make([]string, 0)
+	for _, line := range strings.Split(gfile.GetContents(s.userIndexFilePath(userId)), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			sessionIds = append(sessionIds, line)
+		}
+	}
+	return sessionIds
+}
+
+// writeUserIndex writes the session ids to index file of user `userId`.
+// It deletes the index file if `sessionIds` is empty.
+func (s *StorageFile) writeUserIndex(userId string, sessionIds []string) error {
+	var path = s.userIndexFilePath(userId)
+	if len(sessionIds) == 0 {
+		return gfile.RemoveAll(path)
+	}
+	return gfile.PutContents(path, strings.Join(sessionIds, "\n"))
+}
@@ -8,11 +8,15 @@ package gsession
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gset"
 	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/internal/intlog"
 	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/util/gconv"
 )
 
 // StorageMemory implements the Session Storage interface with memory.
@@ -24,12 +28,19 @@ type StorageMemory struct {
 	//
 	// Its value is type of `*gmap.StrAnyMap`.
 	cache *gcache.Cache
+	// users is the session index for users, which expires along with the latest session of the user.
+	//
+	// Its key is user id and its value is type of `*gset.StrSet`.
+	users *gcache.Cache
+	// usersMu protects the session index from concurrent adding and removing.
+	usersMu sync.Mutex
 }
 
 // NewStorageMemory creates and returns a file storage object for session.
 func NewStorageMemory() *StorageMemory {
 	return &StorageMemory{
 		cache: gcache.New(),
+		users: gcache.New(),
 	}
 }
 
@@ -66,13 +77,89 @@ func (s *StorageMemory) GetSession(ctx context.Context, sessionId string, ttl ti
 // This function is called ever after session, which is changed dirty, is closed.
 // This copy all session data map from memory to storage.
 func (s *StorageMemory) SetSession(ctx context.Context, sessionId string, sessionData *gmap.StrAnyMap, ttl time.Duration) error {
-	return s.cache.Set(ctx, sessionId, sessionData, ttl)
+	if err := s.cache.Set(ctx, sessionId, sessionData, ttl); err != nil {
+		return err
+	}
+	s.updateUserTTL(ctx, gconv.String(sessionData.Get(sessionKeyUser)), ttl)
+	return nil
 }
 
 // UpdateTTL updates the TTL for specified session id.
 // This function is called ever after session, which is not dirty, is closed.
 // It just adds the session id to the async handling queue.
 func (s *StorageMemory) UpdateTTL(ctx context.Context, sessionId string, ttl time.Duration) error {
-	_, err := s.cache.UpdateExpire(ctx, sessionId, ttl)
+	if _, err := s.cache.UpdateExpire(ctx, sessionId, ttl); err != nil {
+		return err
+	}
+	v, err := s.cache.Get(ctx, sessionId)
+	if err != nil {
+		return err
+	}
+	if v != nil {
+		s.updateUserTTL(ctx, gconv.String(v.Val().(*gmap.StrAnyMap).Get(sessionKeyUser)), ttl)
+	}
+	return nil
+}
+
+// AddUserSession adds session `sessionId` to the index of user `userId`.
+// The TTL of the index is extended to `ttl`, so that the index expires along with the latest session.
+func (s *StorageMemory) AddUserSession(ctx context.Context, userId string, sessionId string, ttl time.Duration) error {
+	s.usersMu.Lock()
+	defer s.usersMu.Unlock()
+	v, err := s.users.GetOrSetFunc(ctx, userId, func(ctx context.Context) (any, error) {
+		return gset.NewStrSet(true), nil
+	}, ttl)
+	if err != nil {
+		return err
+	}
+	v.Val().(*gset.StrSet).Add(sessionId)
+	return s.doUpdateUserTTL(ctx, userId, ttl)
+}
+
+// RemoveUserSession removes session `sessionId` from the index of user `userId`.
+func (s *StorageMemory) RemoveUserSession(ctx context.Context, userId string, sessionId string) error {
+	s.usersMu.Lock()
+	defer s.usersMu.Unlock()
+	v, err := s.users.Get(ctx, userId)
+	if err != nil || v == nil {
+		return err
+	}
+	set := v.Val().(*gset.StrSet)
+	set.Remove(sessionId)
+	if set.Size() == 0 {
+		_, err = s.users.Remove(ctx, userId)
+	}
+	return err
+}
+
+// GetUserSessions returns the session ids in the index of user `userId`.
+func (s *StorageMemory) GetUserSessions(ctx context.Context, userId string) ([]string, error) {
+	v, err := s.users.Get(ctx, userId)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return v.Val().(*gset.StrSet).Slice(), nil
+}
+
+// updateUserTTL extends the TTL of the index of user `userId` to `ttl` if the user index exists.
+func (s *StorageMemory) updateUserTTL(ctx context.Context, userId string, ttl time.Duration) {
+	if userId == "" {
+		return
+	}
+	s.usersMu.Lock()
+	defer s.usersMu.Unlock()
+	if err := s.doUpdateUserTTL(ctx, userId, ttl); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+}
+
+// doUpdateUserTTL extends the TTL of the index of user `userId` to `ttl`,
+// which never shortens the TTL of the index as it is shared by all sessions of the user.
+func (s *StorageMemory) doUpdateUserTTL(ctx context.Context, userId string, ttl time.Duration) error {
+	expire, err := s.users.GetExpire(ctx, userId)
+	if err != nil || expire <= 0 || expire >= ttl {
+		return err
+	}
+	_, err = s.users.UpdateExpire(ctx, userId, ttl)
 	return err
 }
@@ -20,6 +20,7 @@ import (
 // StorageRedis implements the Session Storage interface with redis.
 type StorageRedis struct {
 	StorageBase
+	storageRedisUserIndex
 	redis         *gredis.Redis   // Redis client for session storage.
 	prefix        string          // Redis key prefix for session id.
 	updatingIdMap *gmap.StrIntMap // Updating TTL set for session id.
@@ -44,6 +45,10 @@ func NewStorageRedis(redis *gredis.Redis, prefix ...string) *StorageRedis {
 	if len(prefix) > 0 && prefix[0] != "" {
 		s.prefix = prefix[0]
 	}
+	s.storageRedisUserIndex = storageRedisUserIndex{
+		redis:  s.redis,
+		prefix: s.prefix,
+	}
 	// Batch updates the TTL for session ids timely.
 	gtimer.AddSingleton(context.Background(), DefaultStorageRedisLoopInterval, func(ctx context.Context) {
 		intlog.Print(context.TODO(), "StorageRedis.timer start")
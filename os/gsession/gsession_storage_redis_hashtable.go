@@ -18,6 +18,7 @@ import (
 // StorageRedisHashTable implements the Session Storage interface with redis hash table.
 type StorageRedisHashTable struct {
 	StorageBase
+	storageRedisUserIndex
 	redis  *gredis.Redis // Redis client for session storage.
 	prefix string        // Redis key prefix for session id.
 }
@@ -34,6 +35,10 @@ func NewStorageRedisHashTable(redis *gredis.Redis, prefix ...string) *StorageRed
 	if len(prefix) > 0 && prefix[0] != "" {
 		s.prefix = prefix[0]
 	}
+	s.storageRedisUserIndex = storageRedisUserIndex{
+		redis:  s.redis,
+		prefix: s.prefix,
+	}
 	return s
 }
 
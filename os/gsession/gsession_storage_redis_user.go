@@ -0,0 +1,56 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gredis"
+)
+
+// storageRedisUserIndex implements the StorageUserIndex interface with redis set,
+// which is shared by StorageRedis and StorageRedisHashTable.
+type storageRedisUserIndex struct {
+	redis  *gredis.Redis // Redis client for session storage.
+	prefix string        // Redis key prefix for session id.
+}
+
+// AddUserSession adds session `sessionId` to the index of user `userId`.
+// The TTL of the index is refreshed to `ttl`, so that the index expires along with the latest session.
+func (s storageRedisUserIndex) AddUserSession(ctx context.Context, userId string, sessionId string, ttl time.Duration) error {
+	var key = s.userIdToRedisKey(userId)
+	if _, err := s.redis.SAdd(ctx, key, sessionId); err != nil {
+		return err
+	}
+	if ttl > 0 {
+		if _, err := s.redis.Expire(ctx, key, int64(ttl.Seconds())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveUserSession removes session `sessionId` from the index of user `userId`.
+func (s storageRedisUserIndex) RemoveUserSession(ctx context.Context, userId string, sessionId string) error {
+	_, err := s.redis.SRem(ctx, s.userIdToRedisKey(userId), sessionId)
+	return err
+}
+
+// GetUserSessions returns the session ids in the index of user `userId`.
+func (s storageRedisUserIndex) GetUserSessions(ctx context.Context, userId string) ([]string, error) {
+	v, err := s.redis.SMembers(ctx, s.userIdToRedisKey(userId))
+	if err != nil {
+		return nil, err
+	}
+	return v.Strings(), nil
+}
+
+// userIdToRedisKey converts and returns the redis key of user index for given user id.
+func (s storageRedisUserIndex) userIdToRedisKey(userId string) string {
+	return s.prefix + "user:" + userId
+}
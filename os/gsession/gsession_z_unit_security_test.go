@@ -0,0 +1,322 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gsession"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Security_AbsoluteTTL(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = context.TODO()
+			manager = gsession.New(time.Hour, gsession.NewStorageMemory())
+		)
+		manager.SetAbsoluteTTL(time.Second)
+		t.Assert(manager.GetAbsoluteTTL(), time.Second)
+
+		s := manager.New(ctx)
+		t.AssertNil(s.Set("k", "v"))
+		sessionId := s.MustId()
+		t.AssertNil(s.Close())
+
+		// Reserved metadata keys are invisible.
+		s = manager.New(ctx, sessionId)
+		t.Assert(s.MustGet("k"), "v")
+		t.Assert(s.MustSize(), 1)
+		t.Assert(s.MustData(), map[string]any{"k": "v"})
+		t.AssertNil(s.Close())
+
+		// It is invalidated even it is active.
+		time.Sleep(1100 * time.Millisecond)
+		s = manager.New(ctx, sessionId)
+		t.Assert(s.MustGet("k"), nil)
+		t.Assert(s.MustSize(), 0)
+		t.AssertNil(s.Close())
+	})
+}
+
+func Test_Security_AbsoluteTTL_StorageFile(t *testing.T) {
+	path := gfile.Temp(gtime.TimestampNanoStr())
+	defer gfile.RemoveAll(path)
+	if err := gfile.Mkdir(path); err != nil {
+		t.Fatal(err)
+	}
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = context.TODO()
+			manager = gsession.New(time.Hour, gsession.NewStorageFile(path, time.Hour))
+		)
+		manager.SetAbsoluteTTL(time.Second)
+
+		s := manager.New(ctx)
+		t.AssertNil(s.Set("k", "v"))
+		sessionId := s.MustId()
+		t.AssertNil(s.Close())
+
+		// The expired session is deleted and a new session id is created.
+		time.Sleep(1100 * time.Millisecond)
+		s = manager.New(ctx, sessionId)
+		t.AssertNE(s.MustId(), sessionId)
+		t.Assert(s.MustGet("k"), nil)
+		t.Assert(s.IsDirty(), true)
+		t.AssertNil(s.Close())
+		t.Assert(gfile.Exists(gfile.Join(path, sessionId+".session")), false)
+	})
+}
+
+func Test_Security_Fingerprint(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = context.TODO()
+			manager = gsession.New(time.Hour, gsession.NewStorageMemory())
+		)
+		manager.SetFingerprint(gsession.FingerprintConfig{
+			UserAgent:  true,
+			IPv4Prefix: 24,
+			IPv6Prefix: 64,
+		})
+		t.Assert(manager.GetFingerprint().IsEnabled(), true)
+		t.Assert(
+			manager.Fingerprint("agent", "192.168.1.1"),
+			manager.Fingerprint("agent", "192.168.1.100"),
+		)
+		t.Assert(
+			manager.Fingerprint("agent", "2001:db8::1"),
+			manager.Fingerprint("agent", "2001:db8::ffff"),
+		)
+		t.AssertNE(
+			manager.Fingerprint("agent", "192.168.1.1"),
+			manager.Fingerprint("agent", "192.168.2.1"),
+		)
+		t.AssertNE(
+			manager.Fingerprint("agent", "192.168.1.1"),
+			manager.Fingerprint("other", "192.168.1.1"),
+		)
+
+		s := manager.New(ctx)
+		t.AssertNil(s.SetFingerprint("agent", "192.168.1.1"))
+		t.AssertNil(s.Set("k", "v"))
+		sessionId := s.MustId()
+		t.AssertNE(s.SetFingerprint("agent", "192.168.1.1"), nil)
+		t.AssertNil(s.Close())
+
+		// Same network.
+		s = manager.New(ctx, sessionId)
+		t.AssertNil(s.SetFingerprint("agent", "192.168.1.2"))
+		t.Assert(s.MustId(), sessionId)
+		t.Assert(s.MustGet("k"), "v")
+		t.AssertNil(s.Close())
+
+		// Mismatched client.
+		s = manager.New(ctx, sessionId)
+		t.AssertNil(s.SetFingerprint("other", "192.168.1.2"))
+		t.AssertNE(s.MustId(), sessionId)
+		t.Assert(s.MustGet("k"), nil)
+		t.AssertNil(s.Close())
+
+		// The old session is deleted.
+		s = manager.New(ctx, sessionId)
+		t.AssertNil(s.SetFingerprint("agent", "192.168.1.2"))
+		t.Assert(s.MustGet("k"), nil)
+	})
+}
+
+func Test_Security_RotateKeys(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = context.TODO()
+			manager = gsession.New(time.Hour, gsession.NewStorageMemory())
+		)
+		manager.SetRotateKeys("role")
+		t.Assert(manager.GetRotateKeys(), []string{"role"})
+
+		// New session does not rotate.
+		s := manager.New(ctx)
+		t.AssertNil(s.Set("k", "v"))
+		sessionId := s.MustId()
+		t.AssertNil(s.Set("role", "guest"))
+		t.Assert(s.MustId(), sessionId)
+		t.AssertNil(s.Close())
+
+		// Normal key does not rotate.
+		s = manager.New(ctx, sessionId)
+		t.AssertNil(s.Set("k", "v2"))
+		t.Assert(s.MustId(), sessionId)
+		t.AssertNil(s.Close())
+
+		// Privilege key rotates.
+		s = manager.New(ctx, sessionId)
+		t.AssertNil(s.Set("role", "admin"))
+		newId := s.MustId()
+		t.AssertNE(newId, sessionId)
+		t.Assert(s.MustGet("k"), "v2")
+		t.Assert(s.MustGet("role"), "admin")
+		t.AssertNil(s.Close())
+
+		s = manager.New(ctx, sessionId)
+		t.Assert(s.MustGet("k"), nil)
+		s = manager.New(ctx, newId)
+		t.Assert(s.MustGet("role"), "admin")
+	})
+}
+
+func Test_Security_UserSessions(t *testing.T) {
+	path := gfile.Temp(gtime.TimestampNanoStr())
+	defer gfile.RemoveAll(path)
+	if err := gfile.Mkdir(path); err != nil {
+		t.Fatal(err)
+	}
+
+	storages := map[string]gsession.Storage{
+		"memory": gsession.NewStorageMemory(),
+		"file":   gsession.NewStorageFile(path, time.Hour),
+	}
+	for name, storage := range storages {
+		gtest.C(t, func(t *gtest.T) {
+			var (
+				ctx     = context.TODO()
+				manager = gsession.New(time.Hour, storage)
+				userId  = "user-" + name
+			)
+			// Anonymous session.
+			s := manager.New(ctx)
+			t.AssertNil(s.Set("k", "v"))
+			anonymousId := s.MustId()
+			t.AssertNil(s.Close())
+
+			// Login rotates the session id.
+			s = manager.New(ctx, anonymousId)
+			t.AssertNil(s.SetUser(userId))
+			id1 := s.MustId()
+			t.AssertNE(id1, anonymousId)
+			t.Assert(s.MustGet("k"), "v")
+			t.Assert(s.MustData(), map[string]any{"k": "v"})
+			user, err := s.GetUser()
+			t.AssertNil(err)
+			t.Assert(user, userId)
+			t.AssertNil(s.Close())
+
+			// Another device.
+			s = manager.New(ctx)
+			t.AssertNil(s.SetUser(userId))
+			id2 := s.MustId()
+			t.AssertNil(s.Close())
+
+			ids, err := manager.UserSessions(ctx, userId)
+			t.AssertNil(err)
+			t.Assert(len(ids), 2)
+			t.AssertIN(id1, ids)
+			t.AssertIN(id2, ids)
+
+			// Logout.
+			s = manager.New(ctx, id2)
+			t.AssertNil(s.SetUser(""))
+			user, err = s.GetUser()
+			t.AssertNil(err)
+			t.Assert(user, "")
+			t.AssertNil(s.Close())
+			ids, err = manager.UserSessions(ctx, userId)
+			t.AssertNil(err)
+			t.Assert(ids, []string{id1})
+
+			// Revoke.
+			t.AssertNil(manager.RevokeUserSessions(ctx, userId))
+			ids, err = manager.UserSessions(ctx, userId)
+			t.AssertNil(err)
+			t.Assert(len(ids), 0)
+			s = manager.New(ctx, id1)
+			t.Assert(s.MustGet("k"), nil)
+		})
+	}
+}
+
+func Test_Security_UserSessions_Size(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = context.TODO()
+			manager = gsession.New(time.Hour, gsession.NewStorageMemory())
+		)
+		s := manager.New(ctx)
+		t.AssertNil(s.Set("k", "v"))
+		t.Assert(s.MustSize(), 1)
+		t.AssertNil(s.SetUser("user"))
+		t.Assert(s.MustSize(), 1)
+		t.AssertNil(s.Close())
+
+		s = manager.New(ctx, s.MustId())
+		t.Assert(s.MustSize(), 1)
+		t.AssertNil(s.SetUser(""))
+		t.Assert(s.MustSize(), 1)
+	})
+}
+
+func Test_Security_UserSessions_MemoryExpire(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = context.TODO()
+			storage = gsession.NewStorageMemory()
+		)
+		t.AssertNil(storage.AddUserSession(ctx, "user", "id1", 200*time.Millisecond))
+		t.AssertNil(storage.AddUserSession(ctx, "user", "id2", 100*time.Millisecond))
+		ids, err := storage.GetUserSessions(ctx, "user")
+		t.AssertNil(err)
+		t.Assert(len(ids), 2)
+
+		// The index expires along with the latest session.
+		time.Sleep(150 * time.Millisecond)
+		ids, err = storage.GetUserSessions(ctx, "user")
+		t.AssertNil(err)
+		t.Assert(len(ids), 2)
+		time.Sleep(100 * time.Millisecond)
+		ids, err = storage.GetUserSessions(ctx, "user")
+		t.AssertNil(err)
+		t.Assert(len(ids), 0)
+	})
+	// The index is refreshed along with the session TTL.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = context.TODO()
+			manager = gsession.New(200*time.Millisecond, gsession.NewStorageMemory())
+		)
+		s := manager.New(ctx)
+		t.AssertNil(s.SetUser("user"))
+		sessionId := s.MustId()
+		t.AssertNil(s.Close())
+
+		time.Sleep(150 * time.Millisecond)
+		s = manager.New(ctx, sessionId)
+		t.Assert(s.MustGet("k"), nil)
+		t.AssertNil(s.Close())
+
+		time.Sleep(150 * time.Millisecond)
+		ids, err := manager.UserSessions(ctx, "user")
+		t.AssertNil(err)
+		t.Assert(ids, []string{sessionId})
+	})
+}
+
+func Test_Security_UserSessions_NotSupported(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = context.TODO()
+			manager = gsession.New(time.Hour, &gsession.StorageBase{})
+		)
+		_, err := manager.UserSessions(ctx, "user")
+		t.Assert(gerror.Code(err), gcode.CodeNotSupported)
+		t.Assert(gerror.Code(manager.RevokeUserSessions(ctx, "user")), gcode.CodeNotSupported)
+	})
+}
@@ -153,6 +153,8 @@ func New(path ...string) *View {
 		"minus":      view.buildInFuncMinus,
 		"times":      view.buildInFuncTimes,
 		"divide":     view.buildInFuncDivide,
+		"tn":         view.buildInFuncTn,
+		"ta":         view.buildInFuncTa,
 	})
 	return view
 }
@@ -312,3 +312,20 @@ func (view *View) buildInFuncDivide(value any, values ...any) string {
 	}
 	return gconv.String(result)
 }
+
+// buildInFuncTn implements build-in template function: tn
+// It translates the content of `key` in plural form for `count` with named arguments `args`,
+// eg: {{tn "apple" .count}}, {{tn "apple" .count .user}}.
+//
+// Note that it is replaced with the one bound to the language of template in parsing.
+func (view *View) buildInFuncTn(key any, count any, args ...any) string {
+	return view.i18nTranslatePlural(context.TODO(), key, count, args...)
+}
+
+// buildInFuncTa implements build-in template function: ta
+// It translates the content of `key` with named arguments `args`, eg: {{ta "welcome" .user}}.
+//
+// Note that it is replaced with the one bound to the language of template in parsing.
+func (view *View) buildInFuncTa(key any, args ...any) string {
+	return view.i18nTranslateArgs(context.TODO(), key, args...)
+}
@@ -20,15 +20,58 @@ const (
 // i18nTranslate translate the content with i18n feature.
 func (view *View) i18nTranslate(ctx context.Context, content string, variables Params) string {
 	if view.config.I18nManager != nil {
-		// Compatible with old version.
-		if language, ok := variables[i18nLanguageVariableName]; ok {
-			ctx = gi18n.WithLanguage(ctx, gconv.String(language))
-		}
-		return view.config.I18nManager.T(ctx, content)
+		return view.config.I18nManager.TranslateContent(view.i18nCtx(ctx, variables), content)
 	}
 	return content
 }
 
+// i18nCtx returns the context with language specified by template variables.
+func (view *View) i18nCtx(ctx context.Context, variables Params) context.Context {
+	// Compatible with old version.
+	if language, ok := variables[i18nLanguageVariableName]; ok {
+		ctx = gi18n.WithLanguage(ctx, gconv.String(language))
+	}
+	return ctx
+}
+
+// i18nFuncMap returns the i18n template functions bound to the language of `ctx` and `variables`.
+func (view *View) i18nFuncMap(ctx context.Context, variables Params) map[string]any {
+	ctx = view.i18nCtx(ctx, variables)
+	return map[string]any{
+		"tn": func(key any, count any, args ...any) string {
+			return view.i18nTranslatePlural(ctx, key, count, args...)
+		},
+		"ta": func(key any, args ...any) string {
+			return view.i18nTranslateArgs(ctx, key, args...)
+		},
+	}
+}
+
+// i18nTranslatePlural translates the content of `key` in plural form for `count`,
+// the optional `args` is the named arguments for formatting.
+func (view *View) i18nTranslatePlural(ctx context.Context, key any, count any, args ...any) string {
+	if view.config.I18nManager == nil {
+		return gconv.String(key)
+	}
+	var arguments map[string]any
+	if len(args) > 0 {
+		arguments = gconv.Map(args[0])
+	}
+	return view.config.I18nManager.Tn(ctx, gconv.String(key), count, arguments)
+}
+
+// i18nTranslateArgs translates the content of `key` with named arguments `args`.
+func (view *View) i18nTranslateArgs(ctx context.Context, key any, args ...any) string {
+	if view.config.I18nManager == nil {
+		return gconv.String(key)
+	}
+	var arguments map[string]any
+	if len(args) > 0 {
+		arguments = gconv.Map(args[0])
+	}
+	return view.config.I18nManager.Ta(ctx, gconv.String(key), arguments)
+}
+
 // setI18nLanguageFromCtx retrieves language name from context and sets it to template variables map.
 func (view *View) setI18nLanguageFromCtx(ctx context.Context, variables map[string]any) {
 	if _, ok := variables[i18nLanguageVariableName]; !ok {
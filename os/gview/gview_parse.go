@@ -257,12 +257,26 @@ func (view *View) doParseContentWithStdTemplate(ctx context.Context, tpl any, pa
 			err = gerror.Wrapf(err, `template clone failed`)
 			return "", err
 		}
+		if view.config.I18nManager != nil {
+			newTpl.Funcs(view.i18nFuncMap(ctx, variables))
+		}
 		if err = newTpl.Execute(buffer, variables); err != nil {
 			err = gerror.Wrapf(err, `template parsing failed`)
 			return "", err
 		}
 	} else {
-		if err := tpl.(*texttpl.Template).Execute(buffer, variables); err != nil {
+		newTpl := tpl.(*texttpl.Template)
+		// The i18n functions bound to the language of template are injected into a clone,
+		// which is unnecessary if there's no i18n manager.
+		if view.config.I18nManager != nil {
+			var err error
+			if newTpl, err = newTpl.Clone(); err != nil {
+				err = gerror.Wrapf(err, `template clone failed`)
+				return "", err
+			}
+			newTpl.Funcs(view.i18nFuncMap(ctx, variables))
+		}
+		if err := newTpl.Execute(buffer, variables); err != nil {
 			err = gerror.Wrapf(err, `template parsing failed`)
 			return "", err
 		}
@@ -113,3 +113,47 @@ func Test_I18n(t *testing.T) {
 	})
 
 }
+
+func Test_I18n_PluralAndArgs(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			view    = gview.New()
+			content = `{{tn "apple" .count}}, {{ta "welcome" .user}} {#hello}`
+			params  = g.Map{
+				"count": 5,
+				"user":  g.Map{"name": "john"},
+			}
+		)
+		view.SetI18n(gi18n.New(gi18n.Options{
+			Path:      gtest.DataPath("i18n-plural"),
+			Fallbacks: map[string][]string{"ru": {"en"}},
+		}))
+
+		result, err := view.ParseContent(context.TODO(), content, params)
+		t.AssertNil(err)
+		t.Assert(result, `5 apples, Welcome, john! Hello`)
+
+		// Language from context.
+		result, err = view.ParseContent(gi18n.WithLanguage(context.TODO(), "ru"), content, params)
+		t.AssertNil(err)
+		t.Assert(result, `5 яблок, Welcome, john! Hello`)
+
+		// Language from template variable.
+		params["I18nLanguage"] = "ru"
+		params["count"] = 2
+		result, err = view.ParseContent(context.TODO(), content, params)
+		t.AssertNil(err)
+		t.Assert(result, `2 яблока, Welcome, john! Hello`)
+	})
+	// Without i18n manager.
+	gtest.C(t, func(t *gtest.T) {
+		view := gview.New()
+		view.SetI18n(nil)
+		for _, autoEncode := range []bool{false, true} {
+			view.SetAutoEncode(autoEncode)
+			result, err := view.ParseContent(context.TODO(), `{{tn "apple" .count}}, {{ta "welcome"}}`, g.Map{"count": 5})
+			t.AssertNil(err)
+			t.Assert(result, `apple, welcome`)
+		}
+	})
+}
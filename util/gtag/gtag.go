@@ -50,6 +50,10 @@ const (
 	Status               = "status"          // Response status code, usually for OpenAPI in response struct.
 	ResponseExample      = "responseExample" // Response example resource path, usually for OpenAPI in response struct.
 	ResponseExampleShort = "resEg"           // Short name of ResponseExample.
+	Const                = "const"           // Const value for struct field, usually for OpenAPI 3.1 schema.
+	OneOf                = "oneOf"           // OneOf field names of struct in g.Meta, usually for OpenAPI schema composition.
+	AnyOf                = "anyOf"           // AnyOf field names of struct in g.Meta, usually for OpenAPI schema composition.
+	Discriminator        = "discriminator"   // Discriminator property name for OneOf/AnyOf in g.Meta, usually for OpenAPI schema.
 )
 
 // StructTagPriority defines the default priority tags for Map*/Struct* functions.
@@ -47,3 +47,22 @@ func TestValidator_I18n(t *testing.T) {
 		t.Assert(err.String(), "项目ID必须大于等于1并且要小于等于10000")
 	})
 }
+
+func TestValidator_I18n_Fallbacks(t *testing.T) {
+	var (
+		err         gvalid.Error
+		i18nManager = gi18n.New(gi18n.Options{
+			Path:      gtest.DataPath("i18n"),
+			Fallbacks: map[string][]string{"tw": {"cn", "en"}},
+		})
+		ctxTw     = gi18n.WithLanguage(context.TODO(), "tw")
+		validator = gvalid.New().I18n(i18nManager)
+	)
+	gtest.C(t, func(t *gtest.T) {
+		err = validator.Rules("required").Data("").Run(ctxTw)
+		t.Assert(err.String(), "字段不能为空")
+
+		err = validator.Rules("required").Messages("CustomMessage").Data("").Run(ctxTw)
+		t.Assert(err.String(), "自定义错误")
+	})
+}